@@ -27,6 +27,8 @@ import (
 	ghostConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/ghost"
 	goferConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/gofer"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
+	metricsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/metrics"
+	tracingConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/tracing"
 	transportConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
@@ -42,6 +44,8 @@ type Config struct {
 	Ghost     ghostConfig.Ghost         `json:"ghost"`
 	Feeds     feedsConfig.Feeds         `json:"feeds"`
 	Logger    loggerConfig.Logger       `json:"logger"`
+	Metrics   metricsConfig.Metrics     `json:"metrics"`
+	Tracing   tracingConfig.Tracing     `json:"tracing"`
 }
 
 func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor, error) {
@@ -68,6 +72,10 @@ func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor
 	if err != nil {
 		return nil, fmt.Errorf(`gofer config error: %w`, err)
 	}
+	hook, err := opts.Config.Gofer.ConfigurePriceHook(ctx, cli)
+	if err != nil {
+		return nil, fmt.Errorf(`price hook config error: %w`, err)
+	}
 
 	if sig.Address() == ethereum.EmptyAddress {
 		return nil, errors.New("ethereum account must be configured")
@@ -91,6 +99,7 @@ func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor
 	}
 	gho, err := opts.Config.Ghost.Configure(ghostConfig.Dependencies{
 		Gofer:     gof,
+		PriceHook: hook,
 		Signer:    sig,
 		Transport: tra,
 		Logger:    log,
@@ -98,8 +107,22 @@ func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor
 	if err != nil {
 		return nil, fmt.Errorf(`ghost config error: %w`, err)
 	}
+	met, err := opts.Config.Metrics.Configure(metricsConfig.Dependencies{Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`metrics config error: %w`, err)
+	}
+	trc, err := opts.Config.Tracing.Configure(tracingConfig.Dependencies{AppName: "ghost", Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`tracing config error: %w`, err)
+	}
 	sup := supervisor.New(log)
+	if trc != nil {
+		sup.Watch(trc)
+	}
 	sup.Watch(tra, gho, sysmon.New(time.Minute, log))
+	if met != nil {
+		sup.Watch(met)
+	}
 	if g, ok := gof.(supervisor.Service); ok {
 		sup.Watch(g)
 	}
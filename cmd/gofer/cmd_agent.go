@@ -28,7 +28,7 @@ func NewAgentCmd(opts *options) *cobra.Command {
 		Use:   "agent",
 		Args:  cobra.NoArgs,
 		Short: "Start an RPC server",
-		Long:  `Start an RPC server.`,
+		Long:  `Start an RPC server and, if configured, an HTTP JSON API.`,
 		RunE: func(_ *cobra.Command, args []string) error {
 			ctx, _ := signal.NotifyContext(context.Background(), os.Interrupt)
 			sup, err := PrepareAgentServices(ctx, opts)
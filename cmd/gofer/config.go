@@ -27,6 +27,8 @@ import (
 	ethereumConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 	goferConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/gofer"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
+	metricsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/metrics"
+	tracingConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/tracing"
 	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 	"github.com/chronicleprotocol/oracle-suite/pkg/sysmon"
 )
@@ -35,6 +37,8 @@ type Config struct {
 	Ethereum ethereumConfig.Ethereum `json:"ethereum"`
 	Gofer    goferConfig.Gofer       `json:"gofer"`
 	Logger   loggerConfig.Logger     `json:"logger"`
+	Metrics  metricsConfig.Metrics   `json:"metrics"`
+	Tracing  tracingConfig.Tracing   `json:"tracing"`
 }
 
 func PrepareClientServices(
@@ -103,8 +107,29 @@ func PrepareAgentServices(ctx context.Context, opts *options) (*supervisor.Super
 	if err != nil {
 		return nil, fmt.Errorf(`gofer config error: %w`, err)
 	}
+	api, err := opts.Config.Gofer.ConfigurePriceAPI(gof, log)
+	if err != nil {
+		return nil, fmt.Errorf(`gofer config error: %w`, err)
+	}
+	met, err := opts.Config.Metrics.Configure(metricsConfig.Dependencies{Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`metrics config error: %w`, err)
+	}
+	trc, err := opts.Config.Tracing.Configure(tracingConfig.Dependencies{AppName: "gofer", Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`tracing config error: %w`, err)
+	}
 	sup := supervisor.New(log)
+	if trc != nil {
+		sup.Watch(trc)
+	}
 	sup.Watch(gof.(supervisor.Service), age, sysmon.New(time.Minute, log))
+	if met != nil {
+		sup.Watch(met)
+	}
+	if api != nil {
+		sup.Watch(api)
+	}
 	if l, ok := log.(supervisor.Service); ok {
 		sup.Watch(l)
 	}
@@ -37,6 +37,7 @@ func main() {
 		NewPairsCmd(&opts),
 		NewPricesCmd(&opts),
 		NewAgentCmd(&opts),
+		NewHealthCmd(&opts),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
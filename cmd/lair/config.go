@@ -24,6 +24,7 @@ import (
 	eventAPIConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/eventapi"
 	feedsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/feeds"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
+	metricsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/metrics"
 	transportConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/geth"
 	"github.com/chronicleprotocol/oracle-suite/pkg/event/publisher/teleportevm"
@@ -40,6 +41,7 @@ type Config struct {
 	Transport transportConfig.Transport `json:"transport"`
 	Feeds     feedsConfig.Feeds         `json:"feeds"`
 	Logger    loggerConfig.Logger       `json:"logger"`
+	Metrics   metricsConfig.Metrics     `json:"metrics"`
 }
 
 func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor, error) {
@@ -89,8 +91,15 @@ func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor
 	if err != nil {
 		return nil, fmt.Errorf(`lair config error: %w`, err)
 	}
+	met, err := opts.Config.Metrics.Configure(metricsConfig.Dependencies{Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`metrics config error: %w`, err)
+	}
 	sup := supervisor.New(log)
 	sup.Watch(tra, evs, api, sysmon.New(time.Minute, log))
+	if met != nil {
+		sup.Watch(met)
+	}
 	if l, ok := log.(supervisor.Service); ok {
 		sup.Watch(l)
 	}
@@ -25,6 +25,7 @@ import (
 	leelooConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/eventpublisher"
 	feedsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/feeds"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
+	metricsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/metrics"
 	transportConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 	"github.com/chronicleprotocol/oracle-suite/pkg/sysmon"
@@ -38,6 +39,7 @@ type Config struct {
 	Transport transportConfig.Transport   `json:"transport"`
 	Feeds     feedsConfig.Feeds           `json:"feeds"`
 	Logger    loggerConfig.Logger         `json:"logger"`
+	Metrics   metricsConfig.Metrics       `json:"metrics"`
 }
 
 func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor, error) {
@@ -78,8 +80,15 @@ func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor
 	if err != nil {
 		return nil, fmt.Errorf(`leeloo config error: %w`, err)
 	}
+	met, err := opts.Config.Metrics.Configure(metricsConfig.Dependencies{Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`metrics config error: %w`, err)
+	}
 	sup := supervisor.New(log)
 	sup.Watch(tra, lee, sysmon.New(time.Minute, log))
+	if met != nil {
+		sup.Watch(met)
+	}
 	if l, ok := log.(supervisor.Service); ok {
 		sup.Watch(l)
 	}
@@ -24,6 +24,7 @@ import (
 
 type options struct {
 	Listen             string
+	MetricsListen      string
 	EnableCORS         bool
 	GracefulTimeoutSec int
 	TotalTimeoutSec    int
@@ -50,6 +51,12 @@ func NewRootCommand(opts *options) *cobra.Command {
 		"127.0.0.1:8545",
 		"listen address",
 	)
+	rootCmd.PersistentFlags().StringVar(
+		&opts.MetricsListen,
+		"metrics-listen",
+		"",
+		"listen address for the Prometheus metrics endpoint, disabled if empty",
+	)
 	rootCmd.PersistentFlags().BoolVarP(
 		&opts.EnableCORS,
 		"enable-cors",
@@ -27,6 +27,7 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
 	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver/middleware"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
 	"github.com/chronicleprotocol/oracle-suite/pkg/rpcsplitter"
 )
 
@@ -77,6 +78,19 @@ func NewRunCmd(opts *options) *cobra.Command {
 				return fmt.Errorf("unable to start the HTTP server: %w", err)
 			}
 
+			if opts.MetricsListen != "" {
+				met, err := metrics.New(metrics.Config{
+					Address: opts.MetricsListen,
+					Logger:  log,
+				})
+				if err != nil {
+					return err
+				}
+				if err := met.Start(ctx); err != nil {
+					return err
+				}
+			}
+
 			defer func() {
 				err := <-srv.Wait()
 				if err != nil {
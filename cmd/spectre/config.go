@@ -24,7 +24,9 @@ import (
 	ethereumConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 	feedsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/feeds"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
+	metricsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/metrics"
 	spectreConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/spectre"
+	tracingConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/tracing"
 	transportConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
 	"github.com/chronicleprotocol/oracle-suite/pkg/sysmon"
@@ -38,6 +40,8 @@ type Config struct {
 	Spectre   spectreConfig.Spectre     `json:"spectre"`
 	Feeds     feedsConfig.Feeds         `json:"feeds"`
 	Logger    loggerConfig.Logger       `json:"logger"`
+	Metrics   metricsConfig.Metrics     `json:"metrics"`
+	Tracing   tracingConfig.Tracing     `json:"tracing"`
 }
 
 func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor, error) {
@@ -95,8 +99,22 @@ func PrepareServices(ctx context.Context, opts *options) (*supervisor.Supervisor
 	if err != nil {
 		return nil, fmt.Errorf(`spectre config error: %w`, err)
 	}
+	met, err := opts.Config.Metrics.Configure(metricsConfig.Dependencies{Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`metrics config error: %w`, err)
+	}
+	trc, err := opts.Config.Tracing.Configure(tracingConfig.Dependencies{AppName: "spectre", Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`tracing config error: %w`, err)
+	}
 	sup := supervisor.New(log)
+	if trc != nil {
+		sup.Watch(trc)
+	}
 	sup.Watch(tra, pst, spe, sysmon.New(time.Minute, log))
+	if met != nil {
+		sup.Watch(met)
+	}
 	if l, ok := log.(supervisor.Service); ok {
 		sup.Watch(l)
 	}
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -37,6 +38,7 @@ func NewPullCmd(opts *options) *cobra.Command {
 	cmd.AddCommand(
 		NewPullPriceCmd(opts),
 		NewPullPricesCmd(opts),
+		NewPullHistoryCmd(opts),
 	)
 
 	return cmd
@@ -137,3 +139,109 @@ func NewPullPricesCmd(opts *options) *cobra.Command {
 
 	return cmd
 }
+
+type pullHistoryOptions struct {
+	FilterPair string
+	FilterFrom string
+	From       string
+	To         string
+	Interval   time.Duration
+}
+
+func NewPullHistoryCmd(opts *options) *cobra.Command {
+	var pullHistoryOpts pullHistoryOptions
+
+	cmd := &cobra.Command{
+		Use:   "history",
+		Args:  cobra.ExactArgs(0),
+		Short: "",
+		Long:  ``,
+		RunE: func(_ *cobra.Command, args []string) (err error) {
+			from, err := parseTime(pullHistoryOpts.From)
+			if err != nil {
+				return err
+			}
+			to, err := parseTime(pullHistoryOpts.To)
+			if err != nil {
+				return err
+			}
+			ctx, ctxCancel := signal.NotifyContext(context.Background(), os.Interrupt)
+			sup, cli, err := PrepareClientServices(ctx, opts)
+			if err != nil {
+				return err
+			}
+			if err = sup.Start(ctx); err != nil {
+				return err
+			}
+			defer func() {
+				ctxCancel()
+				if sErr := <-sup.Wait(); err == nil { // Ignore sErr if another error has already occurred.
+					err = sErr
+				}
+			}()
+			var res interface{}
+			if pullHistoryOpts.Interval > 0 {
+				res, err = cli.PullOHLC(pullHistoryOpts.FilterPair, pullHistoryOpts.FilterFrom, from, to, pullHistoryOpts.Interval)
+			} else {
+				res, err = cli.PullHistory(pullHistoryOpts.FilterPair, pullHistoryOpts.FilterFrom, from, to)
+			}
+			if err != nil {
+				return err
+			}
+			bts, err := json.Marshal(res)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", string(bts))
+			return
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(
+		&pullHistoryOpts.FilterFrom,
+		"filter.from",
+		"",
+		"feeder address",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&pullHistoryOpts.FilterPair,
+		"filter.pair",
+		"",
+		"asset pair",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&pullHistoryOpts.From,
+		"from",
+		"",
+		"beginning of the time range in RFC3339 format",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&pullHistoryOpts.To,
+		"to",
+		"",
+		"end of the time range in RFC3339 format",
+	)
+
+	cmd.PersistentFlags().DurationVar(
+		&pullHistoryOpts.Interval,
+		"interval",
+		0,
+		"if set, prices are aggregated into OHLC candles of the given interval",
+	)
+
+	return cmd
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: %w", s, err)
+	}
+	return t, nil
+}
@@ -24,7 +24,9 @@ import (
 	ethereumConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/ethereum"
 	feedsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/feeds"
 	loggerConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/logger"
+	metricsConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/metrics"
 	spireConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/spire"
+	tracingConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/tracing"
 	transportConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/spire"
 	"github.com/chronicleprotocol/oracle-suite/pkg/supervisor"
@@ -39,6 +41,8 @@ type Config struct {
 	Spire     spireConfig.Spire         `json:"spire"`
 	Feeds     feedsConfig.Feeds         `json:"feeds"`
 	Logger    loggerConfig.Logger       `json:"logger"`
+	Metrics   metricsConfig.Metrics     `json:"metrics"`
+	Tracing   tracingConfig.Tracing     `json:"tracing"`
 }
 
 func PrepareAgentServices(ctx context.Context, opts *options) (*supervisor.Supervisor, error) {
@@ -93,8 +97,22 @@ func PrepareAgentServices(ctx context.Context, opts *options) (*supervisor.Super
 	if err != nil {
 		return nil, fmt.Errorf(`spire config error: %w`, err)
 	}
+	met, err := opts.Config.Metrics.Configure(metricsConfig.Dependencies{Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`metrics config error: %w`, err)
+	}
+	trc, err := opts.Config.Tracing.Configure(tracingConfig.Dependencies{AppName: "spire", Logger: log})
+	if err != nil {
+		return nil, fmt.Errorf(`tracing config error: %w`, err)
+	}
 	sup := supervisor.New(log)
+	if trc != nil {
+		sup.Watch(trc)
+	}
 	sup.Watch(tra, dat, age, sysmon.New(time.Minute, log))
+	if met != nil {
+		sup.Watch(met)
+	}
 	if l, ok := log.(supervisor.Service); ok {
 		sup.Watch(l)
 	}
@@ -23,7 +23,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
@@ -69,7 +68,7 @@ var ethClientFactory = func(
 		if err != nil {
 			return nil, err
 		}
-		return ethclient.NewClient(rpcClient), nil
+		return geth.NewEthClient(rpcClient), nil
 	}
 }
 
@@ -37,6 +37,7 @@ type Ghost struct {
 
 type Dependencies struct {
 	Gofer     provider.Provider
+	PriceHook provider.PriceHook
 	Signer    ethereum.Signer
 	Transport transport.Transport
 	Logger    log.Logger
@@ -45,6 +46,7 @@ type Dependencies struct {
 func (c *Ghost) Configure(d Dependencies) (*ghost.Ghost, error) {
 	cfg := ghost.Config{
 		PriceProvider: d.Gofer,
+		PriceHook:     d.PriceHook,
 		Signer:        d.Signer,
 		Transport:     d.Transport,
 		Logger:        d.Logger,
@@ -26,9 +26,12 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/api"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/maputil"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/discovery"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/feeder"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/nodes"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/origins"
@@ -69,6 +72,26 @@ type Gofer struct {
 	RPCListenAddr string                `yaml:"rpcListenAddr"`
 	Origins       map[string]Origin     `yaml:"origins"`
 	PriceModels   map[string]PriceModel `yaml:"priceModels"`
+	// HTTPListenAddr is an optional address for the HTTP JSON API served by
+	// the agent. If empty, the API is disabled.
+	HTTPListenAddr string `yaml:"httpListenAddr"`
+	// CircuitBreaker configures when failing origins are temporarily
+	// disabled. If omitted, default values are used.
+	CircuitBreaker *CircuitBreaker `yaml:"circuitBreaker"`
+	// HTTPCacheTTL is the number of seconds for which successful HTTP
+	// responses are shared between origins. If omitted, one second is
+	// used. Zero disables caching.
+	HTTPCacheTTL *int `yaml:"httpCacheTTL"`
+}
+
+type CircuitBreaker struct {
+	// FailureThreshold is the number of consecutive failures after which
+	// an origin is disabled. If zero, origins are never disabled.
+	FailureThreshold int `yaml:"failureThreshold"`
+	// MinBackoff and MaxBackoff are the minimum and maximum number of
+	// seconds for which an origin is disabled.
+	MinBackoff int `yaml:"minBackoff"`
+	MaxBackoff int `yaml:"maxBackoff"`
 }
 
 type RPC struct {
@@ -79,24 +102,64 @@ type Origin struct {
 	Type   string    `yaml:"type"`
 	URL    string    `yaml:"url"` // TODO: Move it to the params field.
 	Params yaml.Node `yaml:"params"`
+	// Pairs is an optional list of pairs supported by the origin. It is used
+	// to discover cross-rate paths, in addition to pairs used in sources of
+	// price models.
+	Pairs []string `yaml:"pairs"`
+	// RateLimit is an optional request quota for hosts used by the origin.
+	RateLimit *RateLimit `yaml:"rateLimit"`
+}
+
+type RateLimit struct {
+	// RequestsPerSecond is the maximum sustained number of requests per
+	// second.
+	RequestsPerSecond float64 `yaml:"requestsPerSecond"`
+	// Burst is the maximum number of requests that may be sent at once.
+	Burst int `yaml:"burst"`
 }
 
 type PriceModel struct {
-	Method  string     `yaml:"method"`
-	Sources [][]Source `yaml:"sources"`
-	Params  yaml.Node  `yaml:"params"`
-	TTL     int        `yaml:"ttl"`
+	Method    string     `yaml:"method"`
+	Sources   [][]Source `yaml:"sources"`
+	Params    yaml.Node  `yaml:"params"`
+	TTL       int        `yaml:"ttl"`
+	Discovery *Discovery `yaml:"discovery"`
+}
+
+// Discovery configures automatic discovery of cross-rate paths. Discovered
+// paths are added to sources of the price model.
+type Discovery struct {
+	// MaxHops is the maximum number of pairs in a single path.
+	MaxHops int `yaml:"maxHops"`
+	// MaxPaths is the maximum number of discovered paths.
+	MaxPaths int `yaml:"maxPaths"`
+	// Bridges is a list of preferred intermediate assets.
+	Bridges []string `yaml:"bridges"`
 }
 
 type MedianPriceModel struct {
 	MinSourceSuccess int                    `yaml:"minimumSuccessfulSources"`
 	PostPriceHook    map[string]interface{} `yaml:"postPriceHook"`
+	// MaxDeviation and MaxZScore configure the outlier filter used by
+	// the median method. Zero values disable the corresponding check.
+	MaxDeviation float64 `yaml:"maxDeviation"`
+	MaxZScore    float64 `yaml:"maxZScore"`
+}
+
+type TrimmedMeanPriceModel struct {
+	MedianPriceModel `yaml:",inline"`
+	// Trim is the fraction of the lowest and the highest prices that are
+	// discarded before calculating the mean.
+	Trim float64 `yaml:"trim"`
 }
 
 type Source struct {
 	Origin string `yaml:"origin"`
 	Pair   string `yaml:"pair"`
 	TTL    int    `yaml:"ttl"`
+	// Weight is used by the weightedMedian method. For sources consisting
+	// of multiple pairs, the weight of the first pair is used.
+	Weight *float64 `yaml:"weight"`
 }
 
 // ConfigureRPCAgent returns a new rpc.Agent instance.
@@ -117,6 +180,23 @@ func (c *Gofer) ConfigureRPCAgent(cli ethereum.Client, gof provider.Provider, lo
 	return srv, nil
 }
 
+// ConfigurePriceAPI returns a new api.PriceAPI instance. If the HTTP listen
+// address is not configured, nil is returned.
+func (c *Gofer) ConfigurePriceAPI(gof provider.Provider, logger log.Logger) (*api.PriceAPI, error) {
+	if c.HTTPListenAddr == "" {
+		return nil, nil
+	}
+	srv, err := api.New(api.Config{
+		Provider: gof,
+		Address:  c.HTTPListenAddr,
+		Logger:   logger,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to initialize HTTP API: %w", err)
+	}
+	return srv, nil
+}
+
 // ConfigureAsyncGofer returns a new async gofer instance.
 func (c *Gofer) ConfigureAsyncGofer(cli ethereum.Client, logger log.Logger) (provider.Provider, error) {
 	gra, err := c.buildGraphs()
@@ -131,7 +211,11 @@ func (c *Gofer) ConfigureAsyncGofer(cli ethereum.Client, logger log.Logger) (pro
 	if err != nil {
 		return nil, err
 	}
-	fed := feeder.NewFeeder(originSet, logger)
+	cbc, err := c.circuitBreakerConfig()
+	if err != nil {
+		return nil, err
+	}
+	fed := feeder.NewFeederWithCircuitBreaker(originSet, logger, cbc)
 	gof, err := graph.NewAsyncProvider(gra, fed, ns, logger)
 	if err != nil {
 		return nil, fmt.Errorf("unable to initialize RPC agent: %w", err)
@@ -143,7 +227,7 @@ func (c *Gofer) ConfigurePriceHook(ctx context.Context, cli ethereum.Client) (pr
 	m := provider.NewHookParams()
 	for name, model := range c.PriceModels {
 		switch model.Method {
-		case "median":
+		case "median", "weightedMedian", "vwap", "trimmedMean":
 			var params MedianPriceModel
 			err := model.Params.Decode(&params)
 			if err != nil {
@@ -173,7 +257,11 @@ func (c *Gofer) ConfigureGofer(cli ethereum.Client, logger log.Logger, noRPC boo
 		if err != nil {
 			return nil, err
 		}
-		fed := feeder.NewFeeder(originSet, logger)
+		cbc, err := c.circuitBreakerConfig()
+		if err != nil {
+			return nil, err
+		}
+		fed := feeder.NewFeederWithCircuitBreaker(originSet, logger, cbc)
 		gof := graph.NewProvider(gra, fed)
 		return gof, nil
 	}
@@ -185,12 +273,57 @@ func (c *Gofer) configureRPCClient(listenAddr string) (*rpc.Provider, error) {
 	return rpc.NewProvider("tcp", listenAddr)
 }
 
+func (c *Gofer) circuitBreakerConfig() (feeder.CircuitBreakerConfig, error) {
+	if c.CircuitBreaker == nil {
+		return feeder.DefaultCircuitBreakerConfig, nil
+	}
+	cb := c.CircuitBreaker
+	if cb.FailureThreshold < 0 || cb.MinBackoff < 0 || cb.MaxBackoff < 0 {
+		return feeder.CircuitBreakerConfig{}, fmt.Errorf("circuit breaker options must not be negative")
+	}
+	if cb.MaxBackoff != 0 && cb.MaxBackoff < cb.MinBackoff {
+		return feeder.CircuitBreakerConfig{}, fmt.Errorf("circuit breaker maxBackoff must not be less than minBackoff")
+	}
+	cfg := feeder.CircuitBreakerConfig{
+		FailureThreshold: cb.FailureThreshold,
+		MinBackoff:       time.Duration(cb.MinBackoff) * time.Second,
+		MaxBackoff:       time.Duration(cb.MaxBackoff) * time.Second,
+	}
+	if cfg.MinBackoff == 0 {
+		cfg.MinBackoff = feeder.DefaultCircuitBreakerConfig.MinBackoff
+	}
+	if cfg.MaxBackoff == 0 {
+		cfg.MaxBackoff = feeder.DefaultCircuitBreakerConfig.MaxBackoff
+	}
+	return cfg, nil
+}
+
 func (c *Gofer) buildOrigins(cli ethereum.Client) (*origins.Set, error) {
 	const defaultWorkerCount = 10
-	wp := query.NewHTTPWorkerPool(defaultWorkerCount)
+	cacheTTL := query.DefaultHTTPCacheTTL
+	if c.HTTPCacheTTL != nil {
+		if *c.HTTPCacheTTL < 0 {
+			return nil, fmt.Errorf("httpCacheTTL must not be negative")
+		}
+		cacheTTL = time.Duration(*c.HTTPCacheTTL) * time.Second
+	}
+	wp := query.NewHTTPWorkerPoolWithConfig(query.HTTPWorkerPoolConfig{
+		WorkerCount: defaultWorkerCount,
+		CacheTTL:    cacheTTL,
+	})
 	originSet := origins.DefaultOriginSet(wp)
 	for name, origin := range c.Origins {
-		handler, err := NewHandler(origin.Type, wp, cli, origin.URL, origin.Params)
+		var owp query.WorkerPool = wp
+		if origin.RateLimit != nil {
+			if origin.RateLimit.RequestsPerSecond <= 0 {
+				return nil, fmt.Errorf("rateLimit.requestsPerSecond must be positive for origin %s", name)
+			}
+			owp = wp.WithRateLimit(query.RateLimit{
+				RequestsPerSecond: origin.RateLimit.RequestsPerSecond,
+				Burst:             origin.RateLimit.Burst,
+			})
+		}
+		handler, err := NewHandler(origin.Type, owp, cli, origin.URL, origin.Params)
 		if err != nil || handler == nil {
 			return nil, fmt.Errorf(
 				"failed to initiate %s origin with name %s due to error: %w", origin.Type, name, err,
@@ -239,7 +372,41 @@ func (c *Gofer) buildRoots(graphs map[provider.Pair]nodes.Aggregator) error {
 			if err := model.Params.Decode(&params); err != nil {
 				return err
 			}
-			graphs[modelPair] = nodes.NewMedianAggregatorNode(modelPair, params.MinSourceSuccess)
+			if params.MaxDeviation < 0 || params.MaxZScore < 0 {
+				return fmt.Errorf("maxDeviation and maxZScore must not be negative for pair %s", name)
+			}
+			graphs[modelPair] = nodes.NewMedianAggregatorNodeWithOutlierFilter(
+				modelPair,
+				params.MinSourceSuccess,
+				nodes.OutlierFilter{MaxDeviation: params.MaxDeviation, MaxZScore: params.MaxZScore},
+			)
+		case "weightedMedian":
+			var params MedianPriceModel
+			if err := model.Params.Decode(&params); err != nil {
+				return err
+			}
+			if params.MaxDeviation != 0 || params.MaxZScore != 0 {
+				return fmt.Errorf("maxDeviation and maxZScore are not supported by the %s method for pair %s", model.Method, name)
+			}
+			graphs[modelPair] = nodes.NewWeightedMedianAggregatorNode(modelPair, params.MinSourceSuccess)
+		case "vwap":
+			var params MedianPriceModel
+			if err := model.Params.Decode(&params); err != nil {
+				return err
+			}
+			if params.MaxDeviation != 0 || params.MaxZScore != 0 {
+				return fmt.Errorf("maxDeviation and maxZScore are not supported by the %s method for pair %s", model.Method, name)
+			}
+			graphs[modelPair] = nodes.NewVWAPAggregatorNode(modelPair, params.MinSourceSuccess)
+		case "trimmedMean":
+			var params TrimmedMeanPriceModel
+			if err := model.Params.Decode(&params); err != nil {
+				return err
+			}
+			if params.Trim < 0 || params.Trim >= 0.5 {
+				return fmt.Errorf("trim must be in the [0, 0.5) range for pair %s", name)
+			}
+			graphs[modelPair] = nodes.NewTrimmedMeanAggregatorNode(modelPair, params.MinSourceSuccess, params.Trim)
 		default:
 			return fmt.Errorf("unknown method %s for pair %s", model.Method, name)
 		}
@@ -249,6 +416,11 @@ func (c *Gofer) buildRoots(graphs map[provider.Pair]nodes.Aggregator) error {
 }
 
 func (c *Gofer) buildBranches(graphs map[provider.Pair]nodes.Aggregator) error {
+	edges, err := c.originPairs()
+	if err != nil {
+		return err
+	}
+
 	for name, model := range c.PriceModels {
 		// We can ignore error here, because it was checked already
 		// in buildRoots method.
@@ -264,7 +436,7 @@ func (c *Gofer) buildBranches(graphs map[provider.Pair]nodes.Aggregator) error {
 			)
 		}
 
-		for _, sources := range model.Sources {
+		for _, sources := range c.modelSources(edges, modelPair, model) {
 			var children []nodes.Node
 			for _, source := range sources {
 				var err error
@@ -300,6 +472,18 @@ func (c *Gofer) buildBranches(graphs map[provider.Pair]nodes.Aggregator) error {
 				node = indirectAggregator
 			}
 
+			if weighted, ok := parent.(*nodes.WeightedMedianAggregatorNode); ok {
+				weight := 1.0
+				if w := sources[0].Weight; w != nil {
+					weight = *w
+				}
+				if weight < 0 {
+					return fmt.Errorf("weight must not be negative for pair %s", name)
+				}
+				weighted.AddChildWithWeight(node, weight)
+				continue
+			}
+
 			parent.AddChild(node)
 		}
 	}
@@ -307,6 +491,80 @@ func (c *Gofer) buildBranches(graphs map[provider.Pair]nodes.Aggregator) error {
 	return nil
 }
 
+// originPairs returns all pairs supported by origins. These are pairs
+// listed in the origins configuration and pairs used by price models.
+func (c *Gofer) originPairs() ([]nodes.OriginPair, error) {
+	var ops []nodes.OriginPair
+	for _, name := range sortedKeys(c.Origins) {
+		for _, s := range c.Origins[name].Pairs {
+			pair, err := provider.NewPair(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pair %s for origin %s: %w", s, name, err)
+			}
+			ops = append(ops, nodes.OriginPair{Origin: name, Pair: pair})
+		}
+	}
+	for _, name := range sortedKeys(c.PriceModels) {
+		for _, sources := range c.PriceModels[name].Sources {
+			for _, source := range sources {
+				if source.Origin == "." {
+					continue
+				}
+				// Invalid pairs are reported when building branches.
+				if pair, err := provider.NewPair(source.Pair); err == nil {
+					ops = append(ops, nodes.OriginPair{Origin: source.Origin, Pair: pair})
+				}
+			}
+		}
+	}
+	return ops, nil
+}
+
+// modelSources returns sources of the price model together with paths
+// discovered for the model, if the discovery is enabled.
+func (c *Gofer) modelSources(edges []nodes.OriginPair, pair provider.Pair, model PriceModel) [][]Source {
+	if model.Discovery == nil {
+		return model.Sources
+	}
+
+	configured := map[string]struct{}{}
+	for _, sources := range model.Sources {
+		configured[sourcesKey(sources)] = struct{}{}
+	}
+
+	all := model.Sources
+	paths := discovery.Paths(edges, pair, discovery.Options{
+		MaxHops:  model.Discovery.MaxHops,
+		MaxPaths: model.Discovery.MaxPaths,
+		Bridges:  model.Discovery.Bridges,
+	})
+	for _, path := range paths {
+		var sources []Source
+		for _, op := range path {
+			sources = append(sources, Source{Origin: op.Origin, Pair: op.Pair.String()})
+		}
+		if _, ok := configured[sourcesKey(sources)]; ok {
+			continue
+		}
+		all = append(all, sources)
+	}
+	return all
+}
+
+func sourcesKey(sources []Source) string {
+	var s []string
+	for _, source := range sources {
+		s = append(s, source.Origin+" "+strings.ToUpper(source.Pair))
+	}
+	return strings.Join(s, " -> ")
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	ks := maputil.Keys(m)
+	sort.Strings(ks)
+	return ks
+}
+
 func (c *Gofer) reference(graphs map[provider.Pair]nodes.Aggregator, source Source) (nodes.Node, error) {
 	sourcePair, err := provider.NewPair(source.Pair)
 	if err != nil {
@@ -16,16 +16,23 @@
 package gofer
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
 	"gopkg.in/yaml.v3"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/feeder"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/nodes"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/mocks"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/origins"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
 
 	ethereumMocks "github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -113,6 +120,162 @@ func TestConfig_buildGraphs_ValidConfig(t *testing.T) {
 	assert.Same(t, c[bc], c[ac].Children()[1].(*nodes.IndirectAggregatorNode).Children()[1])
 }
 
+func TestConfig_buildGraphs_Methods(t *testing.T) {
+	weight := 2.5
+	config := Gofer{
+		PriceModels: map[string]PriceModel{
+			"A/B": {
+				Method: "weightedMedian",
+				Sources: [][]Source{
+					{{Origin: "ab1", Pair: "A/B", Weight: &weight}},
+					{{Origin: "ab2", Pair: "A/B"}},
+				},
+				Params: yamlNode(t, `{"minimumSuccessfulSources": 2}`),
+			},
+			"B/C": {
+				Method:  "vwap",
+				Sources: [][]Source{{{Origin: "bc1", Pair: "B/C"}}},
+				Params:  yamlNode(t, `{"minimumSuccessfulSources": 1}`),
+			},
+			"C/D": {
+				Method:  "trimmedMean",
+				Sources: [][]Source{{{Origin: "cd1", Pair: "C/D"}}},
+				Params:  yamlNode(t, `{"minimumSuccessfulSources": 1, "trim": 0.2}`),
+			},
+		},
+	}
+
+	c, err := config.buildGraphs()
+	require.NoError(t, err)
+
+	ab := provider.Pair{Base: "A", Quote: "B"}
+	bc := provider.Pair{Base: "B", Quote: "C"}
+	cd := provider.Pair{Base: "C", Quote: "D"}
+
+	require.IsType(t, &nodes.WeightedMedianAggregatorNode{}, c[ab])
+	assert.Equal(t, []float64{2.5, 1}, c[ab].(*nodes.WeightedMedianAggregatorNode).Weights())
+	assert.IsType(t, &nodes.VWAPAggregatorNode{}, c[bc])
+	require.IsType(t, &nodes.TrimmedMeanAggregatorNode{}, c[cd])
+	assert.Equal(t, 0.2, c[cd].(*nodes.TrimmedMeanAggregatorNode).Trim())
+}
+
+func TestConfig_buildGraphs_MedianOutlierFilter(t *testing.T) {
+	config := Gofer{
+		PriceModels: map[string]PriceModel{
+			"A/B": {
+				Method:  "median",
+				Sources: [][]Source{{{Origin: "ab1", Pair: "A/B"}}},
+				Params:  yamlNode(t, `{"minimumSuccessfulSources": 1, "maxDeviation": 0.05, "maxZScore": 3.5}`),
+			},
+		},
+	}
+
+	c, err := config.buildGraphs()
+	require.NoError(t, err)
+
+	ab := provider.Pair{Base: "A", Quote: "B"}
+	require.IsType(t, &nodes.MedianAggregatorNode{}, c[ab])
+	assert.Equal(
+		t,
+		nodes.OutlierFilter{MaxDeviation: 0.05, MaxZScore: 3.5},
+		c[ab].(*nodes.MedianAggregatorNode).OutlierFilter(),
+	)
+}
+
+func TestConfig_buildGraphs_InvalidMethodParams(t *testing.T) {
+	weight := -1.0
+	tests := []PriceModel{
+		{
+			Method:  "trimmedMean",
+			Sources: [][]Source{{{Origin: "ab1", Pair: "A/B"}}},
+			Params:  yamlNode(t, `{"trim": 0.5}`),
+		},
+		{
+			Method:  "weightedMedian",
+			Sources: [][]Source{{{Origin: "ab1", Pair: "A/B", Weight: &weight}}},
+		},
+		{
+			Method:  "median",
+			Sources: [][]Source{{{Origin: "ab1", Pair: "A/B"}}},
+			Params:  yamlNode(t, `{"maxDeviation": -1}`),
+		},
+		{
+			Method:  "weightedMedian",
+			Sources: [][]Source{{{Origin: "ab1", Pair: "A/B"}}},
+			Params:  yamlNode(t, `{"maxDeviation": 0.1}`),
+		},
+		{
+			Method:  "vwap",
+			Sources: [][]Source{{{Origin: "ab1", Pair: "A/B"}}},
+			Params:  yamlNode(t, `{"maxZScore": 3}`),
+		},
+		{
+			Method:  "mean",
+			Sources: [][]Source{{{Origin: "ab1", Pair: "A/B"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.Method, func(t *testing.T) {
+			config := Gofer{PriceModels: map[string]PriceModel{"A/B": tt}}
+			_, err := config.buildGraphs()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConfig_buildGraphs_Discovery(t *testing.T) {
+	config := Gofer{
+		Origins: map[string]Origin{
+			"b": {Type: "b", Pairs: []string{"eth/btc", "btc/usd"}},
+		},
+		PriceModels: map[string]PriceModel{
+			"ETH/USD": {
+				Method: "median",
+				Sources: [][]Source{
+					{{Origin: "a", Pair: "ETH/USD"}},
+					{{Origin: "c", Pair: "ETH/DAI"}, {Origin: "c", Pair: "DAI/USD"}},
+				},
+				Discovery: &Discovery{MaxHops: 2, Bridges: []string{"BTC"}},
+			},
+			"USDT/USD": {
+				Method:  "median",
+				Sources: [][]Source{{{Origin: "d", Pair: "USDT/USD"}}},
+			},
+			"ETH/USDT": {
+				Method:  "median",
+				Sources: [][]Source{{{Origin: "d", Pair: "ETH/USDT"}}},
+			},
+		},
+	}
+
+	c, err := config.buildGraphs()
+	require.NoError(t, err)
+
+	ethusd := provider.Pair{Base: "ETH", Quote: "USD"}
+	ms, err := graph.NewProvider(c, nil).Models(ethusd)
+	require.NoError(t, err)
+
+	var paths []string
+	for _, m := range ms[ethusd].Models {
+		var hops []string
+		if m.Type == "origin" {
+			hops = append(hops, m.Parameters["origin"]+" "+m.Pair.String())
+		}
+		for _, h := range m.Models {
+			hops = append(hops, h.Parameters["origin"]+" "+h.Pair.String())
+		}
+		paths = append(paths, strings.Join(hops, " -> "))
+	}
+
+	// Configured sources are first, then discovered ones without duplicates:
+	assert.Equal(t, []string{
+		"a ETH/USD",
+		"c ETH/DAI -> c DAI/USD",
+		"b ETH/BTC -> b BTC/USD",
+		"d ETH/USDT -> d USDT/USD",
+	}, paths)
+}
+
 func TestConfig_buildGraphs_CyclicConfig(t *testing.T) {
 	config := Gofer{
 		Origins: nil,
@@ -335,3 +498,94 @@ func TestConfig_buildGraphs_UpdatedOriginURL(t *testing.T) {
 	require.NotNil(t, bin)
 	require.Equal(t, url, bin.BaseURL)
 }
+
+func TestConfig_circuitBreakerConfig(t *testing.T) {
+	cfg, err := (&Gofer{}).circuitBreakerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, feeder.DefaultCircuitBreakerConfig, cfg)
+
+	cfg, err = (&Gofer{CircuitBreaker: &CircuitBreaker{FailureThreshold: 5, MinBackoff: 30}}).circuitBreakerConfig()
+	require.NoError(t, err)
+	assert.Equal(t, feeder.CircuitBreakerConfig{
+		FailureThreshold: 5,
+		MinBackoff:       30 * time.Second,
+		MaxBackoff:       feeder.DefaultCircuitBreakerConfig.MaxBackoff,
+	}, cfg)
+
+	_, err = (&Gofer{CircuitBreaker: &CircuitBreaker{FailureThreshold: -1}}).circuitBreakerConfig()
+	assert.Error(t, err)
+
+	_, err = (&Gofer{CircuitBreaker: &CircuitBreaker{MinBackoff: 60, MaxBackoff: 30}}).circuitBreakerConfig()
+	assert.Error(t, err)
+}
+
+func TestConfig_buildOrigins_RateLimit(t *testing.T) {
+	config := Gofer{
+		Origins: map[string]Origin{
+			"ab": {
+				Type:      "binance",
+				Params:    yamlNode(t, `{}`),
+				RateLimit: &RateLimit{RequestsPerSecond: 5, Burst: 10},
+			},
+		},
+	}
+
+	o, err := config.buildOrigins(&ethereumMocks.Client{})
+	require.NoError(t, err)
+
+	bin := o.Handlers()["ab"].(*origins.BaseExchangeHandler).ExchangeHandler.(origins.Binance)
+	_, ok := bin.WorkerPool.(*query.HTTPWorkerPool)
+	assert.False(t, ok, "origin with a rate limit must not use the shared worker pool directly")
+
+	config.Origins["ab"] = Origin{Type: "binance", Params: yamlNode(t, `{}`), RateLimit: &RateLimit{}}
+	_, err = config.buildOrigins(&ethereumMocks.Client{})
+	assert.Error(t, err)
+
+	ttl := -1
+	_, err = (&Gofer{HTTPCacheTTL: &ttl}).buildOrigins(&ethereumMocks.Client{})
+	assert.Error(t, err)
+}
+
+func TestConfig_ConfigurePriceHook(t *testing.T) {
+	config := Gofer{
+		PriceModels: map[string]PriceModel{
+			"DAI/USD": {
+				Method: "median",
+				Params: yamlNode(t, `
+postPriceHook:
+  peg:
+    maxDeviation: 0.05
+  bounds:
+    min: 0.5
+`),
+			},
+		},
+	}
+
+	hook, err := config.ConfigurePriceHook(context.Background(), &ethereumMocks.Client{})
+	require.NoError(t, err)
+
+	pair := provider.Pair{Base: "DAI", Quote: "USD"}
+	prices := map[provider.Pair]*provider.Price{pair: {Pair: pair, Price: 0.9}}
+	require.NoError(t, hook.Check(prices))
+	assert.Contains(t, prices[pair].Error, "peg")
+
+	config.PriceModels["DAI/USD"] = PriceModel{
+		Method: "median",
+		Params: yamlNode(t, `{postPriceHook: {unknown: {}}}`),
+	}
+	_, err = config.ConfigurePriceHook(context.Background(), &ethereumMocks.Client{})
+	assert.Error(t, err)
+}
+
+func TestConfig_ConfigurePriceAPI(t *testing.T) {
+	config := Gofer{}
+	srv, err := config.ConfigurePriceAPI(&mocks.Provider{}, null.New())
+	require.NoError(t, err)
+	assert.Nil(t, srv)
+
+	config.HTTPListenAddr = "127.0.0.1:0"
+	srv, err = config.ConfigurePriceAPI(&mocks.Provider{}, null.New())
+	require.NoError(t, err)
+	assert.NotNil(t, srv)
+}
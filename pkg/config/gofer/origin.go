@@ -16,7 +16,9 @@
 package gofer
 
 import (
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -63,6 +65,95 @@ func parseParamsContracts(params yaml.Node) (origins.ContractAddresses, error) {
 	return res.Contracts, nil
 }
 
+func parseParamsWindow(params yaml.Node) (time.Duration, error) {
+	var res struct {
+		Window int `yaml:"window"`
+	}
+	err := params.Decode(&res)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal origin window from params: %w", err)
+	}
+	if res.Window < 0 {
+		return 0, fmt.Errorf("origin window must not be negative")
+	}
+	return time.Duration(res.Window) * time.Second, nil
+}
+
+type paramsGenericJSON struct {
+	Method        string            `yaml:"method"`
+	Headers       map[string]string `yaml:"headers"`
+	Body          string            `yaml:"body"`
+	PricePath     string            `yaml:"price"`
+	BidPath       string            `yaml:"bid"`
+	AskPath       string            `yaml:"ask"`
+	VolumePath    string            `yaml:"volume"`
+	TimestampPath string            `yaml:"timestamp"`
+}
+
+func parseParamsGenericJSON(params yaml.Node) (paramsGenericJSON, error) {
+	var res paramsGenericJSON
+	err := params.Decode(&res)
+	if err != nil {
+		return res, fmt.Errorf("failed to marshal origin params: %w", err)
+	}
+	if res.PricePath == "" {
+		return res, fmt.Errorf("price path must be specified in origin params")
+	}
+	return res, nil
+}
+
+type paramsContractCall struct {
+	Blocks []int64                           `yaml:"blocks"`
+	Pairs  map[string]paramsContractCallPair `yaml:"pairs"`
+}
+
+type paramsContractCallPair struct {
+	Address  string    `yaml:"address"`
+	ABI      yaml.Node `yaml:"abi"`
+	Method   string    `yaml:"method"`
+	Args     []string  `yaml:"args"`
+	Output   int       `yaml:"output"`
+	Decimals int       `yaml:"decimals"`
+	Scale    float64   `yaml:"scale"`
+}
+
+func parseParamsContractCall(params yaml.Node) ([]int64, map[string]origins.ContractCallPair, error) {
+	var res paramsContractCall
+	err := params.Decode(&res)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal origin params: %w", err)
+	}
+	pairs := map[string]origins.ContractCallPair{}
+	for name, p := range res.Pairs {
+		if !pkgEthereum.IsHexAddress(p.Address) {
+			return nil, nil, fmt.Errorf("invalid contract address for pair %s: %s", name, p.Address)
+		}
+		// ABI may be given either as a JSON string or as a YAML list.
+		abi := p.ABI.Value
+		if p.ABI.Kind != yaml.ScalarNode {
+			var v interface{}
+			if err := p.ABI.Decode(&v); err != nil {
+				return nil, nil, fmt.Errorf("invalid ABI for pair %s: %w", name, err)
+			}
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, nil, fmt.Errorf("invalid ABI for pair %s: %w", name, err)
+			}
+			abi = string(b)
+		}
+		pairs[name] = origins.ContractCallPair{
+			Address:  pkgEthereum.HexToAddress(p.Address),
+			ABI:      abi,
+			Method:   p.Method,
+			Args:     p.Args,
+			Output:   p.Output,
+			Decimals: p.Decimals,
+			Scale:    p.Scale,
+		}
+	}
+	return res.Blocks, pairs, nil
+}
+
 //nolint:funlen,gocyclo,whitespace
 func NewHandler(
 	origin string,
@@ -88,6 +179,8 @@ func NewHandler(
 		}, aliases), nil
 	case "binance":
 		return origins.NewBaseExchangeHandler(origins.Binance{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
+	case "binanceStream":
+		return origins.NewBinanceStream(baseURL, aliases), nil
 	case "bitfinex":
 		return origins.NewBaseExchangeHandler(origins.Bitfinex{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
 	case "bitstamp":
@@ -98,6 +191,18 @@ func NewHandler(
 		return origins.NewBaseExchangeHandler(origins.Bittrex{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
 	case "coinbase", "coinbasepro":
 		return origins.NewBaseExchangeHandler(origins.CoinbasePro{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
+	case "coinbaseStream", "coinbaseproStream":
+		return origins.NewCoinbaseProStream(baseURL, aliases), nil
+	case "contract":
+		blocks, pairs, err := parseParamsContractCall(params)
+		if err != nil {
+			return nil, err
+		}
+		h, err := origins.NewContractCall(cli, pairs, blocks)
+		if err != nil {
+			return nil, err
+		}
+		return origins.NewBaseExchangeHandler(*h, aliases), nil
 	case "cryptocompare":
 		return origins.NewBaseExchangeHandler(origins.CryptoCompare{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
 	case "coinmarketcap":
@@ -132,8 +237,30 @@ func NewHandler(
 		return origins.NewBaseExchangeHandler(origins.Hitbtc{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
 	case "huobi":
 		return origins.NewBaseExchangeHandler(origins.Huobi{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
+	case "json":
+		if baseURL == "" {
+			return nil, fmt.Errorf("url must be specified for the json origin")
+		}
+		p, err := parseParamsGenericJSON(params)
+		if err != nil {
+			return nil, err
+		}
+		return origins.NewBaseExchangeHandler(origins.GenericJSON{
+			WorkerPool:    wp,
+			URL:           baseURL,
+			Method:        p.Method,
+			Headers:       p.Headers,
+			Body:          p.Body,
+			PricePath:     p.PricePath,
+			BidPath:       p.BidPath,
+			AskPath:       p.AskPath,
+			VolumePath:    p.VolumePath,
+			TimestampPath: p.TimestampPath,
+		}, aliases), nil
 	case "kraken":
 		return origins.NewBaseExchangeHandler(origins.Kraken{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
+	case "krakenStream":
+		return origins.NewKrakenStream(baseURL, aliases), nil
 	case "kucoin":
 		return origins.NewBaseExchangeHandler(origins.Kucoin{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
 	case "loopring":
@@ -193,6 +320,16 @@ func NewHandler(
 			return nil, err
 		}
 		return origins.NewBaseExchangeHandler(*h, aliases), nil
+	case "chainlink":
+		contracts, err := parseParamsContracts(params)
+		if err != nil {
+			return nil, err
+		}
+		h, err := origins.NewChainlink(cli, contracts)
+		if err != nil {
+			return nil, err
+		}
+		return origins.NewBaseExchangeHandler(*h, aliases), nil
 	case "rocketpool":
 		contracts, err := parseParamsContracts(params)
 		if err != nil {
@@ -223,6 +360,20 @@ func NewHandler(
 			BaseURL:           baseURL,
 			ContractAddresses: contracts,
 		}, aliases), nil
+	case "uniswapV3TWAP":
+		contracts, err := parseParamsContracts(params)
+		if err != nil {
+			return nil, err
+		}
+		window, err := parseParamsWindow(params)
+		if err != nil {
+			return nil, err
+		}
+		h, err := origins.NewUniswapV3TWAP(cli, contracts, window)
+		if err != nil {
+			return nil, err
+		}
+		return origins.NewBaseExchangeHandler(*h, aliases), nil
 	case "upbit":
 		return origins.NewBaseExchangeHandler(origins.Upbit{WorkerPool: wp, BaseURL: baseURL}, aliases), nil
 	}
@@ -44,3 +44,50 @@ func TestParsingOriginParamsAliases(t *testing.T) {
 	assert.NotNil(t, aliases)
 	assert.Equal(t, "WETH", aliases["ETH"])
 }
+
+func TestParsingOriginParamsContractCall(t *testing.T) {
+	// ABI as a JSON string
+	blocks, pairs, err := parseParamsContractCall(yamlNode(t, `
+blocks: [0, 10]
+pairs:
+  WSTETH/STETH:
+    address: "0x7f39C581F595B53c5cb19bD0b3f8dA6c935E2Ca0"
+    abi: '[{"inputs":[],"name":"stEthPerToken","outputs":[{"name":"","type":"uint256"}],"type":"function"}]'
+    method: stEthPerToken
+    decimals: 18
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{0, 10}, blocks)
+	assert.Equal(t, "stEthPerToken", pairs["WSTETH/STETH"].Method)
+	assert.Equal(t, 18, pairs["WSTETH/STETH"].Decimals)
+	assert.JSONEq(t, `[{"inputs":[],"name":"stEthPerToken","outputs":[{"name":"","type":"uint256"}],"type":"function"}]`, pairs["WSTETH/STETH"].ABI)
+
+	// ABI as a YAML list
+	_, pairs, err = parseParamsContractCall(yamlNode(t, `
+pairs:
+  A/B:
+    address: "0x7f39C581F595B53c5cb19bD0b3f8dA6c935E2Ca0"
+    abi:
+      - name: rate
+        type: function
+        inputs: []
+        outputs: [{name: "", type: uint256}]
+    method: rate
+`))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"name":"rate","type":"function","inputs":[],"outputs":[{"name":"","type":"uint256"}]}]`, pairs["A/B"].ABI)
+
+	// Invalid address
+	_, _, err = parseParamsContractCall(yamlNode(t, `{"pairs":{"A/B":{"address":"0x1"}}}`))
+	assert.Error(t, err)
+}
+
+func TestParsingOriginParamsGenericJSON(t *testing.T) {
+	p, err := parseParamsGenericJSON(yamlNode(t, `{"price":"data.last","headers":{"X-Key":"key"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "data.last", p.PricePath)
+	assert.Equal(t, "key", p.Headers["X-Key"])
+
+	_, err = parseParamsGenericJSON(yamlNode(t, `{"bid":"data.bid"}`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
+)
+
+//nolint
+var metricsFactory = func(cfg metrics.Config) (*metrics.Server, error) {
+	return metrics.New(cfg)
+}
+
+type Metrics struct {
+	// ListenAddr is the address of the Prometheus metrics endpoint. If
+	// empty, metrics are not exposed.
+	ListenAddr string `yaml:"listenAddr"`
+}
+
+type Dependencies struct {
+	Logger log.Logger
+}
+
+// Configure returns a new metrics server. If the listen address is not
+// configured, nil is returned.
+func (c *Metrics) Configure(d Dependencies) (*metrics.Server, error) {
+	if c.ListenAddr == "" {
+		return nil, nil
+	}
+	return metricsFactory(metrics.Config{
+		Address: c.ListenAddr,
+		Logger:  d.Logger,
+	})
+}
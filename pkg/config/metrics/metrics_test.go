@@ -0,0 +1,53 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
+)
+
+func TestMetrics_Configure(t *testing.T) {
+	prevMetricsFactory := metricsFactory
+	defer func() { metricsFactory = prevMetricsFactory }()
+
+	log := null.New()
+	config := Metrics{
+		ListenAddr: "127.0.0.1:0",
+	}
+
+	metricsFactory = func(cfg metrics.Config) (*metrics.Server, error) {
+		assert.Equal(t, config.ListenAddr, cfg.Address)
+		assert.Equal(t, log, cfg.Logger)
+		return &metrics.Server{}, nil
+	}
+
+	srv, err := config.Configure(Dependencies{Logger: log})
+	require.NoError(t, err)
+	assert.NotNil(t, srv)
+}
+
+func TestMetrics_Configure_Disabled(t *testing.T) {
+	config := Metrics{}
+	srv, err := config.Configure(Dependencies{Logger: null.New()})
+	require.NoError(t, err)
+	assert.Nil(t, srv)
+}
@@ -0,0 +1,132 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package pricestore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store/redis"
+)
+
+const day = 3600 * 24
+const month = day * 30
+
+//nolint
+var fileStorageFactory = func(path string, ttl time.Duration) (store.Storage, error) {
+	return store.NewFileStorage(path, ttl)
+}
+
+//nolint
+var redisStorageFactory = func(cfg redis.Config) (store.Storage, error) {
+	return redis.NewRedisStorage(cfg)
+}
+
+// Storage is the configuration of the storage used by the price store.
+type Storage struct {
+	Type    string         `yaml:"type"`
+	File    StorageFile    `yaml:"file"`
+	Redis   StorageRedis   `yaml:"redis"`
+	History StorageHistory `yaml:"history"`
+}
+
+type StorageFile struct {
+	Path string `yaml:"path"`
+	TTL  int    `yaml:"ttl"`
+}
+
+type StorageHistory struct {
+	Path      string `yaml:"path"`
+	Retention int    `yaml:"retention"`
+}
+
+type StorageRedis struct {
+	TTL                   int    `yaml:"ttl"`
+	Address               string `yaml:"address"`
+	Username              string `yaml:"username"`
+	Password              string `yaml:"password"`
+	DB                    int    `yaml:"db"`
+	TLS                   bool   `yaml:"tls"`
+	TLSServerName         string `yaml:"tlsServerName"`
+	TLSCertFile           string `yaml:"tlsCertFile"`
+	TLSKeyFile            string `yaml:"tlsKeyFile"`
+	TLSRootCAFile         string `yaml:"tlsRootCAFile"`
+	TLSInsecureSkipVerify bool   `yaml:"tlsInsecureSkipVerify"`
+}
+
+func (c *Storage) Configure() (store.Storage, error) {
+	s, err := c.configureStorage()
+	if err != nil {
+		return nil, err
+	}
+	if len(c.History.Path) == 0 {
+		return s, nil
+	}
+	retention := month
+	if c.History.Retention > 0 {
+		retention = c.History.Retention
+	}
+	h, err := store.NewHistoryStorage(s, c.History.Path, time.Duration(retention)*time.Second)
+	if err != nil {
+		return nil, fmt.Errorf(`price store config: unable to open the history storage: %w`, err)
+	}
+	return h, nil
+}
+
+func (c *Storage) configureStorage() (store.Storage, error) {
+	switch c.Type {
+	case "memory", "":
+		return store.NewMemoryStorage(), nil
+	case "file":
+		if len(c.File.Path) == 0 {
+			return nil, fmt.Errorf(`price store config: file path must not be empty`)
+		}
+		ttl := day
+		if c.File.TTL > 0 {
+			ttl = c.File.TTL
+		}
+		s, err := fileStorageFactory(c.File.Path, time.Duration(ttl)*time.Second)
+		if err != nil {
+			return nil, fmt.Errorf(`price store config: unable to open the storage file: %w`, err)
+		}
+		return s, nil
+	case "redis":
+		ttl := day
+		if c.Redis.TTL > 0 {
+			ttl = c.Redis.TTL
+		}
+		r, err := redisStorageFactory(redis.Config{
+			TTL:                   time.Duration(ttl) * time.Second,
+			Address:               c.Redis.Address,
+			Username:              c.Redis.Username,
+			Password:              c.Redis.Password,
+			DB:                    c.Redis.DB,
+			TLS:                   c.Redis.TLS,
+			TLSServerName:         c.Redis.TLSServerName,
+			TLSCertFile:           c.Redis.TLSCertFile,
+			TLSKeyFile:            c.Redis.TLSKeyFile,
+			TLSRootCAFile:         c.Redis.TLSRootCAFile,
+			TLSInsecureSkipVerify: c.Redis.TLSInsecureSkipVerify,
+		})
+		if err != nil {
+			return nil, fmt.Errorf(`price store config: unable to connect to the Redis server: %w`, err)
+		}
+		return r, nil
+	default:
+		return nil, fmt.Errorf(`price store config: storage type must be "memory", "file", "redis" or empty to use default one`)
+	}
+}
@@ -0,0 +1,122 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package pricestore
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store/redis"
+)
+
+func TestStorage_Configure_memory(t *testing.T) {
+	config := Storage{Type: "memory"}
+	sto, err := config.Configure()
+	require.NoError(t, err)
+	require.IsType(t, &store.MemoryStorage{}, sto)
+}
+
+func TestStorage_Configure_file(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.log")
+	config := Storage{
+		Type: "file",
+		File: StorageFile{
+			Path: path,
+			TTL:  60,
+		},
+	}
+	sto, err := config.Configure()
+	require.NoError(t, err)
+	require.IsType(t, &store.FileStorage{}, sto)
+	assert.FileExists(t, path)
+	require.NoError(t, sto.(*store.FileStorage).Close())
+}
+
+func TestStorage_Configure_fileDefaultTTL(t *testing.T) {
+	prevFileStorageFactory := fileStorageFactory
+	defer func() { fileStorageFactory = prevFileStorageFactory }()
+
+	fileStorageFactory = func(path string, ttl time.Duration) (store.Storage, error) {
+		assert.Equal(t, "prices.log", path)
+		assert.Equal(t, 24*time.Hour, ttl)
+		return store.NewMemoryStorage(), nil
+	}
+
+	config := Storage{Type: "file", File: StorageFile{Path: "prices.log"}}
+	_, err := config.Configure()
+	require.NoError(t, err)
+}
+
+func TestStorage_Configure_fileEmptyPath(t *testing.T) {
+	config := Storage{Type: "file"}
+	_, err := config.Configure()
+	require.Error(t, err)
+}
+
+func TestStorage_Configure_redis(t *testing.T) {
+	prevRedisStorageFactory := redisStorageFactory
+	defer func() { redisStorageFactory = prevRedisStorageFactory }()
+
+	config := Storage{
+		Type: "redis",
+		Redis: StorageRedis{
+			Address:  "127.0.0.1:6379",
+			Password: "password",
+			DB:       1,
+		},
+	}
+
+	redisStorageFactory = func(cfg redis.Config) (store.Storage, error) {
+		assert.Equal(t, 24*time.Hour, cfg.TTL)
+		assert.Equal(t, config.Redis.Address, cfg.Address)
+		assert.Equal(t, config.Redis.Password, cfg.Password)
+		assert.Equal(t, config.Redis.DB, cfg.DB)
+		return &redis.Storage{}, nil
+	}
+
+	sto, err := config.Configure()
+	require.NoError(t, err)
+	require.IsType(t, &redis.Storage{}, sto)
+}
+
+func TestStorage_Configure_history(t *testing.T) {
+	config := Storage{
+		Type:    "memory",
+		History: StorageHistory{Path: t.TempDir()},
+	}
+	sto, err := config.Configure()
+	require.NoError(t, err)
+	require.IsType(t, &store.HistoryStorage{}, sto)
+	require.NoError(t, sto.(*store.HistoryStorage).Close())
+}
+
+func TestStorage_Configure_invalidType(t *testing.T) {
+	config := Storage{Type: "invalid"}
+	_, err := config.Configure()
+	require.Error(t, err)
+}
+
+func TestStorage_Configure_defaultType(t *testing.T) {
+	config := Storage{}
+	sto, err := config.Configure()
+	require.NoError(t, err)
+	require.IsType(t, &store.MemoryStorage{}, sto)
+}
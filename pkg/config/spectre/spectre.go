@@ -16,18 +16,39 @@
 package spectre
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/ethereum/go-ethereum/params"
+
+	pricestoreConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/pricestore"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/maputil"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	ethereumGeth "github.com/chronicleprotocol/oracle-suite/pkg/ethereum/geth"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/txmanager"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
 	oracleGeth "github.com/chronicleprotocol/oracle-suite/pkg/price/oracle/geth"
+	oracleStarknet "github.com/chronicleprotocol/oracle-suite/pkg/price/oracle/starknet"
 	"github.com/chronicleprotocol/oracle-suite/pkg/spectre"
+	"github.com/chronicleprotocol/oracle-suite/pkg/starknet"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 )
 
+const defaultBatchGasLimit = 200000
+const batchTxKey = "batch"
+const defaultFeeHistoryBlocks = 10
+const defaultFeeHistoryPercentile = 50
+
 //nolint
 var spectreFactory = func(cfg spectre.Config) (*spectre.Spectre, error) {
 	return spectre.NewSpectre(cfg)
@@ -39,15 +60,81 @@ var priceStoreFactory = func(cfg store.Config) (*store.PriceStore, error) {
 }
 
 type Spectre struct {
-	Interval    int64                 `yaml:"interval"`
-	Medianizers map[string]Medianizer `yaml:"medianizers"`
+	Interval    int64                    `yaml:"interval"`
+	Medianizers map[string]Medianizer    `yaml:"medianizers"`
+	Storage     pricestoreConfig.Storage `yaml:"storage"`
+	Shadow      Shadow                   `yaml:"shadow"`
+	Transaction Transaction              `yaml:"transaction"`
+	Batch       Batch                    `yaml:"batch"`
+}
+
+type Batch struct {
+	// Enable enables sending updates for all Oracles that need to be
+	// updated in a single multicall transaction.
+	Enable bool `yaml:"enable"`
+	// Contract is the address of the Multicall3 contract. If empty,
+	// the default address is used.
+	Contract string `yaml:"contract"`
+	// GasLimit is the gas limit per single Oracle update.
+	GasLimit uint64 `yaml:"gasLimit"`
+	// Gas is the gas configuration used for batch transactions. When
+	// batching is enabled, it replaces gas configurations of Oracles, which
+	// must not be set.
+	Gas Gas `yaml:"gas"`
+}
+
+type Shadow struct {
+	// Enable enables the shadow mode, in which spectre does not send
+	// transactions but only records the decisions it would make.
+	Enable bool `yaml:"enable"`
+	// File is an optional path to the file where decisions are appended as
+	// JSON lines. If empty, decisions are only logged.
+	File string `yaml:"file"`
+}
+
+type Transaction struct {
+	// ReplaceTimeout is the time in seconds after which a pending Oracle
+	// update is replaced with a new one with bumped fees.
+	ReplaceTimeout int64 `yaml:"replaceTimeout"`
+	// FeeBump is the fraction by which fees are increased when a pending
+	// transaction is replaced.
+	FeeBump float64 `yaml:"feeBump"`
 }
 
 type Medianizer struct {
+	// Type is the type of the Oracle contract: "median" (default),
+	// "scribe" or "starknet".
+	Type             string  `yaml:"type"`
 	Contract         string  `yaml:"oracle"`
 	OracleSpread     float64 `yaml:"oracleSpread"`
 	OracleExpiration int64   `yaml:"oracleExpiration"`
 	MsgExpiration    int64   `yaml:"msgExpiration"`
+	Gas              Gas     `yaml:"gas"`
+	// Sequencer is the URL of the Starknet sequencer used by the "starknet"
+	// Oracle type. The maximum transaction fee is taken from the gas.maxFee
+	// option.
+	Sequencer string `yaml:"sequencer"`
+}
+
+type Gas struct {
+	// Strategy is the name of the gas strategy: "node" (default) uses fees
+	// suggested by the node, "fixed" uses fixed fees and "feeHistory" uses
+	// priority fees paid in the latest blocks.
+	Strategy string `yaml:"strategy"`
+	// PriorityFee and MaxFee are fees in gwei used by the "fixed" strategy.
+	PriorityFee float64 `yaml:"priorityFee"`
+	MaxFee      float64 `yaml:"maxFee"`
+	// Blocks is the number of the latest blocks used by the "feeHistory"
+	// strategy.
+	Blocks int `yaml:"blocks"`
+	// Percentile is the percentile of priority fees paid in each block used
+	// by the "feeHistory" strategy.
+	Percentile float64 `yaml:"percentile"`
+	// UrgencyMultiplier, if greater than 1, is the maximum multiplier of fees
+	// used when the Oracle has been expired for another expiration period.
+	// Fees of the "fixed" strategy multiplied by this value are also limits
+	// for replacement transactions.
+	UrgencyMultiplier float64 `yaml:"urgencyMultiplier"`
 }
 
 type Dependencies struct {
@@ -72,21 +159,130 @@ func (c *Spectre) ConfigureSpectre(d Dependencies) (*spectre.Spectre, error) {
 		PriceStore: d.PriceStore,
 		Logger:     d.Logger,
 	}
+	if c.Shadow.Enable {
+		w, err := c.Shadow.writer()
+		if err != nil {
+			return nil, err
+		}
+		cfg.ShadowMode = true
+		cfg.ShadowWriter = w
+	}
+	txm, err := txmanager.New(txmanager.Config{
+		Client:         d.EthereumClient,
+		Signer:         d.Signer,
+		ReplaceTimeout: time.Second * time.Duration(c.Transaction.ReplaceTimeout),
+		FeeBump:        c.Transaction.FeeBump,
+		Logger:         d.Logger,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if c.Batch.Enable {
+		address := ethereumGeth.MultiCall3Address
+		if c.Batch.Contract != "" {
+			address = ethereum.HexToAddress(c.Batch.Contract)
+		}
+		gasLimit := c.Batch.GasLimit
+		if gasLimit == 0 {
+			gasLimit = defaultBatchGasLimit
+		}
+		gas, err := c.Batch.Gas.Configure(d.EthereumClient)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Batcher = ethereumGeth.NewBatcher(txm.Client(batchTxKey, gas), address, gasLimit)
+	}
 	for name, pair := range c.Medianizers {
+		if c.Batch.Enable && strings.EqualFold(pair.Type, "starknet") {
+			return nil, fmt.Errorf("spectre config: batching is not supported for the starknet oracle %s", name)
+		}
+		if c.Batch.Enable && pair.Gas != (Gas{}) {
+			return nil, fmt.Errorf("spectre config: gas of the %s oracle must not be set when batching is enabled, use batch.gas instead", name)
+		}
+		gas, err := pair.Gas.Configure(d.EthereumClient)
+		if err != nil {
+			return nil, err
+		}
+		orcl, err := pair.configureOracle(txm.Client(name, gas), d.Signer)
+		if err != nil {
+			return nil, err
+		}
 		cfg.Pairs = append(cfg.Pairs, &spectre.Pair{
 			AssetPair:        name,
 			OracleSpread:     pair.OracleSpread,
 			OracleExpiration: time.Second * time.Duration(pair.OracleExpiration),
 			PriceExpiration:  time.Second * time.Duration(pair.MsgExpiration),
-			Median:           oracleGeth.NewMedian(d.EthereumClient, ethereum.HexToAddress(pair.Contract)),
+			Median:           orcl,
 		})
 	}
 	return spectreFactory(cfg)
 }
 
+func (c *Medianizer) configureOracle(client ethereum.Client, signer ethereum.Signer) (oracle.Oracle, error) {
+	address := ethereum.HexToAddress(c.Contract)
+	switch strings.ToLower(c.Type) {
+	case "median", "":
+		return oracleGeth.NewMedian(client, address), nil
+	case "scribe":
+		return oracleGeth.NewScribe(client, signer, address), nil
+	case "starknet":
+		if _, err := url.ParseRequestURI(c.Sequencer); err != nil {
+			return nil, fmt.Errorf("spectre config: sequencer address is not valid url: %w", err)
+		}
+		return oracleStarknet.NewMedian(
+			starknet.NewSequencer(c.Sequencer, http.Client{}),
+			starknet.HexToFelt(c.Contract),
+			gweiToWei(c.Gas.MaxFee),
+		), nil
+	default:
+		return nil, fmt.Errorf("spectre config: unknown oracle type: %s", c.Type)
+	}
+}
+
+func (c *Gas) Configure(client ethereum.Client) (ethereum.GasStrategy, error) {
+	var gas ethereum.GasStrategy
+	switch strings.ToLower(c.Strategy) {
+	case "node", "":
+		gas = client
+	case "fixed":
+		if c.MaxFee <= 0 {
+			return nil, errors.New("spectre config: maxFee must be greater than 0 for the fixed gas strategy")
+		}
+		gas = ethereum.NewFixedGasStrategy(gweiToWei(c.PriorityFee), gweiToWei(c.MaxFee))
+	case "feehistory":
+		blocks := c.Blocks
+		if blocks == 0 {
+			blocks = defaultFeeHistoryBlocks
+		}
+		percentile := c.Percentile
+		if percentile == 0 {
+			percentile = defaultFeeHistoryPercentile
+		}
+		if blocks < 0 || percentile < 0 || percentile > 100 {
+			return nil, errors.New("spectre config: invalid blocks or percentile for the feeHistory gas strategy")
+		}
+		gas = ethereum.NewFeeHistoryGasStrategy(client, blocks, percentile)
+	default:
+		return nil, fmt.Errorf("spectre config: unknown gas strategy: %s", c.Strategy)
+	}
+	if c.UrgencyMultiplier > 1 {
+		gas = ethereum.NewUrgencyGasStrategy(gas, c.UrgencyMultiplier)
+	}
+	return gas, nil
+}
+
+func gweiToWei(gwei float64) *big.Int {
+	wei, _ := new(big.Float).Mul(big.NewFloat(gwei), big.NewFloat(params.GWei)).Int(nil)
+	return wei
+}
+
 func (c *Spectre) ConfigurePriceStore(d PriceStoreDependencies) (*store.PriceStore, error) {
+	sto, err := c.Storage.Configure()
+	if err != nil {
+		return nil, err
+	}
 	cfg := store.Config{
-		Storage:   store.NewMemoryStorage(),
+		Storage:   sto,
 		Signer:    d.Signer,
 		Transport: d.Transport,
 		Pairs:     maputil.Keys(c.Medianizers),
@@ -95,3 +291,14 @@ func (c *Spectre) ConfigurePriceStore(d PriceStoreDependencies) (*store.PriceSto
 
 	return priceStoreFactory(cfg)
 }
+
+func (c *Shadow) writer() (io.Writer, error) {
+	if c.File == "" {
+		return nil, nil
+	}
+	f, err := os.OpenFile(c.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("spectre config: unable to open shadow file %s: %w", c.File, err)
+	}
+	return f, nil
+}
@@ -16,6 +16,10 @@
 package spectre
 
 import (
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -25,6 +29,8 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	ethereumMocks "github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	oracleGeth "github.com/chronicleprotocol/oracle-suite/pkg/price/oracle/geth"
+	oracleStarknet "github.com/chronicleprotocol/oracle-suite/pkg/price/oracle/starknet"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
 	"github.com/chronicleprotocol/oracle-suite/pkg/spectre"
 )
@@ -80,6 +86,153 @@ func TestSpectre_Configure(t *testing.T) {
 	require.NotNil(t, s)
 }
 
+func TestSpectre_Configure_Shadow(t *testing.T) {
+	prevSpectreFactory := spectreFactory
+	defer func() {
+		spectreFactory = prevSpectreFactory
+	}()
+
+	path := filepath.Join(t.TempDir(), "shadow.log")
+	config := Spectre{
+		Interval: 10,
+		Shadow:   Shadow{Enable: true, File: path},
+	}
+
+	spectreFactory = func(cfg spectre.Config) (*spectre.Spectre, error) {
+		assert.True(t, cfg.ShadowMode)
+		require.NotNil(t, cfg.ShadowWriter)
+		_, err := cfg.ShadowWriter.Write([]byte("test\n"))
+		require.NoError(t, err)
+		require.NoError(t, cfg.ShadowWriter.(*os.File).Close())
+		return &spectre.Spectre{}, nil
+	}
+
+	_, err := config.ConfigureSpectre(Dependencies{
+		Signer:         &ethereumMocks.Signer{},
+		EthereumClient: &ethereumMocks.Client{},
+		Logger:         null.New(),
+	})
+	require.NoError(t, err)
+	assert.FileExists(t, path)
+}
+
+func TestSpectre_Configure_Batch(t *testing.T) {
+	prevSpectreFactory := spectreFactory
+	defer func() {
+		spectreFactory = prevSpectreFactory
+	}()
+
+	config := Spectre{
+		Interval: 10,
+		Batch:    Batch{Enable: true, Gas: Gas{Strategy: "fixed", MaxFee: 100}},
+		Medianizers: map[string]Medianizer{
+			"AAABBB": {Contract: "0xe0F30cb149fAADC7247E953746Be9BbBB6B5751f"},
+		},
+	}
+
+	spectreFactory = func(cfg spectre.Config) (*spectre.Spectre, error) {
+		assert.NotNil(t, cfg.Batcher)
+		return &spectre.Spectre{}, nil
+	}
+
+	deps := Dependencies{
+		Signer:         &ethereumMocks.Signer{},
+		EthereumClient: &ethereumMocks.Client{},
+		Logger:         null.New(),
+	}
+	_, err := config.ConfigureSpectre(deps)
+	require.NoError(t, err)
+
+	// Invalid batch gas configuration:
+	config.Batch.Gas = Gas{Strategy: "unknown"}
+	_, err = config.ConfigureSpectre(deps)
+	assert.Error(t, err)
+
+	// Gas configuration of an Oracle is not used in the batch mode:
+	config.Batch.Gas = Gas{}
+	config.Medianizers["AAABBB"] = Medianizer{
+		Contract: "0xe0F30cb149fAADC7247E953746Be9BbBB6B5751f",
+		Gas:      Gas{Strategy: "fixed", MaxFee: 100},
+	}
+	_, err = config.ConfigureSpectre(deps)
+	assert.Error(t, err)
+}
+
+func TestMedianizer_configureOracle(t *testing.T) {
+	ethClient := &ethereumMocks.Client{}
+	signer := &ethereumMocks.Signer{}
+	address := "0xe0F30cb149fAADC7247E953746Be9BbBB6B5751f"
+
+	o, err := (&Medianizer{Contract: address}).configureOracle(ethClient, signer)
+	require.NoError(t, err)
+	assert.IsType(t, &oracleGeth.Median{}, o)
+
+	o, err = (&Medianizer{Type: "scribe", Contract: address}).configureOracle(ethClient, signer)
+	require.NoError(t, err)
+	assert.IsType(t, &oracleGeth.Scribe{}, o)
+	assert.Equal(t, ethereum.HexToAddress(address), o.Address())
+
+	o, err = (&Medianizer{Type: "starknet", Contract: "0x197f9e93", Sequencer: "http://localhost"}).configureOracle(ethClient, signer)
+	require.NoError(t, err)
+	assert.IsType(t, &oracleStarknet.Median{}, o)
+	assert.Equal(t, "197f9e93", o.(*oracleStarknet.Median).ContractAddress().Text(16))
+
+	_, err = (&Medianizer{Type: "starknet", Contract: "0x197f9e93"}).configureOracle(ethClient, signer)
+	assert.Error(t, err)
+
+	_, err = (&Medianizer{Type: "unknown", Contract: address}).configureOracle(ethClient, signer)
+	assert.Error(t, err)
+}
+
+func TestGas_Configure(t *testing.T) {
+	ethClient := &ethereumMocks.Client{}
+	tests := []struct {
+		gas     Gas
+		want    ethereum.GasStrategy
+		wantErr bool
+	}{
+		{
+			gas:  Gas{},
+			want: ethClient,
+		},
+		{
+			gas:  Gas{Strategy: "fixed", PriorityFee: 1.5, MaxFee: 100},
+			want: ethereum.NewFixedGasStrategy(big.NewInt(1_500_000_000), big.NewInt(100_000_000_000)),
+		},
+		{
+			gas:     Gas{Strategy: "fixed"},
+			wantErr: true,
+		},
+		{
+			gas:  Gas{Strategy: "feeHistory"},
+			want: ethereum.NewFeeHistoryGasStrategy(ethClient, defaultFeeHistoryBlocks, defaultFeeHistoryPercentile),
+		},
+		{
+			gas:     Gas{Strategy: "feeHistory", Percentile: 101},
+			wantErr: true,
+		},
+		{
+			gas:  Gas{Strategy: "feeHistory", Blocks: 5, Percentile: 90, UrgencyMultiplier: 2},
+			want: ethereum.NewUrgencyGasStrategy(ethereum.NewFeeHistoryGasStrategy(ethClient, 5, 90), 2),
+		},
+		{
+			gas:     Gas{Strategy: "unknown"},
+			wantErr: true,
+		},
+	}
+	for n, tt := range tests {
+		t.Run(fmt.Sprintf("case-%d", n+1), func(t *testing.T) {
+			gas, err := tt.gas.Configure(ethClient)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, gas)
+		})
+	}
+}
+
 func secToDuration(s int64) time.Duration {
 	return time.Duration(s) * time.Second
 }
@@ -16,6 +16,7 @@
 package spire
 
 import (
+	pricestoreConfig "github.com/chronicleprotocol/oracle-suite/pkg/config/pricestore"
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
@@ -39,9 +40,10 @@ var priceStoreFactory = func(cfg store.Config) (*store.PriceStore, error) {
 }
 
 type Spire struct {
-	RPC           RPC      `yaml:"rpc"` // Old configuration format, to remove in the future.
-	RPCListenAddr string   `yaml:"rpcListenAddr"`
-	Pairs         []string `yaml:"pairs"`
+	RPC           RPC                      `yaml:"rpc"` // Old configuration format, to remove in the future.
+	RPCListenAddr string                   `yaml:"rpcListenAddr"`
+	Pairs         []string                 `yaml:"pairs"`
+	Storage       pricestoreConfig.Storage `yaml:"storage"`
 }
 
 type RPC struct {
@@ -97,8 +99,12 @@ func (c *Spire) ConfigureClient(d ClientDependencies) (*spire.Client, error) {
 }
 
 func (c *Spire) ConfigurePriceStore(d PriceStoreDependencies) (*store.PriceStore, error) {
+	sto, err := c.Storage.Configure()
+	if err != nil {
+		return nil, err
+	}
 	cfg := store.Config{
-		Storage:   store.NewMemoryStorage(),
+		Storage:   sto,
 		Signer:    d.Signer,
 		Transport: d.Transport,
 		Pairs:     c.Pairs,
@@ -0,0 +1,61 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tracing
+
+import (
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/tracing"
+)
+
+//nolint
+var tracingFactory = func(cfg tracing.Config) (*tracing.Tracing, error) {
+	return tracing.New(cfg)
+}
+
+type Tracing struct {
+	// Endpoint is the address of the OTLP/HTTP collector in the form
+	// "host:port". If empty, tracing is disabled.
+	Endpoint string `yaml:"endpoint"`
+	// Insecure disables TLS for the connection to the collector.
+	Insecure bool `yaml:"insecure"`
+	// SampleRatio is the fraction of traces to sample, between 0 and 1.
+	// If zero, all traces are sampled.
+	SampleRatio float64 `yaml:"sampleRatio"`
+}
+
+type Dependencies struct {
+	AppName string
+	Logger  log.Logger
+}
+
+// Configure returns a new tracing service. If the endpoint is not
+// configured, nil is returned.
+func (c *Tracing) Configure(d Dependencies) (*tracing.Tracing, error) {
+	if c.Endpoint == "" {
+		return nil, nil
+	}
+	sampleRatio := c.SampleRatio
+	if sampleRatio == 0 {
+		sampleRatio = 1
+	}
+	return tracingFactory(tracing.Config{
+		Endpoint:    c.Endpoint,
+		Insecure:    c.Insecure,
+		ServiceName: d.AppName,
+		SampleRatio: sampleRatio,
+		Logger:      d.Logger,
+	})
+}
@@ -0,0 +1,57 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/tracing"
+)
+
+func TestTracing_Configure(t *testing.T) {
+	prevTracingFactory := tracingFactory
+	defer func() { tracingFactory = prevTracingFactory }()
+
+	log := null.New()
+	config := Tracing{
+		Endpoint: "localhost:4318",
+		Insecure: true,
+	}
+
+	tracingFactory = func(cfg tracing.Config) (*tracing.Tracing, error) {
+		assert.Equal(t, config.Endpoint, cfg.Endpoint)
+		assert.True(t, cfg.Insecure)
+		assert.Equal(t, "app", cfg.ServiceName)
+		assert.Equal(t, float64(1), cfg.SampleRatio)
+		assert.Equal(t, log, cfg.Logger)
+		return &tracing.Tracing{}, nil
+	}
+
+	tr, err := config.Configure(Dependencies{AppName: "app", Logger: log})
+	require.NoError(t, err)
+	assert.NotNil(t, tr)
+}
+
+func TestTracing_Configure_Disabled(t *testing.T) {
+	config := Tracing{}
+	tr, err := config.Configure(Dependencies{AppName: "app", Logger: null.New()})
+	require.NoError(t, err)
+	assert.Nil(t, tr)
+}
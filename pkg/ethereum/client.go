@@ -44,6 +44,34 @@ type Transaction struct {
 	SignedTx interface{}
 }
 
+// Receipt is the receipt of a mined transaction.
+type Receipt struct {
+	// TransactionHash is the hash of the transaction.
+	TransactionHash Hash
+	// BlockNumber is the number of the block in which the transaction
+	// was included.
+	BlockNumber *big.Int
+	// GasUsed is the amount of gas used by the transaction.
+	GasUsed uint64
+	// Status is 1 if the transaction succeeded and 0 if it was reverted.
+	Status uint64
+}
+
+// FeeHistory contains the fee history for a range of blocks, as returned by
+// the eth_feeHistory method.
+type FeeHistory struct {
+	// OldestBlock is the number of the first block in the range.
+	OldestBlock *big.Int
+	// Reward contains priority fees at the requested percentiles for
+	// each block.
+	Reward [][]*big.Int
+	// BaseFee contains base fees for each block, including the next block
+	// after the range.
+	BaseFee []*big.Int
+	// GasUsedRatio contains gas used ratios for each block.
+	GasUsedRatio []float64
+}
+
 type Call struct {
 	// Address is the contract's address.
 	Address Address
@@ -69,11 +97,25 @@ type Client interface {
 	// SendTransaction injects a signed transaction into the pending pool
 	// for execution.
 	SendTransaction(ctx context.Context, transaction *Transaction) (*Hash, error)
+	// TransactionReceipt returns the receipt of a mined transaction. If the
+	// transaction is not mined yet, nil is returned.
+	TransactionReceipt(ctx context.Context, hash Hash) (*Receipt, error)
+	// PendingNonce returns the nonce that should be used for the next
+	// transaction sent from the given address.
+	PendingNonce(ctx context.Context, address Address) (uint64, error)
+	// SuggestFees returns the priority fee and the max fee that are used
+	// by SendTransaction if they are not specified in the transaction.
+	SuggestFees(ctx context.Context) (priorityFee *big.Int, maxFee *big.Int, err error)
+	// FeeHistory returns the fee history for the given number of the latest
+	// blocks. Priority fees are returned for the given percentiles.
+	FeeHistory(ctx context.Context, blocks int, percentiles []float64) (*FeeHistory, error)
 }
 
 type contextKey string
 
 const contextBlockNumber contextKey = "ethereum_block_number"
+const contextUrgency contextKey = "ethereum_urgency"
+const contextExplicitNonce contextKey = "ethereum_explicit_nonce"
 
 // WithBlockNumber sets the block number in the context.
 func WithBlockNumber(ctx context.Context, block *big.Int) context.Context {
@@ -88,3 +130,33 @@ func BlockNumberFromContext(ctx context.Context) *big.Int {
 	}
 	return nil
 }
+
+// WithUrgency sets the transaction urgency in the context. The urgency is
+// a value between 0 and 1, where 1 means that the transaction should be
+// mined as soon as possible. It is used by the UrgencyGasStrategy.
+func WithUrgency(ctx context.Context, urgency float64) context.Context {
+	return context.WithValue(ctx, contextUrgency, urgency)
+}
+
+// UrgencyFromContext returns the transaction urgency from the context.
+func UrgencyFromContext(ctx context.Context) float64 {
+	u, ok := ctx.Value(contextUrgency).(float64)
+	if ok {
+		return u
+	}
+	return 0
+}
+
+// WithExplicitNonce marks in the context that the nonce of sent transactions
+// is set explicitly, so a zero nonce must not be replaced with the next
+// available one.
+func WithExplicitNonce(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextExplicitNonce, true)
+}
+
+// ExplicitNonceFromContext returns true if the nonce of sent transactions
+// is set explicitly (see WithExplicitNonce).
+func ExplicitNonceFromContext(ctx context.Context) bool {
+	e, _ := ctx.Value(contextExplicitNonce).(bool)
+	return e
+}
@@ -0,0 +1,169 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+)
+
+// GasStrategy determines fees for new transactions.
+//
+// The Client interface implements the GasStrategy, so a client may be used
+// as a strategy that uses fees suggested by the node.
+type GasStrategy interface {
+	// SuggestFees returns the priority fee and the max fee for a new
+	// transaction.
+	SuggestFees(ctx context.Context) (priorityFee *big.Int, maxFee *big.Int, err error)
+}
+
+// FeeLimiter is an optional interface implemented by gas strategies that
+// limit fees. Fees of replacement transactions must not exceed the limits.
+// A nil limit means that the fee is not limited.
+type FeeLimiter interface {
+	// FeeLimits returns the maximum priority fee and the maximum max fee.
+	FeeLimits() (priorityFee *big.Int, maxFee *big.Int)
+}
+
+// FixedGasStrategy always returns the same fees.
+type FixedGasStrategy struct {
+	PriorityFee *big.Int
+	MaxFee      *big.Int
+}
+
+// NewFixedGasStrategy returns a new FixedGasStrategy instance.
+func NewFixedGasStrategy(priorityFee, maxFee *big.Int) *FixedGasStrategy {
+	return &FixedGasStrategy{PriorityFee: priorityFee, MaxFee: maxFee}
+}
+
+// SuggestFees implements the GasStrategy interface.
+func (s *FixedGasStrategy) SuggestFees(_ context.Context) (*big.Int, *big.Int, error) {
+	return new(big.Int).Set(s.PriorityFee), new(big.Int).Set(s.MaxFee), nil
+}
+
+// FeeLimits implements the FeeLimiter interface. Fixed fees are also
+// the maximum fees.
+func (s *FixedGasStrategy) FeeLimits() (*big.Int, *big.Int) {
+	return new(big.Int).Set(s.PriorityFee), new(big.Int).Set(s.MaxFee)
+}
+
+// FeeHistoryGasStrategy calculates fees based on the fee history of
+// the latest blocks. The priority fee is the median of priority fees paid
+// at the given percentile in each block, and the max fee is twice the next
+// block base fee plus the priority fee.
+type FeeHistoryGasStrategy struct {
+	client     Client
+	blocks     int
+	percentile float64
+}
+
+// NewFeeHistoryGasStrategy returns a new FeeHistoryGasStrategy instance that
+// uses the given number of the latest blocks and the given percentile
+// (0-100) of priority fees paid in each block.
+func NewFeeHistoryGasStrategy(client Client, blocks int, percentile float64) *FeeHistoryGasStrategy {
+	return &FeeHistoryGasStrategy{
+		client:     client,
+		blocks:     blocks,
+		percentile: percentile,
+	}
+}
+
+// SuggestFees implements the GasStrategy interface.
+func (s *FeeHistoryGasStrategy) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	fh, err := s.client.FeeHistory(ctx, s.blocks, []float64{s.percentile})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(fh.BaseFee) == 0 {
+		return nil, nil, errors.New("fee history is empty")
+	}
+	var rewards []*big.Int
+	for _, r := range fh.Reward {
+		if len(r) > 0 && r[0] != nil {
+			rewards = append(rewards, r[0])
+		}
+	}
+	priorityFee := new(big.Int)
+	if len(rewards) > 0 {
+		sort.Slice(rewards, func(i, j int) bool {
+			return rewards[i].Cmp(rewards[j]) < 0
+		})
+		priorityFee.Set(rewards[len(rewards)/2])
+	}
+	baseFee := fh.BaseFee[len(fh.BaseFee)-1]
+	maxFee := new(big.Int).Mul(baseFee, big.NewInt(2))
+	maxFee.Add(maxFee, priorityFee)
+	return priorityFee, maxFee, nil
+}
+
+// UrgencyGasStrategy scales fees returned by another strategy depending on
+// the transaction urgency stored in the context (see WithUrgency). Fees are
+// multiplied by a value between 1, for not urgent transactions, and
+// MaxMultiplier for transactions with the urgency of 1 or more.
+type UrgencyGasStrategy struct {
+	strategy      GasStrategy
+	maxMultiplier float64
+}
+
+// NewUrgencyGasStrategy returns a new UrgencyGasStrategy instance.
+func NewUrgencyGasStrategy(strategy GasStrategy, maxMultiplier float64) *UrgencyGasStrategy {
+	return &UrgencyGasStrategy{
+		strategy:      strategy,
+		maxMultiplier: maxMultiplier,
+	}
+}
+
+// SuggestFees implements the GasStrategy interface.
+func (s *UrgencyGasStrategy) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	priorityFee, maxFee, err := s.strategy.SuggestFees(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	urgency := UrgencyFromContext(ctx)
+	if urgency < 0 {
+		urgency = 0
+	}
+	if urgency > 1 {
+		urgency = 1
+	}
+	m := big.NewFloat(1 + (s.maxMultiplier-1)*urgency)
+	return mulBigInt(priorityFee, m), mulBigInt(maxFee, m), nil
+}
+
+// FeeLimits implements the FeeLimiter interface. If the underlying strategy
+// limits fees, the limits are multiplied by MaxMultiplier.
+func (s *UrgencyGasStrategy) FeeLimits() (*big.Int, *big.Int) {
+	l, ok := s.strategy.(FeeLimiter)
+	if !ok {
+		return nil, nil
+	}
+	priorityFee, maxFee := l.FeeLimits()
+	m := big.NewFloat(s.maxMultiplier)
+	if priorityFee != nil {
+		priorityFee = mulBigInt(priorityFee, m)
+	}
+	if maxFee != nil {
+		maxFee = mulBigInt(maxFee, m)
+	}
+	return priorityFee, maxFee
+}
+
+func mulBigInt(x *big.Int, m *big.Float) *big.Int {
+	r, _ := new(big.Float).Mul(new(big.Float).SetInt(x), m).Int(nil)
+	return r
+}
@@ -0,0 +1,102 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+)
+
+func TestFixedGasStrategy(t *testing.T) {
+	s := ethereum.NewFixedGasStrategy(big.NewInt(2), big.NewInt(100))
+	priorityFee, maxFee, err := s.SuggestFees(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(2), priorityFee)
+	assert.Equal(t, big.NewInt(100), maxFee)
+}
+
+func TestFeeHistoryGasStrategy(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.Client{}
+	cli.On("FeeHistory", ctx, 4, []float64{60}).Return(&ethereum.FeeHistory{
+		Reward: [][]*big.Int{
+			{big.NewInt(3)},
+			{big.NewInt(1)},
+			{big.NewInt(5)},
+			{big.NewInt(2)},
+		},
+		BaseFee: []*big.Int{big.NewInt(10), big.NewInt(12), big.NewInt(11), big.NewInt(13), big.NewInt(14)},
+	}, nil)
+
+	s := ethereum.NewFeeHistoryGasStrategy(cli, 4, 60)
+	priorityFee, maxFee, err := s.SuggestFees(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(3), priorityFee)
+	assert.Equal(t, big.NewInt(31), maxFee)
+}
+
+func TestFeeHistoryGasStrategy_Error(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.Client{}
+	cli.On("FeeHistory", ctx, 4, []float64{60}).Return((*ethereum.FeeHistory)(nil), errors.New("error"))
+
+	_, _, err := ethereum.NewFeeHistoryGasStrategy(cli, 4, 60).SuggestFees(ctx)
+	assert.Error(t, err)
+}
+
+func TestUrgencyGasStrategy(t *testing.T) {
+	s := ethereum.NewUrgencyGasStrategy(ethereum.NewFixedGasStrategy(big.NewInt(10), big.NewInt(100)), 3)
+	tests := []struct {
+		urgency     float64
+		priorityFee int64
+		maxFee      int64
+	}{
+		{urgency: -1, priorityFee: 10, maxFee: 100},
+		{urgency: 0, priorityFee: 10, maxFee: 100},
+		{urgency: 0.5, priorityFee: 20, maxFee: 200},
+		{urgency: 1, priorityFee: 30, maxFee: 300},
+		{urgency: 2, priorityFee: 30, maxFee: 300},
+	}
+	for _, tt := range tests {
+		priorityFee, maxFee, err := s.SuggestFees(ethereum.WithUrgency(context.Background(), tt.urgency))
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(tt.priorityFee), priorityFee)
+		assert.Equal(t, big.NewInt(tt.maxFee), maxFee)
+	}
+}
+
+func TestFeeLimits(t *testing.T) {
+	fixed := ethereum.NewFixedGasStrategy(big.NewInt(10), big.NewInt(100))
+	priorityFee, maxFee := fixed.FeeLimits()
+	assert.Equal(t, big.NewInt(10), priorityFee)
+	assert.Equal(t, big.NewInt(100), maxFee)
+
+	priorityFee, maxFee = ethereum.NewUrgencyGasStrategy(fixed, 3).FeeLimits()
+	assert.Equal(t, big.NewInt(30), priorityFee)
+	assert.Equal(t, big.NewInt(300), maxFee)
+
+	priorityFee, maxFee = ethereum.NewUrgencyGasStrategy(&mocks.Client{}, 3).FeeLimits()
+	assert.Nil(t, priorityFee)
+	assert.Nil(t, maxFee)
+}
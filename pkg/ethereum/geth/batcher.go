@@ -0,0 +1,135 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	pkgEthereum "github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+)
+
+// MultiCall3Address is the address of the Multicall3 contract, which is
+// deployed at the same address on most chains.
+//
+// https://github.com/mds1/multicall
+var MultiCall3Address = common.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+
+// ErrAllCallsFailed is returned by Batcher.SendBatch if none of the calls
+// would succeed.
+var ErrAllCallsFailed = errors.New("all calls in the batch failed")
+
+const batchGasOverhead = 50000
+
+type multiCall3Call struct {
+	Target       common.Address `abi:"target"`
+	AllowFailure bool           `abi:"allowFailure"`
+	CallData     []byte         `abi:"callData"`
+}
+
+type multiCall3Result struct {
+	Success    bool   `abi:"success"`
+	ReturnData []byte `abi:"returnData"`
+}
+
+// Batcher sends multiple calls in a single transaction using the Multicall3
+// contract. A failure of a single call does not revert the whole
+// transaction.
+type Batcher struct {
+	client          pkgEthereum.Client
+	address         common.Address
+	gasLimitPerCall uint64
+}
+
+// NewBatcher returns a new Batcher instance that uses the Multicall3
+// contract at the given address. The gas limit of the batch transaction is
+// calculated using the gasLimitPerCall value.
+func NewBatcher(client pkgEthereum.Client, address common.Address, gasLimitPerCall uint64) *Batcher {
+	return &Batcher{
+		client:          client,
+		address:         address,
+		gasLimitPerCall: gasLimitPerCall,
+	}
+}
+
+// SendBatch simulates all calls first and sends a transaction containing
+// only the calls that succeeded. It returns the transaction hash and a list
+// of errors for each call. If the call was skipped, the error is not nil.
+func (b *Batcher) SendBatch(ctx context.Context, calls []pkgEthereum.Call) (*pkgEthereum.Hash, []error, error) {
+	cd, err := packMultiCall3(calls)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := b.client.Call(ctx, pkgEthereum.Call{Address: b.address, Data: cd})
+	if err != nil {
+		return nil, nil, err
+	}
+	res, err := multiCall3ABI.Unpack("aggregate3", resp)
+	if err != nil {
+		return nil, nil, err
+	}
+	results := *abi.ConvertType(res[0], new([]multiCall3Result)).(*[]multiCall3Result)
+	if len(results) != len(calls) {
+		return nil, nil, errors.New("invalid number of results returned by the multicall contract")
+	}
+
+	var ok []pkgEthereum.Call
+	errs := make([]error, len(calls))
+	for i, r := range results {
+		if !r.Success {
+			errs[i] = ErrRevert{Message: revertReason(r.ReturnData)}
+			continue
+		}
+		ok = append(ok, calls[i])
+	}
+	if len(ok) == 0 {
+		return nil, errs, ErrAllCallsFailed
+	}
+
+	cd, err = packMultiCall3(ok)
+	if err != nil {
+		return nil, errs, err
+	}
+	hash, err := b.client.SendTransaction(ctx, &pkgEthereum.Transaction{
+		Address:  b.address,
+		GasLimit: new(big.Int).SetUint64(batchGasOverhead + b.gasLimitPerCall*uint64(len(ok))),
+		Data:     cd,
+	})
+	return hash, errs, err
+}
+
+func packMultiCall3(calls []pkgEthereum.Call) ([]byte, error) {
+	var abiCalls []multiCall3Call
+	for _, c := range calls {
+		abiCalls = append(abiCalls, multiCall3Call{
+			Target:       c.Address,
+			AllowFailure: true,
+			CallData:     c.Data,
+		})
+	}
+	return multiCall3ABI.Pack("aggregate3", abiCalls)
+}
+
+func revertReason(data []byte) string {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	return "unknown reason"
+}
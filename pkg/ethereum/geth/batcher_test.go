@@ -0,0 +1,88 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	pkgEthereum "github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+)
+
+func TestBatcher_SendBatch(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.Client{}
+	b := NewBatcher(cli, MultiCall3Address, 100000)
+
+	calls := []pkgEthereum.Call{
+		{Address: common.HexToAddress("0x01"), Data: []byte{1}},
+		{Address: common.HexToAddress("0x02"), Data: []byte{2}},
+		{Address: common.HexToAddress("0x03"), Data: []byte{3}},
+	}
+	resp, err := multiCall3ABI.Methods["aggregate3"].Outputs.Pack([]multiCall3Result{
+		{Success: true},
+		{Success: false, ReturnData: common.Hex2Bytes("08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000094e6f74206f776e65720000000000000000000000000000000000000000000000")},
+		{Success: true},
+	})
+	require.NoError(t, err)
+
+	hash := pkgEthereum.HexToHash("0x01")
+	cli.On("Call", ctx, mock.Anything).Return(resp, nil)
+	cli.On("SendTransaction", ctx, mock.Anything).Return(&hash, nil)
+
+	tx, errs, err := b.SendBatch(ctx, calls)
+	require.NoError(t, err)
+	assert.Equal(t, hash, *tx)
+	require.Len(t, errs, 3)
+	assert.NoError(t, errs[0])
+	assert.EqualError(t, errs[1], "reverted: Not owner")
+	assert.NoError(t, errs[2])
+
+	// Only successful calls must be sent:
+	sent := cli.Calls[1].Arguments.Get(1).(*pkgEthereum.Transaction)
+	assert.Equal(t, MultiCall3Address, sent.Address)
+	assert.Equal(t, big.NewInt(batchGasOverhead+2*100000), sent.GasLimit)
+	args, err := multiCall3ABI.Methods["aggregate3"].Inputs.Unpack(sent.Data[4:])
+	require.NoError(t, err)
+	var sentCalls []multiCall3Call
+	require.NoError(t, multiCall3ABI.Methods["aggregate3"].Inputs.Copy(&sentCalls, args))
+	assert.Equal(t, []multiCall3Call{
+		{Target: common.HexToAddress("0x01"), AllowFailure: true, CallData: []byte{1}},
+		{Target: common.HexToAddress("0x03"), AllowFailure: true, CallData: []byte{3}},
+	}, sentCalls)
+}
+
+func TestBatcher_SendBatch_AllFailed(t *testing.T) {
+	ctx := context.Background()
+	cli := &mocks.Client{}
+	b := NewBatcher(cli, MultiCall3Address, 100000)
+
+	resp, err := multiCall3ABI.Methods["aggregate3"].Outputs.Pack([]multiCall3Result{{Success: false}})
+	require.NoError(t, err)
+	cli.On("Call", ctx, mock.Anything).Return(resp, nil)
+
+	_, errs, err := b.SendBatch(ctx, []pkgEthereum.Call{{Address: common.HexToAddress("0x01")}})
+	assert.ErrorIs(t, err, ErrAllCallsFailed)
+	assert.Error(t, errs[0])
+	cli.AssertNotCalled(t, "SendTransaction", mock.Anything, mock.Anything)
+}
@@ -83,12 +83,20 @@ type EthClient interface {
 	NetworkID(ctx context.Context) (*big.Int, error)
 	BlockNumber(ctx context.Context) (uint64, error)
 	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error)
+	FeeHistory(
+		ctx context.Context,
+		blockCount uint64,
+		lastBlock *big.Int,
+		rewardPercentiles []float64,
+	) (*pkgEthereum.FeeHistory, error)
 }
 
 // Client implements the ethereum.Client interface.
 type Client struct {
 	ethClient EthClient
 	signer    pkgEthereum.Signer
+	nonces    *pkgEthereum.NonceManager
 }
 
 // NewClient returns a new Client instance.
@@ -96,6 +104,7 @@ func NewClient(ethClient EthClient, signer pkgEthereum.Signer) *Client {
 	return &Client{
 		ethClient: ethClient,
 		signer:    signer,
+		nonces:    pkgEthereum.NewNonceManager(ethClient.PendingNonceAt),
 	}
 }
 
@@ -203,6 +212,13 @@ func (e *Client) Storage(ctx context.Context, address pkgEthereum.Address, key p
 }
 
 // SendTransaction implements the ethereum.Client interface.
+//
+// If the transaction nonce is not specified, it is allocated locally by the
+// NonceManager, so multiple transactions may be sent at once. A zero nonce
+// is treated as not specified unless the context is marked with
+// ethereum.WithExplicitNonce. If the node
+// rejects the transaction because the nonce is out of sync, the nonce is
+// fetched from the node again and the transaction is resent once.
 func (e *Client) SendTransaction(ctx context.Context, transaction *pkgEthereum.Transaction) (*pkgEthereum.Hash, error) {
 	var err error
 
@@ -221,25 +237,24 @@ func (e *Client) SendTransaction(ctx context.Context, transaction *pkgEthereum.T
 	copy(tx.Data, transaction.Data)
 
 	// Fill optional values if necessary:
-	if tx.Nonce == 0 {
-		tx.Nonce, err = e.ethClient.PendingNonceAt(ctx, e.signer.Address())
+	autoNonce := tx.Nonce == 0 && tx.SignedTx == nil && !pkgEthereum.ExplicitNonceFromContext(ctx)
+	if autoNonce {
+		tx.Nonce, err = e.nonces.Next(ctx, e.signer.Address())
 		if err != nil {
 			return nil, err
 		}
 	}
 	if tx.PriorityFee == nil {
-		suggestedGasTipPrice, err := e.ethClient.SuggestGasTipCap(ctx)
+		tx.PriorityFee, err = e.suggestPriorityFee(ctx)
 		if err != nil {
 			return nil, err
 		}
-		tx.PriorityFee = suggestedGasTipPrice
 	}
 	if tx.MaxFee == nil {
-		suggestedGasPrice, err := e.ethClient.SuggestGasPrice(ctx)
+		tx.MaxFee, err = e.suggestMaxFee(ctx)
 		if err != nil {
 			return nil, err
 		}
-		tx.MaxFee = new(big.Int).Mul(suggestedGasPrice, big.NewInt(2))
 	}
 	if tx.ChainID == nil {
 		tx.ChainID, err = e.ethClient.NetworkID(ctx)
@@ -247,21 +262,104 @@ func (e *Client) SendTransaction(ctx context.Context, transaction *pkgEthereum.T
 			return nil, err
 		}
 	}
-	if tx.SignedTx == nil {
-		err = e.signer.SignTransaction(tx)
+
+	hash, err := e.sendTransaction(ctx, tx)
+	if err != nil && autoNonce && pkgEthereum.IsNonceError(err) {
+		// Local nonce is out of sync, try again with the nonce from the node:
+		e.nonces.Reset(e.signer.Address())
+		tx.Nonce, err = e.nonces.Next(ctx, e.signer.Address())
 		if err != nil {
 			return nil, err
 		}
+		tx.SignedTx = nil
+		hash, err = e.sendTransaction(ctx, tx)
+	}
+	if err != nil {
+		if e.signer != nil {
+			// The nonce may or may not have been used, so it is safer to
+			// fetch it from the node next time:
+			e.nonces.Reset(e.signer.Address())
+		}
+		return nil, err
+	}
+	if !autoNonce && e.signer != nil {
+		e.nonces.Observe(e.signer.Address(), tx.Nonce)
 	}
+	return hash, nil
+}
+
+// PendingNonce implements the ethereum.Client interface.
+//
+// The nonce is provided by the same NonceManager that is used by the
+// SendTransaction method.
+func (e *Client) PendingNonce(ctx context.Context, address pkgEthereum.Address) (uint64, error) {
+	return e.nonces.Peek(ctx, address)
+}
 
-	// Send transaction:
+// sendTransaction signs the transaction, if it's not signed yet, and sends it.
+func (e *Client) sendTransaction(ctx context.Context, tx *pkgEthereum.Transaction) (*pkgEthereum.Hash, error) {
+	if tx.SignedTx == nil {
+		if err := e.signer.SignTransaction(tx); err != nil {
+			return nil, err
+		}
+	}
 	if stx, ok := tx.SignedTx.(*types.Transaction); ok {
 		hash := stx.Hash()
-		return &hash, e.ethClient.SendTransaction(ctx, stx)
+		if err := e.ethClient.SendTransaction(ctx, stx); err != nil {
+			return nil, err
+		}
+		return &hash, nil
 	}
 	return nil, ErrInvalidSignedTxType
 }
 
+// TransactionReceipt implements the ethereum.Client interface.
+func (e *Client) TransactionReceipt(ctx context.Context, hash pkgEthereum.Hash) (*pkgEthereum.Receipt, error) {
+	r, err := e.ethClient.TransactionReceipt(ctx, hash)
+	if errors.Is(err, ethereum.NotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pkgEthereum.Receipt{
+		TransactionHash: r.TxHash,
+		BlockNumber:     r.BlockNumber,
+		GasUsed:         r.GasUsed,
+		Status:          r.Status,
+	}, nil
+}
+
+// SuggestFees implements the ethereum.Client interface.
+func (e *Client) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	priorityFee, err := e.suggestPriorityFee(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxFee, err := e.suggestMaxFee(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return priorityFee, maxFee, nil
+}
+
+// FeeHistory implements the ethereum.Client interface.
+func (e *Client) FeeHistory(ctx context.Context, blocks int, percentiles []float64) (*pkgEthereum.FeeHistory, error) {
+	return e.ethClient.FeeHistory(ctx, uint64(blocks), pkgEthereum.BlockNumberFromContext(ctx), percentiles)
+}
+
+func (e *Client) suggestPriorityFee(ctx context.Context) (*big.Int, error) {
+	return e.ethClient.SuggestGasTipCap(ctx)
+}
+
+func (e *Client) suggestMaxFee(ctx context.Context) (*big.Int, error) {
+	suggestedGasPrice, err := e.ethClient.SuggestGasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).Mul(suggestedGasPrice, big.NewInt(2)), nil
+}
+
 func isRevertResp(resp []byte) error {
 	revert, err := abi.UnpackRevert(resp)
 	if err != nil {
@@ -18,6 +18,7 @@ package geth
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"math/big"
 	"testing"
 
@@ -266,3 +267,127 @@ func TestClient_SendTransaction_Minimal(t *testing.T) {
 	assert.Equal(t, uint64(1000), stx.Gas())
 	assert.Equal(t, big.NewInt(mainnetChainID), stx.ChainId())
 }
+
+func TestClient_TransactionReceipt(t *testing.T) {
+	ethClient := &mocks.EthClient{}
+	client := NewClient(ethClient, nil)
+
+	minedHash := common.HexToHash("0x01")
+	pendingHash := common.HexToHash("0x02")
+
+	ethClient.On(
+		"TransactionReceipt",
+		mock.Anything,
+		minedHash,
+	).Return(&types.Receipt{TxHash: minedHash, BlockNumber: big.NewInt(42), GasUsed: 21000, Status: 1}, nil)
+
+	ethClient.On(
+		"TransactionReceipt",
+		mock.Anything,
+		pendingHash,
+	).Return((*types.Receipt)(nil), ethereum.NotFound)
+
+	r, err := client.TransactionReceipt(context.Background(), minedHash)
+	assert.NoError(t, err)
+	assert.Equal(t, &pkgEthereum.Receipt{
+		TransactionHash: minedHash,
+		BlockNumber:     big.NewInt(42),
+		GasUsed:         21000,
+		Status:          1,
+	}, r)
+
+	r, err = client.TransactionReceipt(context.Background(), pendingHash)
+	assert.NoError(t, err)
+	assert.Nil(t, r)
+}
+
+func TestClient_SendTransaction_LocalNonce(t *testing.T) {
+	account, _ := NewAccount("./testdata/keystore", "test123", clientAddress)
+	ethClient := &mocks.EthClient{}
+	client := NewClient(ethClient, NewSigner(account))
+
+	ethClient.On("PendingNonceAt", mock.Anything, clientAddress).Return(10, nil).Once()
+	ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Times(3)
+
+	tx := &pkgEthereum.Transaction{
+		Address:     clientContractAddress,
+		PriorityFee: big.NewInt(50),
+		MaxFee:      big.NewInt(100),
+		GasLimit:    big.NewInt(1000),
+		Data:        clientCallData,
+		ChainID:     big.NewInt(mainnetChainID),
+	}
+
+	_, err := client.SendTransaction(context.Background(), tx)
+	assert.NoError(t, err)
+	_, err = client.SendTransaction(context.Background(), tx)
+	assert.NoError(t, err)
+
+	// Transaction with explicit nonce must not reuse locally allocated nonces:
+	tx.Nonce = 20
+	_, err = client.SendTransaction(context.Background(), tx)
+	assert.NoError(t, err)
+
+	nonce, err := client.PendingNonce(context.Background(), clientAddress)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(21), nonce)
+
+	calls := ethClient.Calls()
+	assert.Equal(t, uint64(10), calls[1].Arguments.Get(1).(*types.Transaction).Nonce())
+	assert.Equal(t, uint64(11), calls[2].Arguments.Get(1).(*types.Transaction).Nonce())
+	assert.Equal(t, uint64(20), calls[3].Arguments.Get(1).(*types.Transaction).Nonce())
+	ethClient.AssertNumberOfCalls(t, "PendingNonceAt", 1)
+}
+
+func TestClient_SendTransaction_ExplicitZeroNonce(t *testing.T) {
+	account, _ := NewAccount("./testdata/keystore", "test123", clientAddress)
+	ethClient := &mocks.EthClient{}
+	client := NewClient(ethClient, NewSigner(account))
+
+	ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+
+	tx := &pkgEthereum.Transaction{
+		Address:     clientContractAddress,
+		PriorityFee: big.NewInt(50),
+		MaxFee:      big.NewInt(100),
+		GasLimit:    big.NewInt(1000),
+		Data:        clientCallData,
+		ChainID:     big.NewInt(mainnetChainID),
+	}
+
+	_, err := client.SendTransaction(pkgEthereum.WithExplicitNonce(context.Background()), tx)
+	assert.NoError(t, err)
+
+	stx := ethClient.Calls()[0].Arguments.Get(1).(*types.Transaction)
+	assert.Equal(t, uint64(0), stx.Nonce())
+	ethClient.AssertNotCalled(t, "PendingNonceAt", mock.Anything, mock.Anything)
+}
+
+func TestClient_SendTransaction_NonceTooLow(t *testing.T) {
+	account, _ := NewAccount("./testdata/keystore", "test123", clientAddress)
+	ethClient := &mocks.EthClient{}
+	client := NewClient(ethClient, NewSigner(account))
+
+	ethClient.On("PendingNonceAt", mock.Anything, clientAddress).Return(10, nil).Once()
+	ethClient.On("PendingNonceAt", mock.Anything, clientAddress).Return(15, nil).Once()
+	ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(errors.New("nonce too low")).Once()
+	ethClient.On("SendTransaction", mock.Anything, mock.Anything).Return(nil).Once()
+
+	tx := &pkgEthereum.Transaction{
+		Address:     clientContractAddress,
+		PriorityFee: big.NewInt(50),
+		MaxFee:      big.NewInt(100),
+		GasLimit:    big.NewInt(1000),
+		Data:        clientCallData,
+		ChainID:     big.NewInt(mainnetChainID),
+	}
+
+	hash, err := client.SendTransaction(context.Background(), tx)
+	assert.NoError(t, err)
+
+	calls := ethClient.Calls()
+	stx := calls[3].Arguments.Get(1).(*types.Transaction)
+	assert.Equal(t, uint64(10), calls[1].Arguments.Get(1).(*types.Transaction).Nonce())
+	assert.Equal(t, uint64(15), stx.Nonce())
+	assert.Equal(t, stx.Hash(), *hash)
+}
@@ -0,0 +1,83 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	pkgEthereum "github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+)
+
+// rpcEthClient extends the ethclient.Client with methods that are not
+// implemented by the go-ethereum package.
+type rpcEthClient struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+// NewEthClient returns a new EthClient instance that uses the given RPC
+// client.
+func NewEthClient(rpcClient *rpc.Client) EthClient {
+	return &rpcEthClient{
+		Client: ethclient.NewClient(rpcClient),
+		rpc:    rpcClient,
+	}
+}
+
+// FeeHistory implements the EthClient interface.
+func (c *rpcEthClient) FeeHistory(
+	ctx context.Context,
+	blockCount uint64,
+	lastBlock *big.Int,
+	rewardPercentiles []float64,
+) (*pkgEthereum.FeeHistory, error) {
+
+	var res struct {
+		OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+		Reward       [][]*hexutil.Big `json:"reward"`
+		BaseFee      []*hexutil.Big   `json:"baseFeePerGas"`
+		GasUsedRatio []float64        `json:"gasUsedRatio"`
+	}
+	block := "latest"
+	if lastBlock != nil {
+		block = hexutil.EncodeBig(lastBlock)
+	}
+	err := c.rpc.CallContext(ctx, &res, "eth_feeHistory", hexutil.Uint64(blockCount), block, rewardPercentiles)
+	if err != nil {
+		return nil, err
+	}
+	fh := &pkgEthereum.FeeHistory{
+		OldestBlock:  (*big.Int)(res.OldestBlock),
+		Reward:       make([][]*big.Int, len(res.Reward)),
+		BaseFee:      make([]*big.Int, len(res.BaseFee)),
+		GasUsedRatio: res.GasUsedRatio,
+	}
+	for i, r := range res.Reward {
+		fh.Reward[i] = make([]*big.Int, len(r))
+		for j, v := range r {
+			fh.Reward[i][j] = (*big.Int)(v)
+		}
+	}
+	for i, v := range res.BaseFee {
+		fh.BaseFee[i] = (*big.Int)(v)
+	}
+	return fh, nil
+}
@@ -0,0 +1,62 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEthClient_FeeHistory(t *testing.T) {
+	var req struct {
+		Method string            `json:"method"`
+		Params []json.RawMessage `json:"params"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &req))
+		_, _ = w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":{` +
+			`"oldestBlock":"0x10",` +
+			`"reward":[["0x1"],["0x2"]],` +
+			`"baseFeePerGas":["0xa","0xb","0xc"],` +
+			`"gasUsedRatio":[0.5,0.25]}}`))
+	}))
+	defer srv.Close()
+
+	rpcClient, err := rpc.DialHTTP(srv.URL)
+	require.NoError(t, err)
+
+	fh, err := NewEthClient(rpcClient).FeeHistory(context.Background(), 2, nil, []float64{50})
+	require.NoError(t, err)
+
+	assert.Equal(t, "eth_feeHistory", req.Method)
+	assert.Equal(t, `"0x2"`, string(req.Params[0]))
+	assert.Equal(t, `"latest"`, string(req.Params[1]))
+	assert.Equal(t, `[50]`, string(req.Params[2]))
+	assert.Equal(t, big.NewInt(16), fh.OldestBlock)
+	assert.Equal(t, [][]*big.Int{{big.NewInt(1)}, {big.NewInt(2)}}, fh.Reward)
+	assert.Equal(t, []*big.Int{big.NewInt(10), big.NewInt(11), big.NewInt(12)}, fh.BaseFee)
+	assert.Equal(t, []float64{0.5, 0.25}, fh.GasUsedRatio)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stretchr/testify/mock"
+
+	pkgEthereum "github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 )
 
 type EthClient struct {
@@ -101,6 +103,25 @@ func (e *EthClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]t
 	return args.Get(0).([]types.Log), args.Error(1)
 }
 
+func (e *EthClient) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	args := e.Called(ctx, txHash)
+	return args.Get(0).(*types.Receipt), args.Error(1)
+}
+
+func (e *EthClient) FeeHistory(
+	ctx context.Context,
+	blockCount uint64,
+	lastBlock *big.Int,
+	rewardPercentiles []float64,
+) (*pkgEthereum.FeeHistory, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	args := e.Called(ctx, blockCount, lastBlock, rewardPercentiles)
+	return args.Get(0).(*pkgEthereum.FeeHistory), args.Error(1)
+}
+
 func (e *EthClient) Calls() []mock.Call {
 	e.mu.Lock()
 	defer e.mu.Unlock()
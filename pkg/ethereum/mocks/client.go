@@ -57,3 +57,23 @@ func (e *Client) SendTransaction(ctx context.Context, transaction *ethereum.Tran
 	args := e.Called(ctx, transaction)
 	return args.Get(0).(*ethereum.Hash), args.Error(1)
 }
+
+func (e *Client) TransactionReceipt(ctx context.Context, hash ethereum.Hash) (*ethereum.Receipt, error) {
+	args := e.Called(ctx, hash)
+	return args.Get(0).(*ethereum.Receipt), args.Error(1)
+}
+
+func (e *Client) PendingNonce(ctx context.Context, address ethereum.Address) (uint64, error) {
+	args := e.Called(ctx, address)
+	return args.Get(0).(uint64), args.Error(1)
+}
+
+func (e *Client) SuggestFees(ctx context.Context) (*big.Int, *big.Int, error) {
+	args := e.Called(ctx)
+	return args.Get(0).(*big.Int), args.Get(1).(*big.Int), args.Error(2)
+}
+
+func (e *Client) FeeHistory(ctx context.Context, blocks int, percentiles []float64) (*ethereum.FeeHistory, error) {
+	args := e.Called(ctx, blocks, percentiles)
+	return args.Get(0).(*ethereum.FeeHistory), args.Error(1)
+}
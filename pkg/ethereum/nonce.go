@@ -0,0 +1,120 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// NonceSource returns the nonce that should be used for the next transaction
+// sent from the given address, according to the blockchain state.
+type NonceSource func(ctx context.Context, address Address) (uint64, error)
+
+// NonceManager allocates transaction nonces locally, so that multiple
+// transactions can be sent from the same address without asking the node
+// for the pending nonce every time. The local state is initialized from
+// the NonceSource and may be resynchronized using the Reset method.
+type NonceManager struct {
+	mu sync.Mutex
+
+	source NonceSource
+	nonces map[Address]uint64
+}
+
+// NewNonceManager creates a new NonceManager instance.
+func NewNonceManager(source NonceSource) *NonceManager {
+	return &NonceManager{
+		source: source,
+		nonces: make(map[Address]uint64),
+	}
+}
+
+// Next allocates and returns the next nonce for the given address.
+func (n *NonceManager) Next(ctx context.Context, address Address) (uint64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	nonce, err := n.peek(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	n.nonces[address] = nonce + 1
+	return nonce, nil
+}
+
+// Peek returns the next nonce for the given address without allocating it.
+func (n *NonceManager) Peek(ctx context.Context, address Address) (uint64, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.peek(ctx, address)
+}
+
+// Observe marks the nonce as used by a transaction which nonce was not
+// allocated by the NonceManager.
+func (n *NonceManager) Observe(address Address, nonce uint64) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if next, ok := n.nonces[address]; ok && next > nonce {
+		return
+	}
+	n.nonces[address] = nonce + 1
+}
+
+// Reset removes the local state for the given address, so the next nonce
+// will be fetched from the NonceSource again.
+func (n *NonceManager) Reset(address Address) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.nonces, address)
+}
+
+func (n *NonceManager) peek(ctx context.Context, address Address) (uint64, error) {
+	if nonce, ok := n.nonces[address]; ok {
+		return nonce, nil
+	}
+	nonce, err := n.source(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	n.nonces[address] = nonce
+	return nonce, nil
+}
+
+// IsNonceError returns true if the error returned by a node indicates that
+// the transaction nonce is out of sync with the blockchain state.
+func IsNonceError(err error) bool {
+	return IsNonceTooLow(err) ||
+		errorContains(err, "nonce too high") ||
+		IsReplacementUnderpriced(err)
+}
+
+// IsNonceTooLow returns true if the error returned by a node indicates that
+// the transaction nonce was already used by a mined transaction.
+func IsNonceTooLow(err error) bool {
+	return errorContains(err, "nonce too low")
+}
+
+// IsReplacementUnderpriced returns true if the error returned by a node
+// indicates that a pending transaction with the same nonce exists and
+// the fees of the new transaction are too low to replace it.
+func IsReplacementUnderpriced(err error) bool {
+	return errorContains(err, "replacement transaction underpriced")
+}
+
+func errorContains(err error, s string) bool {
+	return err != nil && strings.Contains(strings.ToLower(err.Error()), s)
+}
@@ -0,0 +1,91 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceManager(t *testing.T) {
+	ctx := context.Background()
+	addr1 := HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	addr2 := HexToAddress("0x8eb3daaf5cb4138f5f96711c09c0cfd0288a36e9")
+
+	calls := 0
+	chain := map[Address]uint64{addr1: 10, addr2: 20}
+	n := NewNonceManager(func(ctx context.Context, address Address) (uint64, error) {
+		calls++
+		return chain[address], nil
+	})
+
+	assert.Equal(t, uint64(10), mustNonce(t)(n.Next(ctx, addr1)))
+	assert.Equal(t, uint64(11), mustNonce(t)(n.Next(ctx, addr1)))
+	assert.Equal(t, uint64(20), mustNonce(t)(n.Next(ctx, addr2)))
+	assert.Equal(t, uint64(12), mustNonce(t)(n.Peek(ctx, addr1)))
+	assert.Equal(t, uint64(12), mustNonce(t)(n.Next(ctx, addr1)))
+	assert.Equal(t, 2, calls)
+
+	// Observed nonces must not be allocated again:
+	n.Observe(addr1, 15)
+	n.Observe(addr1, 5)
+	assert.Equal(t, uint64(16), mustNonce(t)(n.Next(ctx, addr1)))
+
+	// After reset, the nonce must be fetched again:
+	chain[addr1] = 13
+	n.Reset(addr1)
+	assert.Equal(t, uint64(13), mustNonce(t)(n.Next(ctx, addr1)))
+	assert.Equal(t, 3, calls)
+}
+
+func TestNonceManager_SourceError(t *testing.T) {
+	ctx := context.Background()
+	addr := HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	n := NewNonceManager(func(ctx context.Context, address Address) (uint64, error) {
+		return 0, errors.New("error")
+	})
+
+	_, err := n.Next(ctx, addr)
+	assert.Error(t, err)
+}
+
+func TestIsNonceError(t *testing.T) {
+	assert.True(t, IsNonceError(errors.New("nonce too low")))
+	assert.True(t, IsNonceError(errors.New("Nonce too high")))
+	assert.True(t, IsNonceError(errors.New("replacement transaction underpriced")))
+	assert.False(t, IsNonceError(errors.New("insufficient funds for gas * price + value")))
+	assert.False(t, IsNonceError(nil))
+}
+
+func TestIsNonceTooLow(t *testing.T) {
+	assert.True(t, IsNonceTooLow(errors.New("Nonce too low")))
+	assert.False(t, IsNonceTooLow(errors.New("nonce too high")))
+	assert.False(t, IsNonceTooLow(errors.New("replacement transaction underpriced")))
+	assert.True(t, IsReplacementUnderpriced(errors.New("replacement transaction underpriced")))
+	assert.False(t, IsReplacementUnderpriced(errors.New("nonce too low")))
+	assert.False(t, IsReplacementUnderpriced(nil))
+}
+
+func mustNonce(t *testing.T) func(uint64, error) uint64 {
+	return func(n uint64, err error) uint64 {
+		require.NoError(t, err)
+		return n
+	}
+}
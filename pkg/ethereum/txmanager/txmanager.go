@@ -0,0 +1,317 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+const LoggerTag = "TX_MANAGER"
+
+const defaultReplaceTimeout = 5 * time.Minute
+const defaultFeeBump = 0.125 // Nodes require at least 10% bump to replace a transaction.
+
+// ErrTransactionInFlight is returned by the Manager.Send method if there is
+// already a pending transaction for the given key.
+var ErrTransactionInFlight = errors.New("previous transaction is still pending")
+
+// Config is the configuration for the Manager.
+type Config struct {
+	// Client is the Ethereum client used to send transactions.
+	Client ethereum.Client
+	// Signer is the signer used to sign transactions. It is used to
+	// determine the address from which transactions are sent.
+	Signer ethereum.Signer
+	// ReplaceTimeout is the time after which a pending transaction is
+	// replaced with a new one with bumped fees. If zero, the default value
+	// of 5 minutes is used.
+	ReplaceTimeout time.Duration
+	// FeeBump is the fraction by which fees are increased when
+	// a transaction is replaced, e.g. 0.125 means 12.5%. If zero, the default
+	// value of 0.125 is used.
+	FeeBump float64
+	// Logger is a current logger interface used by the Manager.
+	Logger log.Logger
+}
+
+// Manager tracks transactions sent on behalf of different keys, for example
+// asset pairs, and makes sure that there is at most one pending transaction
+// per key. Transactions that are pending for too long are replaced with
+// transactions with the same nonce and bumped fees.
+type Manager struct {
+	mu sync.Mutex
+
+	client         ethereum.Client
+	signer         ethereum.Signer
+	replaceTimeout time.Duration
+	feeBump        float64
+	pending        map[string]*pendingTx
+	log            log.Logger
+}
+
+// pendingTx describes the last transaction sent for a key and all
+// transactions it replaced.
+type pendingTx struct {
+	nonce       uint64
+	priorityFee *big.Int
+	maxFee      *big.Int
+	hashes      []ethereum.Hash
+	sentAt      time.Time
+}
+
+// New creates a new Manager instance.
+func New(cfg Config) (*Manager, error) {
+	if cfg.Client == nil {
+		return nil, errors.New("client must not be nil")
+	}
+	if cfg.Signer == nil {
+		return nil, errors.New("signer must not be nil")
+	}
+	if cfg.ReplaceTimeout == 0 {
+		cfg.ReplaceTimeout = defaultReplaceTimeout
+	}
+	if cfg.FeeBump == 0 {
+		cfg.FeeBump = defaultFeeBump
+	}
+	if cfg.FeeBump < 0 {
+		return nil, errors.New("fee bump must not be negative")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	return &Manager{
+		client:         cfg.Client,
+		signer:         cfg.Signer,
+		replaceTimeout: cfg.ReplaceTimeout,
+		feeBump:        cfg.FeeBump,
+		pending:        make(map[string]*pendingTx),
+		log:            cfg.Logger.WithField("tag", LoggerTag),
+	}, nil
+}
+
+// Send sends the transaction on behalf of the given key.
+//
+// If the transaction fees are not specified, fees suggested by the client
+// are used. If the previous transaction sent for the same key is not mined
+// yet, and it was sent less than ReplaceTimeout ago, ErrTransactionInFlight
+// is returned. After that time, the given transaction replaces the pending
+// one, using the same nonce and fees increased by FeeBump, or the new fees
+// if they are higher.
+func (m *Manager) Send(ctx context.Context, key string, transaction *ethereum.Transaction) (*ethereum.Hash, error) {
+	return m.send(ctx, key, transaction, nil)
+}
+
+// send sends the transaction as described in the Send method. If the gas
+// strategy implements the ethereum.FeeLimiter interface, the pending
+// transaction is replaced only if the bumped fees do not exceed the limits.
+func (m *Manager) send(ctx context.Context, key string, transaction *ethereum.Transaction, gas ethereum.GasStrategy) (*ethereum.Hash, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	p := m.pending[key]
+	if p != nil {
+		mined, err := m.checkReceipts(ctx, key, p)
+		if err != nil {
+			return nil, err
+		}
+		if mined {
+			delete(m.pending, key)
+			p = nil
+		}
+	}
+	if p != nil && time.Since(p.sentAt) < m.replaceTimeout {
+		return nil, ErrTransactionInFlight
+	}
+
+	var err error
+	tx := *transaction
+	tx.SignedTx = nil
+	if tx.PriorityFee == nil || tx.MaxFee == nil {
+		priorityFee, maxFee, err := m.client.SuggestFees(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if tx.PriorityFee == nil {
+			tx.PriorityFee = priorityFee
+		}
+		if tx.MaxFee == nil {
+			tx.MaxFee = maxFee
+		}
+	}
+	if p == nil {
+		tx.Nonce, err = m.client.PendingNonce(ctx, m.signer.Address())
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		tx.Nonce = p.nonce
+		tx.PriorityFee = maxBigInt(m.bump(p.priorityFee), tx.PriorityFee)
+		tx.MaxFee = maxBigInt(m.bump(p.maxFee), tx.MaxFee)
+		if l, ok := gas.(ethereum.FeeLimiter); ok {
+			priorityFeeLimit, maxFeeLimit := l.FeeLimits()
+			if exceeds(tx.PriorityFee, priorityFeeLimit) || exceeds(tx.MaxFee, maxFeeLimit) {
+				// Fees cannot be bumped enough to replace the pending
+				// transaction without exceeding the limits, so we have
+				// to wait for it to be mined.
+				m.log.
+					WithFields(log.Fields{"key": key, "nonce": p.nonce}).
+					Warn("Unable to replace transaction, fees would exceed the limits")
+				return nil, ErrTransactionInFlight
+			}
+		}
+	}
+	if tx.PriorityFee.Cmp(tx.MaxFee) > 0 {
+		tx.MaxFee = tx.PriorityFee
+	}
+
+	// The nonce is always set here, even if it is zero:
+	hash, err := m.client.SendTransaction(ethereum.WithExplicitNonce(ctx), &tx)
+	if err != nil {
+		switch {
+		case p != nil && ethereum.IsNonceTooLow(err):
+			// The nonce was already used, which means that one of
+			// the pending transactions was mined in the meantime, so
+			// the next transaction will use a new nonce.
+			delete(m.pending, key)
+		case p != nil && ethereum.IsReplacementUnderpriced(err):
+			// The pending transaction is still in the mempool, so
+			// another transaction must not be sent until it is mined
+			// or replaced. The next replacement will use fees bumped
+			// from the ones rejected now.
+			m.log.
+				WithError(err).
+				WithFields(log.Fields{"key": key, "nonce": p.nonce}).
+				Warn("Unable to replace transaction")
+			p.priorityFee = tx.PriorityFee
+			p.maxFee = tx.MaxFee
+			p.sentAt = time.Now()
+			return nil, ErrTransactionInFlight
+		}
+		return nil, err
+	}
+
+	fields := log.Fields{
+		"key":         key,
+		"tx":          hash.String(),
+		"nonce":       tx.Nonce,
+		"priorityFee": tx.PriorityFee.String(),
+		"maxFee":      tx.MaxFee.String(),
+	}
+	if p == nil {
+		p = &pendingTx{nonce: tx.Nonce}
+		m.pending[key] = p
+		m.log.WithFields(fields).Debug("Transaction sent")
+	} else {
+		m.log.WithFields(fields).Warn("Transaction replaced")
+	}
+	p.priorityFee = tx.PriorityFee
+	p.maxFee = tx.MaxFee
+	p.hashes = append(p.hashes, *hash)
+	p.sentAt = time.Now()
+	return hash, nil
+}
+
+// Client returns an ethereum.Client that sends transactions using the
+// Manager on behalf of the given key. If gas is not nil, it is used to
+// determine fees for transactions that do not specify them. If gas
+// implements the ethereum.FeeLimiter interface, its limits also apply to
+// replacement transactions. Other methods are delegated to the underlying
+// client.
+func (m *Manager) Client(key string, gas ethereum.GasStrategy) ethereum.Client {
+	return &client{Client: m.client, manager: m, key: key, gas: gas}
+}
+
+// checkReceipts checks if any of the pending transactions was mined.
+func (m *Manager) checkReceipts(ctx context.Context, key string, p *pendingTx) (bool, error) {
+	for _, hash := range p.hashes {
+		r, err := m.client.TransactionReceipt(ctx, hash)
+		if err != nil {
+			return false, err
+		}
+		if r == nil {
+			continue
+		}
+		fields := log.Fields{
+			"key":     key,
+			"tx":      hash.String(),
+			"block":   r.BlockNumber.String(),
+			"gasUsed": r.GasUsed,
+		}
+		if r.Status == 0 {
+			m.log.WithFields(fields).Warn("Transaction reverted")
+		} else {
+			m.log.WithFields(fields).Info("Transaction mined")
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+func (m *Manager) bump(v *big.Int) *big.Int {
+	b, _ := new(big.Float).Mul(new(big.Float).SetInt(v), big.NewFloat(1+m.feeBump)).Int(nil)
+	// Make sure that the value is increased even for very small fees:
+	if b.Cmp(v) <= 0 {
+		b.Add(v, big.NewInt(1))
+	}
+	return b
+}
+
+type client struct {
+	ethereum.Client
+	manager *Manager
+	key     string
+	gas     ethereum.GasStrategy
+}
+
+// SendTransaction implements the ethereum.Client interface.
+func (c *client) SendTransaction(ctx context.Context, tx *ethereum.Transaction) (*ethereum.Hash, error) {
+	if c.gas != nil && (tx.PriorityFee == nil || tx.MaxFee == nil) {
+		priorityFee, maxFee, err := c.gas.SuggestFees(ctx)
+		if err != nil {
+			return nil, err
+		}
+		cpy := *tx
+		if cpy.PriorityFee == nil {
+			cpy.PriorityFee = priorityFee
+		}
+		if cpy.MaxFee == nil {
+			cpy.MaxFee = maxFee
+		}
+		tx = &cpy
+	}
+	return c.manager.send(ctx, c.key, tx, c.gas)
+}
+
+// exceeds returns true if v is greater than the limit. A nil limit means
+// that there is no limit.
+func exceeds(v, limit *big.Int) bool {
+	return limit != nil && v.Cmp(limit) > 0
+}
+
+func maxBigInt(a, b *big.Int) *big.Int {
+	if a.Cmp(b) >= 0 {
+		return a
+	}
+	return b
+}
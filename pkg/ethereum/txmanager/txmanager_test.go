@@ -0,0 +1,275 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package txmanager
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+)
+
+var testAddress = ethereum.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+
+func newTestManager(t *testing.T) (*Manager, *mocks.Client) {
+	cli := &mocks.Client{}
+	sig := &mocks.Signer{}
+	sig.On("Address").Return(testAddress)
+	m, err := New(Config{Client: cli, Signer: sig, ReplaceTimeout: time.Minute})
+	require.NoError(t, err)
+	return m, cli
+}
+
+func sentTx(cli *mocks.Client, n int) *ethereum.Transaction {
+	var txs []*ethereum.Transaction
+	for _, c := range cli.Calls {
+		if c.Method == "SendTransaction" {
+			txs = append(txs, c.Arguments.Get(1).(*ethereum.Transaction))
+		}
+	}
+	return txs[n]
+}
+
+func TestManager_Send(t *testing.T) {
+	ctx := context.Background()
+	m, cli := newTestManager(t)
+
+	hash1 := ethereum.HexToHash("0x01")
+	hash2 := ethereum.HexToHash("0x02")
+	hash3 := ethereum.HexToHash("0x03")
+
+	cli.On("SuggestFees", ctx).Return(big.NewInt(10), big.NewInt(100), nil)
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(5), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash1, nil).Once()
+	cli.On("TransactionReceipt", ctx, hash1).Return((*ethereum.Receipt)(nil), nil)
+
+	// First transaction:
+	tx, err := m.Send(ctx, "AAABBB", &ethereum.Transaction{Data: []byte{1}})
+	require.NoError(t, err)
+	assert.Equal(t, hash1, *tx)
+	assert.Equal(t, uint64(5), sentTx(cli, 0).Nonce)
+	assert.Equal(t, big.NewInt(10), sentTx(cli, 0).PriorityFee)
+	assert.Equal(t, big.NewInt(100), sentTx(cli, 0).MaxFee)
+
+	// The first transaction is still pending:
+	_, err = m.Send(ctx, "AAABBB", &ethereum.Transaction{Data: []byte{2}})
+	assert.ErrorIs(t, err, ErrTransactionInFlight)
+
+	// After the timeout, the transaction must be replaced using the same
+	// nonce and bumped fees:
+	m.pending["AAABBB"].sentAt = time.Now().Add(-2 * time.Minute)
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash2, nil).Once()
+	cli.On("TransactionReceipt", ctx, hash2).Return(&ethereum.Receipt{
+		TransactionHash: hash2,
+		BlockNumber:     big.NewInt(1),
+		Status:          1,
+	}, nil)
+
+	tx, err = m.Send(ctx, "AAABBB", &ethereum.Transaction{Data: []byte{3}})
+	require.NoError(t, err)
+	assert.Equal(t, hash2, *tx)
+	assert.Equal(t, uint64(5), sentTx(cli, 1).Nonce)
+	assert.Equal(t, big.NewInt(11), sentTx(cli, 1).PriorityFee)
+	assert.Equal(t, big.NewInt(112), sentTx(cli, 1).MaxFee)
+	assert.Equal(t, []byte{3}, sentTx(cli, 1).Data)
+
+	// The replacement transaction was mined, so a new transaction must
+	// use a new nonce:
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(6), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash3, nil).Once()
+
+	tx, err = m.Send(ctx, "AAABBB", &ethereum.Transaction{Data: []byte{4}})
+	require.NoError(t, err)
+	assert.Equal(t, hash3, *tx)
+	assert.Equal(t, uint64(6), sentTx(cli, 2).Nonce)
+	assert.Equal(t, big.NewInt(10), sentTx(cli, 2).PriorityFee)
+}
+
+func TestManager_Send_IndependentKeys(t *testing.T) {
+	ctx := context.Background()
+	m, cli := newTestManager(t)
+
+	hash1 := ethereum.HexToHash("0x01")
+	hash2 := ethereum.HexToHash("0x02")
+
+	cli.On("SuggestFees", ctx).Return(big.NewInt(10), big.NewInt(100), nil)
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(5), nil).Once()
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(6), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash1, nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash2, nil).Once()
+
+	_, err := m.Client("AAABBB", nil).SendTransaction(ctx, &ethereum.Transaction{})
+	require.NoError(t, err)
+	_, err = m.Client("XXXYYY", nil).SendTransaction(ctx, &ethereum.Transaction{})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(5), sentTx(cli, 0).Nonce)
+	assert.Equal(t, uint64(6), sentTx(cli, 1).Nonce)
+}
+
+func TestManager_Send_ZeroNonce(t *testing.T) {
+	ctx := context.Background()
+	m, cli := newTestManager(t)
+
+	hash1 := ethereum.HexToHash("0x01")
+	hash2 := ethereum.HexToHash("0x02")
+
+	cli.On("SuggestFees", ctx).Return(big.NewInt(10), big.NewInt(100), nil)
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(0), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash1, nil).Once()
+	cli.On("TransactionReceipt", ctx, mock.Anything).Return((*ethereum.Receipt)(nil), nil)
+
+	_, err := m.Send(ctx, "AAABBB", &ethereum.Transaction{})
+	require.NoError(t, err)
+
+	// The first transaction of a new account must be replaceable too:
+	m.pending["AAABBB"].sentAt = time.Now().Add(-2 * time.Minute)
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash2, nil).Once()
+
+	tx, err := m.Send(ctx, "AAABBB", &ethereum.Transaction{})
+	require.NoError(t, err)
+	assert.Equal(t, hash2, *tx)
+	assert.Equal(t, uint64(0), sentTx(cli, 1).Nonce)
+	assert.Equal(t, big.NewInt(11), sentTx(cli, 1).PriorityFee)
+	for _, c := range cli.Calls {
+		if c.Method == "SendTransaction" {
+			assert.True(t, ethereum.ExplicitNonceFromContext(c.Arguments.Get(0).(context.Context)))
+		}
+	}
+}
+
+func TestManager_Send_NonceTooLow(t *testing.T) {
+	ctx := context.Background()
+	m, cli := newTestManager(t)
+
+	hash1 := ethereum.HexToHash("0x01")
+
+	cli.On("SuggestFees", ctx).Return(big.NewInt(10), big.NewInt(100), nil)
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(5), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash1, nil).Once()
+	cli.On("TransactionReceipt", ctx, hash1).Return((*ethereum.Receipt)(nil), nil)
+
+	_, err := m.Send(ctx, "AAABBB", &ethereum.Transaction{})
+	require.NoError(t, err)
+
+	// The replacement fails because the nonce was already used:
+	m.pending["AAABBB"].sentAt = time.Now().Add(-2 * time.Minute)
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return((*ethereum.Hash)(nil), errors.New("nonce too low")).Once()
+
+	_, err = m.Send(ctx, "AAABBB", &ethereum.Transaction{})
+	require.Error(t, err)
+	assert.NotContains(t, m.pending, "AAABBB")
+}
+
+func TestManager_Send_ReplacementUnderpriced(t *testing.T) {
+	ctx := context.Background()
+	m, cli := newTestManager(t)
+
+	hash1 := ethereum.HexToHash("0x01")
+	hash2 := ethereum.HexToHash("0x02")
+
+	cli.On("SuggestFees", ctx).Return(big.NewInt(10), big.NewInt(100), nil)
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(5), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash1, nil).Once()
+	cli.On("TransactionReceipt", ctx, mock.Anything).Return((*ethereum.Receipt)(nil), nil)
+
+	_, err := m.Send(ctx, "AAABBB", &ethereum.Transaction{})
+	require.NoError(t, err)
+
+	// The replacement is rejected, but the first transaction is still
+	// pending, so no transaction with a new nonce may be sent:
+	m.pending["AAABBB"].sentAt = time.Now().Add(-2 * time.Minute)
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return((*ethereum.Hash)(nil), errors.New("replacement transaction underpriced")).Once()
+
+	_, err = m.Send(ctx, "AAABBB", &ethereum.Transaction{})
+	assert.ErrorIs(t, err, ErrTransactionInFlight)
+	require.Contains(t, m.pending, "AAABBB")
+	_, err = m.Send(ctx, "AAABBB", &ethereum.Transaction{})
+	assert.ErrorIs(t, err, ErrTransactionInFlight)
+
+	// The next replacement uses the same nonce and fees bumped from
+	// the rejected ones:
+	m.pending["AAABBB"].sentAt = time.Now().Add(-2 * time.Minute)
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash2, nil).Once()
+
+	_, err = m.Send(ctx, "AAABBB", &ethereum.Transaction{})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), sentTx(cli, 2).Nonce)
+	assert.Equal(t, big.NewInt(12), sentTx(cli, 2).PriorityFee)
+	assert.Equal(t, big.NewInt(126), sentTx(cli, 2).MaxFee)
+	cli.AssertNumberOfCalls(t, "PendingNonce", 1)
+	cli.AssertNumberOfCalls(t, "SendTransaction", 3)
+}
+
+func TestManager_Client_GasStrategy(t *testing.T) {
+	ctx := context.Background()
+	m, cli := newTestManager(t)
+
+	hash1 := ethereum.HexToHash("0x01")
+
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(5), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash1, nil).Once()
+
+	gas := ethereum.NewFixedGasStrategy(big.NewInt(3), big.NewInt(30))
+	_, err := m.Client("AAABBB", gas).SendTransaction(ctx, &ethereum.Transaction{})
+	require.NoError(t, err)
+
+	assert.Equal(t, big.NewInt(3), sentTx(cli, 0).PriorityFee)
+	assert.Equal(t, big.NewInt(30), sentTx(cli, 0).MaxFee)
+	cli.AssertNotCalled(t, "SuggestFees", mock.Anything)
+}
+
+func TestManager_Client_FeeLimits(t *testing.T) {
+	ctx := context.Background()
+	m, cli := newTestManager(t)
+
+	hash1 := ethereum.HexToHash("0x01")
+	hash2 := ethereum.HexToHash("0x02")
+
+	cli.On("PendingNonce", ctx, testAddress).Return(uint64(5), nil).Once()
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash1, nil).Once()
+	cli.On("TransactionReceipt", ctx, mock.Anything).Return((*ethereum.Receipt)(nil), nil)
+
+	// Fees may be increased up to two times:
+	gas := ethereum.NewUrgencyGasStrategy(ethereum.NewFixedGasStrategy(big.NewInt(10), big.NewInt(100)), 2)
+	c := m.Client("AAABBB", gas)
+	_, err := c.SendTransaction(ctx, &ethereum.Transaction{})
+	require.NoError(t, err)
+
+	// Bump fees until the limit is reached:
+	cli.On("SendTransaction", mock.Anything, mock.Anything).Return(&hash2, nil)
+	for i := 0; i < 6; i++ {
+		m.pending["AAABBB"].sentAt = time.Now().Add(-2 * time.Minute)
+		_, err = c.SendTransaction(ctx, &ethereum.Transaction{})
+		require.NoError(t, err)
+	}
+	assert.Equal(t, big.NewInt(16), sentTx(cli, 6).PriorityFee)
+	assert.Equal(t, big.NewInt(199), sentTx(cli, 6).MaxFee)
+
+	// The next bump would exceed the limits:
+	m.pending["AAABBB"].sentAt = time.Now().Add(-2 * time.Minute)
+	_, err = c.SendTransaction(ctx, &ethereum.Transaction{})
+	assert.ErrorIs(t, err, ErrTransactionInFlight)
+	cli.AssertNumberOfCalls(t, "SendTransaction", 7)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
@@ -124,6 +125,7 @@ func (l *EventPublisher) listenerLoop() {
 
 func (l *EventPublisher) broadcast(evt *messages.Event) {
 	if !l.sign(evt) {
+		metrics.EventPublisherEvents.WithLabelValues(evt.Type, "unsigned").Inc()
 		return
 	}
 	l.log.
@@ -140,6 +142,7 @@ func (l *EventPublisher) broadcast(evt *messages.Event) {
 		Info("Event published")
 	err := l.transport.Broadcast(messages.EventV1MessageName, evt)
 	if err != nil {
+		metrics.EventPublisherEvents.WithLabelValues(evt.Type, metrics.ResultError).Inc()
 		l.log.
 			WithError(err).
 			WithFields(log.Fields{
@@ -148,7 +151,9 @@ func (l *EventPublisher) broadcast(evt *messages.Event) {
 				"from": l.transport.ID(),
 			}).
 			Error("Unable to publish the event")
+		return
 	}
+	metrics.EventPublisherEvents.WithLabelValues(evt.Type, metrics.ResultSuccess).Inc()
 }
 
 func (l *EventPublisher) sign(evt *messages.Event) bool {
@@ -22,6 +22,7 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
@@ -140,9 +141,14 @@ func (e *EventStore) eventCollectorRoutine() {
 					"new":         isNew,
 				}).
 				Info("Event received")
-			if err != nil {
+			switch {
+			case err != nil:
+				metrics.EventStoreEvents.WithLabelValues(evt.Type, metrics.ResultError).Inc()
 				e.log.WithError(err).Error("Unable to store the event")
-				continue
+			case isNew:
+				metrics.EventStoreEvents.WithLabelValues(evt.Type, metrics.ResultSuccess).Inc()
+			default:
+				metrics.EventStoreEvents.WithLabelValues(evt.Type, "duplicate").Inc()
 			}
 		}
 	}
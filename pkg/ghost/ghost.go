@@ -21,24 +21,33 @@ import (
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/marshal"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
+	"github.com/chronicleprotocol/oracle-suite/pkg/tracing"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
 
 const LoggerTag = "GHOST"
 
+var tracer = otel.Tracer("github.com/chronicleprotocol/oracle-suite/pkg/ghost")
+
 type Ghost struct {
 	ctx    context.Context
 	waitCh chan error
 
 	priceProvider provider.Provider
+	priceHook     provider.PriceHook
 	signer        ethereum.Signer
 	transport     transport.Transport
 	interval      time.Duration
@@ -52,6 +61,11 @@ type Config struct {
 	Pairs []string
 	// PriceProvider is an instance of the provider.Provider.
 	PriceProvider provider.Provider
+	// PriceHook is an optional hook used to verify prices before they are
+	// broadcast. Prices rejected by the hook are not sent to the network.
+	// If the hook implements provider.PriceRecorder, broadcast prices are
+	// passed to it.
+	PriceHook provider.PriceHook
 	// Signer is an instance of the ethereum.Signer which will be used to
 	// sign prices.
 	Signer ethereum.Signer
@@ -85,6 +99,7 @@ func New(cfg Config) (*Ghost, error) {
 	g := &Ghost{
 		waitCh:        make(chan error),
 		priceProvider: cfg.PriceProvider,
+		priceHook:     cfg.PriceHook,
 		signer:        cfg.Signer,
 		transport:     cfg.Transport,
 		interval:      cfg.Interval,
@@ -115,20 +130,40 @@ func (g *Ghost) Wait() chan error {
 
 // broadcast sends price for single pair to the network. This method uses
 // current price from the Provider, so it must be updated beforehand.
-func (g *Ghost) broadcast(pair provider.Pair) error {
-	var err error
-
+//
+// The broadcast is traced in a span linked to the spans in which origin
+// prices were fetched. The trace context of the span is sent along with
+// the price, so relays can continue the trace.
+func (g *Ghost) broadcast(ctx context.Context, pair provider.Pair) (err error) {
 	tick, err := g.priceProvider.Price(pair)
 	if err != nil {
 		return err
 	}
+
+	ctx, span := tracer.Start(ctx, "ghost.broadcast", trace.WithAttributes(
+		attribute.String("pair", pair.String()),
+	), trace.WithLinks(fetchLinks(tick)...))
+	defer func() {
+		tracing.SetStatus(span, err)
+		span.End()
+	}()
+
+	if g.priceHook != nil {
+		if err := g.priceHook.Check(map[provider.Pair]*provider.Price{pair: tick}); err != nil {
+			return err
+		}
+	}
 	if tick.Error != "" {
 		return errors.New(tick.Error)
 	}
 
 	// Create price:
 	price := &oracle.Price{Wat: pair.Base + pair.Quote, Age: tick.Time}
-	price.SetFloat64Price(tick.Price)
+	if tick.DecimalPrice.IsZero() {
+		price.SetFloat64Price(tick.Price)
+	} else {
+		price.SetDecimalPrice(tick.DecimalPrice)
+	}
 
 	// Sign price:
 	err = price.Sign(g.signer)
@@ -141,13 +176,18 @@ func (g *Ghost) broadcast(pair provider.Pair) error {
 	if err != nil {
 		return err
 	}
+	msg.TraceContext = tracing.Inject(ctx)
 	if err := g.transport.Broadcast(messages.PriceV0MessageName, msg.AsV0()); err != nil {
 		return err
 	}
 	if err := g.transport.Broadcast(messages.PriceV1MessageName, msg.AsV1()); err != nil {
 		return err
 	}
-	return err
+	if r, ok := g.priceHook.(provider.PriceRecorder); ok {
+		r.Record(map[provider.Pair]*provider.Price{pair: tick})
+	}
+	span.SetAttributes(attribute.String("price", price.Val.String()))
+	return nil
 }
 
 // broadcasterRoutine creates an asynchronous loop which fetches prices from exchanges and then
@@ -170,13 +210,15 @@ func (g *Ghost) broadcasterRoutine() {
 			wg.Add(1)
 			go func() {
 				for _, pair := range g.pairs {
-					err := g.broadcast(pair)
+					err := g.broadcast(g.ctx, pair)
 					if err != nil {
+						metrics.GhostBroadcasts.WithLabelValues(pair.String(), metrics.ResultError).Inc()
 						g.log.
 							WithFields(log.Fields{"assetPair": pair}).
 							WithError(err).
 							Warn("Unable to broadcast price")
 					} else {
+						metrics.GhostBroadcasts.WithLabelValues(pair.String(), metrics.ResultSuccess).Inc()
 						g.log.
 							WithFields(log.Fields{"assetPair": pair}).
 							Info("Price broadcast")
@@ -195,6 +237,26 @@ func (g *Ghost) contextCancelHandler() {
 	<-g.ctx.Done()
 }
 
+// fetchLinks returns links to the spans in which origin prices used to
+// calculate the given price were fetched. Prices fetched in the same span
+// share a single link.
+func fetchLinks(p *provider.Price) []trace.Link {
+	var links []trace.Link
+	var walk func(p *provider.Price)
+	seen := map[trace.SpanID]bool{}
+	walk = func(p *provider.Price) {
+		if l, ok := tracing.Link(p.TraceContext); ok && !seen[l.SpanContext.SpanID()] {
+			seen[l.SpanContext.SpanID()] = true
+			links = append(links, l)
+		}
+		for _, c := range p.Prices {
+			walk(c)
+		}
+	}
+	walk(p)
+	return links
+}
+
 func createPriceMessage(op *oracle.Price, gp *provider.Price) (*messages.Price, error) {
 	trace, err := marshal.Marshall(marshal.JSON, gp)
 	if err != nil {
@@ -305,3 +305,89 @@ func assertPrice(t *testing.T, expected *provider.Price, actual *messages.Price)
 	assert.Equal(t, actual.Price.R, [32]byte(common.HexToHash("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")))
 	assert.Equal(t, actual.Price.S, [32]byte(common.HexToHash("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")))
 }
+
+type priceHookFunc func(map[provider.Pair]*provider.Price) error
+
+func (f priceHookFunc) Check(prices map[provider.Pair]*provider.Price) error {
+	return f(prices)
+}
+
+func TestGhost_BroadcastPriceHook(t *testing.T) {
+	pair := provider.Pair{Base: "AAA", Quote: "BBB"}
+	price := *PriceAAABBB
+
+	pro := &priceMocks.Provider{}
+	pro.On("Price", pair).Return(&price, nil)
+
+	gho, err := New(Config{
+		Pairs:         []string{"AAA/BBB"},
+		PriceProvider: pro,
+		PriceHook: priceHookFunc(func(prices map[provider.Pair]*provider.Price) error {
+			require.Contains(t, prices, pair)
+			prices[pair].Error = "rejected"
+			return nil
+		}),
+		Signer:    &ethereumMocks.Signer{},
+		Transport: local.New([]byte("test"), 0, nil),
+	})
+	require.NoError(t, err)
+
+	// The price rejected by the hook must not be signed nor broadcast.
+	err = gho.broadcast(context.Background(), pair)
+	require.Error(t, err)
+	assert.Equal(t, "rejected", err.Error())
+}
+
+type priceRecorder struct {
+	priceHookFunc
+	recorded []*provider.Price
+}
+
+func (r *priceRecorder) Record(prices map[provider.Pair]*provider.Price) {
+	for _, p := range prices {
+		r.recorded = append(r.recorded, p)
+	}
+}
+
+func TestGhost_BroadcastPriceRecorder(t *testing.T) {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer ctxCancel()
+
+	pair := provider.Pair{Base: "AAA", Quote: "BBB"}
+	price := *PriceAAABBB
+
+	pro := &priceMocks.Provider{}
+	pro.On("Price", pair).Return(&price, nil)
+	sig := &ethereumMocks.Signer{}
+	tra := local.New([]byte("test"), 1, map[string]transport.Message{
+		messages.PriceV0MessageName: (*messages.Price)(nil),
+		messages.PriceV1MessageName: (*messages.Price)(nil),
+	})
+	require.NoError(t, tra.Start(ctx))
+	defer func() {
+		ctxCancel()
+		<-tra.Wait()
+	}()
+	rec := &priceRecorder{priceHookFunc: func(map[provider.Pair]*provider.Price) error { return nil }}
+
+	gho, err := New(Config{
+		Pairs:         []string{"AAA/BBB"},
+		PriceProvider: pro,
+		PriceHook:     rec,
+		Signer:        sig,
+		Transport:     tra,
+	})
+	require.NoError(t, err)
+
+	// Prices that were not broadcast must not be recorded.
+	sig.On("Signature", PriceAAABBBHash).Return(ethereum.Signature{}, errors.New("err")).Once()
+	require.Error(t, gho.broadcast(ctx, pair))
+	assert.Empty(t, rec.recorded)
+
+	sig.On("Signature", PriceAAABBBHash).Return(ethereum.SignatureFromBytes(bytes.Repeat([]byte{0xAA}, 65)), nil).Once()
+	require.NoError(t, gho.broadcast(ctx, pair))
+	<-tra.Messages(messages.PriceV0MessageName)
+	<-tra.Messages(messages.PriceV1MessageName)
+	require.Len(t, rec.recorded, 1)
+	assert.Equal(t, price.Price, rec.recorded[0].Price)
+}
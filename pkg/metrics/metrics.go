@@ -0,0 +1,154 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package metrics provides Prometheus metrics for oracle-suite services.
+//
+// All metrics are registered in the Registry which is exposed by the Server
+// on the /metrics endpoint. Metrics that are not used by a given service
+// are still exposed, but without any samples.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "oracle_suite"
+
+// Registry contains all metrics defined in this package, as well as the Go
+// runtime and process metrics.
+var Registry = prometheus.NewRegistry()
+
+var (
+	// OriginFetchDuration is the duration of price requests sent to origins.
+	OriginFetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "gofer",
+		Name:      "origin_fetch_duration_seconds",
+		Help:      "Duration of price requests sent to origins.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"origin"})
+
+	// OriginFetchErrors is the number of prices that could not be fetched
+	// from origins, including prices of disabled origins.
+	OriginFetchErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "gofer",
+		Name:      "origin_fetch_errors_total",
+		Help:      "Number of prices that could not be fetched from origins.",
+	}, []string{"origin"})
+
+	// GhostBroadcasts is the number of price broadcast attempts.
+	GhostBroadcasts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "ghost",
+		Name:      "broadcasts_total",
+		Help:      "Number of price broadcast attempts.",
+	}, []string{"pair", "result"})
+
+	// PriceStoreMessages is the number of price messages received from the
+	// transport.
+	PriceStoreMessages = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "price_store",
+		Name:      "messages_total",
+		Help:      "Number of price messages received from the transport.",
+	}, []string{"pair", "result"})
+
+	// PriceStoreSize is the number of prices stored for an asset pair.
+	PriceStoreSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "price_store",
+		Name:      "prices",
+		Help:      "Number of prices stored for an asset pair.",
+	}, []string{"pair"})
+
+	// EventStoreEvents is the number of events received from the
+	// transport.
+	EventStoreEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "event_store",
+		Name:      "events_total",
+		Help:      "Number of events received from the transport.",
+	}, []string{"type", "result"})
+
+	// EventPublisherEvents is the number of events published to the
+	// transport.
+	EventPublisherEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "event_publisher",
+		Name:      "events_total",
+		Help:      "Number of events published to the transport.",
+	}, []string{"type", "result"})
+
+	// SpectrePokes is the number of Oracle update attempts.
+	SpectrePokes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "spectre",
+		Name:      "pokes_total",
+		Help:      "Number of Oracle update attempts.",
+	}, []string{"pair", "result"})
+
+	// TransportPeers is the number of peers connected to the libp2p node.
+	TransportPeers = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "transport",
+		Name:      "peers",
+		Help:      "Number of peers connected to the libp2p node.",
+	})
+
+	// RPCSplitterCallDuration is the duration of calls sent to RPC
+	// endpoints.
+	RPCSplitterCallDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_splitter",
+		Name:      "call_duration_seconds",
+		Help:      "Duration of calls sent to RPC endpoints.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint", "method"})
+
+	// RPCSplitterCallErrors is the number of failed calls sent to RPC
+	// endpoints.
+	RPCSplitterCallErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rpc_splitter",
+		Name:      "call_errors_total",
+		Help:      "Number of failed calls sent to RPC endpoints.",
+	}, []string{"endpoint", "method"})
+)
+
+// Results used as the "result" label value.
+const (
+	ResultSuccess = "success"
+	ResultError   = "error"
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		OriginFetchDuration,
+		OriginFetchErrors,
+		GhostBroadcasts,
+		PriceStoreMessages,
+		PriceStoreSize,
+		EventStoreEvents,
+		EventPublisherEvents,
+		SpectrePokes,
+		TransportPeers,
+		RPCSplitterCallDuration,
+		RPCSplitterCallErrors,
+	)
+}
@@ -0,0 +1,110 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver/middleware"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+const LoggerTag = "METRICS"
+
+// defaultTimeout is the default timeout for the HTTP server.
+const defaultTimeout = 10 * time.Second
+
+// Server exposes metrics from the Registry on the /metrics endpoint in the
+// Prometheus text format.
+type Server struct {
+	ctx context.Context
+
+	srv *httpserver.HTTPServer
+	log log.Logger
+}
+
+// Config is the configuration for the Server.
+type Config struct {
+	// Address specifies the TCP address for the server to listen on in the
+	// form "host:port".
+	Address string
+	// Logger is a current logger used by the Server.
+	Logger log.Logger
+}
+
+// New returns a new instance of the Server struct.
+func New(cfg Config) (*Server, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("address must not be empty")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	s := &Server{
+		log: cfg.Logger.WithField("tag", LoggerTag),
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(Registry, promhttp.HandlerOpts{}))
+	s.srv = httpserver.New(&http.Server{
+		Addr:              cfg.Address,
+		Handler:           mux,
+		IdleTimeout:       defaultTimeout,
+		ReadTimeout:       defaultTimeout,
+		WriteTimeout:      defaultTimeout,
+		ReadHeaderTimeout: defaultTimeout,
+	})
+	s.srv.Use(&middleware.HealthCheck{
+		Path:  "/health",
+		Check: func(r *http.Request) bool { return true },
+	})
+	return s, nil
+}
+
+// Start implements the supervisor.Service interface. It starts HTTP server.
+func (s *Server) Start(ctx context.Context) error {
+	if s.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	s.log.Infof("Starting")
+	s.ctx = ctx
+	err := s.srv.Start(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to start the HTTP server: %w", err)
+	}
+	go s.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (s *Server) Wait() chan error {
+	return s.srv.Wait()
+}
+
+func (s *Server) contextCancelHandler() {
+	defer s.log.Info("Stopped")
+	<-s.ctx.Done()
+}
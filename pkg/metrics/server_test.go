@@ -0,0 +1,60 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+func TestServer(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	srv, err := New(Config{
+		Address: "127.0.0.1:0",
+		Logger:  null.New(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, srv.Start(ctx))
+	defer func() {
+		cancelFunc()
+		require.NoError(t, <-srv.Wait())
+	}()
+
+	GhostBroadcasts.WithLabelValues("AAABBB", ResultSuccess).Inc()
+
+	res, err := http.Get(fmt.Sprintf("http://%s/metrics", srv.srv.Addr().String()))
+	require.NoError(t, err)
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, string(body), `oracle_suite_ghost_broadcasts_total{pair="AAABBB",result="success"} 1`)
+	assert.Contains(t, string(body), "go_goroutines")
+}
+
+func TestNew_EmptyAddress(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+}
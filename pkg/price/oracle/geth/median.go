@@ -22,6 +22,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
@@ -147,26 +148,7 @@ func (m *Median) Feeds(ctx context.Context) ([]ethereum.Address, error) {
 
 // Poke implements the oracle.Median interface.
 func (m *Median) Poke(ctx context.Context, prices []*oracle.Price, simulateBeforeRun bool) (*ethereum.Hash, error) {
-	// It's important to send prices in correct order, otherwise contract will fail:
-	sort.Slice(prices, func(i, j int) bool {
-		return prices[i].Val.Cmp(prices[j].Val) < 0
-	})
-
-	var (
-		val []*big.Int
-		age []*big.Int
-		v   []uint8
-		r   [][32]byte
-		s   [][32]byte
-	)
-
-	for _, arg := range prices {
-		val = append(val, arg.Val)
-		age = append(age, big.NewInt(arg.Age.Unix()))
-		v = append(v, arg.V)
-		r = append(r, arg.R)
-		s = append(s, arg.S)
-	}
+	val, age, v, r, s := pokeArgs(prices)
 
 	if simulateBeforeRun {
 		if _, err := m.read(ctx, "poke", val, age, v, r, s); err != nil {
@@ -177,6 +159,13 @@ func (m *Median) Poke(ctx context.Context, prices []*oracle.Price, simulateBefor
 	return m.write(ctx, "poke", val, age, v, r, s)
 }
 
+// PokeCalldata implements the oracle.Median interface.
+func (m *Median) PokeCalldata(_ context.Context, prices []*oracle.Price) ([]byte, error) {
+	val, age, v, r, s := pokeArgs(prices)
+
+	return medianABI.Pack("poke", val, age, v, r, s)
+}
+
 // Lift implements the oracle.Median interface.
 func (m *Median) Lift(ctx context.Context, addresses []common.Address, simulateBeforeRun bool) (*ethereum.Hash, error) {
 	if simulateBeforeRun {
@@ -211,36 +200,80 @@ func (m *Median) SetBar(ctx context.Context, bar *big.Int, simulateBeforeRun boo
 }
 
 func (m *Median) read(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
-	cd, err := medianABI.Pack(method, args...)
+	return readContract(ctx, m.ethereum, medianABI, m.address, method, args...)
+}
+
+func (m *Median) write(ctx context.Context, method string, args ...interface{}) (*ethereum.Hash, error) {
+	return writeContract(ctx, m.ethereum, medianABI, m.address, method, args...)
+}
+
+// readContract calls the contract method and returns the unpacked result.
+func readContract(
+	ctx context.Context,
+	client ethereum.Client,
+	contractABI abi.ABI,
+	address ethereum.Address,
+	method string,
+	args ...interface{},
+) ([]interface{}, error) {
+
+	cd, err := contractABI.Pack(method, args...)
 	if err != nil {
 		return nil, err
 	}
 
 	var data []byte
 	err = retry(maxReadRetries, delayBetweenReadRetries, func() error {
-		data, err = m.ethereum.Call(ctx, ethereum.Call{Address: m.address, Data: cd})
+		data, err = client.Call(ctx, ethereum.Call{Address: address, Data: cd})
 		return err
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	return medianABI.Unpack(method, data)
+	return contractABI.Unpack(method, data)
 }
 
-func (m *Median) write(ctx context.Context, method string, args ...interface{}) (*ethereum.Hash, error) {
-	cd, err := medianABI.Pack(method, args...)
+// writeContract sends a transaction which invokes the contract method.
+func writeContract(
+	ctx context.Context,
+	client ethereum.Client,
+	contractABI abi.ABI,
+	address ethereum.Address,
+	method string,
+	args ...interface{},
+) (*ethereum.Hash, error) {
+
+	cd, err := contractABI.Pack(method, args...)
 	if err != nil {
 		return nil, err
 	}
 
-	return m.ethereum.SendTransaction(ctx, &ethereum.Transaction{
-		Address:  m.address,
+	return client.SendTransaction(ctx, &ethereum.Transaction{
+		Address:  address,
 		GasLimit: new(big.Int).SetUint64(gasLimit),
 		Data:     cd,
 	})
 }
 
+// pokeArgs converts prices to the arguments of the poke method.
+func pokeArgs(prices []*oracle.Price) (val, age []*big.Int, v []uint8, r, s [][32]byte) {
+	// It's important to send prices in correct order, otherwise contract will fail:
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Val.Cmp(prices[j].Val) < 0
+	})
+
+	for _, arg := range prices {
+		val = append(val, arg.Val)
+		age = append(age, big.NewInt(arg.Age.Unix()))
+		v = append(v, arg.V)
+		r = append(r, arg.R)
+		s = append(s, arg.S)
+	}
+
+	return val, age, v, r, s
+}
+
 func retry(maxRetries int, delay time.Duration, f func() error) error {
 	for i := 0; ; i++ {
 		err := f()
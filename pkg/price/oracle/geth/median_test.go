@@ -165,4 +165,9 @@ func TestMedian_Poke(t *testing.T) {
 	assert.Equal(t, big.NewInt(gasLimit), tx.GasLimit)
 	assert.Equal(t, uint64(0), tx.Nonce)
 	assert.Equal(t, cd, hex.EncodeToString(tx.Data))
+
+	// PokeCalldata must return the same data:
+	data, err := m.PokeCalldata(context.Background(), []*oracle.Price{p1, p2, p3})
+	assert.NoError(t, err)
+	assert.Equal(t, cd, hex.EncodeToString(data))
 }
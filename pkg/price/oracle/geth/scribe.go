@@ -0,0 +1,278 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
+)
+
+var ErrNoPrices = errors.New("unable to aggregate prices, the price list is empty")
+
+var scribeMessageArgs = abi.Arguments{
+	{Type: mustNewType("uint256")}, // val
+	{Type: mustNewType("uint256")}, // age
+	{Type: mustNewType("bytes32")}, // wat
+	{Type: mustNewType("uint256")}, // feeders
+}
+
+// Scribe implements the oracle.Scribe interface using go-ethereum packages.
+type Scribe struct {
+	ethereum ethereum.Client
+	signer   ethereum.Signer
+	address  ethereum.Address
+}
+
+// NewScribe creates the new Scribe instance. The signer is used to recover
+// feeders' addresses and to sign aggregated prices.
+func NewScribe(ethereum ethereum.Client, signer ethereum.Signer, address ethereum.Address) *Scribe {
+	return &Scribe{
+		ethereum: ethereum,
+		signer:   signer,
+		address:  address,
+	}
+}
+
+// Address implements the oracle.Scribe interface.
+func (s *Scribe) Address() common.Address {
+	return s.address
+}
+
+// Age implements the oracle.Scribe interface.
+func (s *Scribe) Age(ctx context.Context) (time.Time, error) {
+	r, err := s.read(ctx, "age")
+	if err != nil {
+		return time.Unix(0, 0), err
+	}
+
+	return time.Unix(int64(r[0].(uint32)), 0), nil
+}
+
+// Bar implements the oracle.Scribe interface.
+func (s *Scribe) Bar(ctx context.Context) (int64, error) {
+	r, err := s.read(ctx, "bar")
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(r[0].(uint8)), nil
+}
+
+// Val implements the oracle.Scribe interface.
+func (s *Scribe) Val(ctx context.Context) (*big.Int, error) {
+	r, err := s.read(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	return r[0].(*big.Int), nil
+}
+
+// Wat implements the oracle.Scribe interface.
+func (s *Scribe) Wat(ctx context.Context) (string, error) {
+	r, err := s.read(ctx, "wat")
+	if err != nil {
+		return "", err
+	}
+
+	b := r[0].([32]byte)
+	return string(b[:]), nil
+}
+
+// Feeds implements the oracle.Scribe interface.
+func (s *Scribe) Feeds(ctx context.Context) ([]ethereum.Address, error) {
+	r, err := s.read(ctx, "feeds")
+	if err != nil {
+		return nil, err
+	}
+
+	return r[0].([]common.Address), nil
+}
+
+// Poke implements the oracle.Scribe interface.
+func (s *Scribe) Poke(ctx context.Context, prices []*oracle.Price, simulateBeforeRun bool) (*ethereum.Hash, error) {
+	price, err := s.aggregate(ctx, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.PokeAggregated(ctx, price, simulateBeforeRun)
+}
+
+// PokeCalldata implements the oracle.Scribe interface.
+func (s *Scribe) PokeCalldata(ctx context.Context, prices []*oracle.Price) ([]byte, error) {
+	price, err := s.aggregate(ctx, prices)
+	if err != nil {
+		return nil, err
+	}
+
+	return scribeABI.Pack("poke", scribePokeArgs(price)...)
+}
+
+// PokeAggregated implements the oracle.Scribe interface.
+func (s *Scribe) PokeAggregated(
+	ctx context.Context,
+	price *oracle.AggregatedPrice,
+	simulateBeforeRun bool,
+) (*ethereum.Hash, error) {
+
+	args := scribePokeArgs(price)
+	if simulateBeforeRun {
+		if _, err := s.read(ctx, "poke", args...); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.write(ctx, "poke", args...)
+}
+
+// Lift implements the oracle.Scribe interface.
+func (s *Scribe) Lift(ctx context.Context, addresses []common.Address, simulateBeforeRun bool) (*ethereum.Hash, error) {
+	if simulateBeforeRun {
+		if _, err := s.read(ctx, "lift", addresses); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.write(ctx, "lift", addresses)
+}
+
+// Drop implements the oracle.Scribe interface.
+func (s *Scribe) Drop(ctx context.Context, addresses []common.Address, simulateBeforeRun bool) (*ethereum.Hash, error) {
+	if simulateBeforeRun {
+		if _, err := s.read(ctx, "drop", addresses); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.write(ctx, "drop", addresses)
+}
+
+// SetBar implements the oracle.Scribe interface.
+func (s *Scribe) SetBar(ctx context.Context, bar *big.Int, simulateBeforeRun bool) (*ethereum.Hash, error) {
+	if simulateBeforeRun {
+		if _, err := s.read(ctx, "setBar", uint8(bar.Uint64())); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.write(ctx, "setBar", uint8(bar.Uint64()))
+}
+
+// aggregate creates an optimistic AggregatedPrice from the feeders' prices.
+// The price value is the median of the prices, and the age is the age of
+// the oldest price.
+func (s *Scribe) aggregate(ctx context.Context, prices []*oracle.Price) (*oracle.AggregatedPrice, error) {
+	if len(prices) == 0 {
+		return nil, ErrNoPrices
+	}
+
+	feeds, err := s.Feeds(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := make(map[ethereum.Address]int, len(feeds))
+	for i, f := range feeds {
+		index[f] = i
+	}
+
+	agg := &oracle.AggregatedPrice{
+		Wat:     prices[0].Wat,
+		Age:     prices[0].Age,
+		Feeders: new(big.Int),
+	}
+	var vals []*big.Int
+	for _, p := range prices {
+		from, err := p.From(s.signer)
+		if err != nil {
+			return nil, err
+		}
+		i, ok := index[*from]
+		if !ok {
+			return nil, fmt.Errorf("price signed by %s who is not a feeder", from.String())
+		}
+		agg.Feeders.SetBit(agg.Feeders, i, 1)
+		if p.Age.Before(agg.Age) {
+			agg.Age = p.Age
+		}
+		vals = append(vals, p.Val)
+	}
+	agg.Val = median(vals)
+
+	msg, err := scribeMessage(agg)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := s.signer.Signature(msg)
+	if err != nil {
+		return nil, err
+	}
+	agg.Signature = sig.Bytes()
+
+	return agg, nil
+}
+
+func (s *Scribe) read(ctx context.Context, method string, args ...interface{}) ([]interface{}, error) {
+	return readContract(ctx, s.ethereum, scribeABI, s.address, method, args...)
+}
+
+func (s *Scribe) write(ctx context.Context, method string, args ...interface{}) (*ethereum.Hash, error) {
+	return writeContract(ctx, s.ethereum, scribeABI, s.address, method, args...)
+}
+
+// scribeMessage returns the message signed by the relayer for the given
+// aggregated price.
+func scribeMessage(price *oracle.AggregatedPrice) ([]byte, error) {
+	var wat [32]byte
+	copy(wat[:], price.Wat)
+	return scribeMessageArgs.Pack(price.Val, big.NewInt(price.Age.Unix()), wat, price.Feeders)
+}
+
+// scribePokeArgs converts aggregated price to the arguments of the poke
+// method.
+func scribePokeArgs(price *oracle.AggregatedPrice) []interface{} {
+	return []interface{}{price.Val, uint32(price.Age.Unix()), price.Signature, price.Feeders}
+}
+
+func median(vals []*big.Int) *big.Int {
+	sort.Slice(vals, func(i, j int) bool {
+		return vals[i].Cmp(vals[j]) < 0
+	})
+	n := len(vals)
+	if n%2 == 1 {
+		return new(big.Int).Set(vals[n/2])
+	}
+	m := new(big.Int).Add(vals[n/2-1], vals[n/2])
+	return m.Div(m, big.NewInt(2))
+}
+
+func mustNewType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
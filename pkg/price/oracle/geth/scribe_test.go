@@ -0,0 +1,101 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package geth
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
+)
+
+func TestScribe_PokeCalldata(t *testing.T) {
+	// Prepare test data:
+	c := &mocks.Client{}
+	s := &mocks.Signer{}
+	a := ethereum.Address{}
+	m := NewScribe(c, s, a)
+
+	feeds := []common.Address{
+		common.HexToAddress("0x01"),
+		common.HexToAddress("0x02"),
+		common.HexToAddress("0x03"),
+	}
+	feedsResp, err := scribeABI.Methods["feeds"].Outputs.Pack(feeds)
+	require.NoError(t, err)
+	c.On("Call", mock.Anything, mock.Anything).Return(feedsResp, nil)
+
+	p1 := &oracle.Price{Wat: "AAABBB", Val: big.NewInt(30), Age: time.Unix(200, 0), V: 1}
+	p2 := &oracle.Price{Wat: "AAABBB", Val: big.NewInt(10), Age: time.Unix(100, 0), V: 2}
+	p3 := &oracle.Price{Wat: "AAABBB", Val: big.NewInt(20), Age: time.Unix(300, 0), V: 3}
+	s.On("Recover", p1.Signature(), mock.Anything).Return(&feeds[0], nil)
+	s.On("Recover", p2.Signature(), mock.Anything).Return(&feeds[2], nil)
+	s.On("Recover", p3.Signature(), mock.Anything).Return(&feeds[1], nil)
+	s.On("Signature", mock.Anything).Return(ethereum.SignatureFromBytes([]byte{0xAA}), nil)
+
+	// Call PokeCalldata function:
+	cd, err := m.PokeCalldata(context.Background(), []*oracle.Price{p1, p2, p3})
+	require.NoError(t, err)
+
+	// Verify:
+	args, err := scribeABI.Methods["poke"].Inputs.Unpack(cd[4:])
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(20), args[0])
+	assert.Equal(t, uint32(100), args[1])
+	assert.Equal(t, ethereum.SignatureFromBytes([]byte{0xAA}).Bytes(), args[2])
+	assert.Equal(t, big.NewInt(0b111), args[3])
+
+	// Verify signed message:
+	var wat [32]byte
+	copy(wat[:], "AAABBB")
+	msg, err := scribeMessageArgs.Pack(big.NewInt(20), big.NewInt(100), wat, big.NewInt(0b111))
+	require.NoError(t, err)
+	s.AssertCalled(t, "Signature", msg)
+}
+
+func TestScribe_PokeCalldata_UnknownFeeder(t *testing.T) {
+	// Prepare test data:
+	c := &mocks.Client{}
+	s := &mocks.Signer{}
+	m := NewScribe(c, s, ethereum.Address{})
+
+	feedsResp, err := scribeABI.Methods["feeds"].Outputs.Pack([]common.Address{common.HexToAddress("0x01")})
+	require.NoError(t, err)
+	c.On("Call", mock.Anything, mock.Anything).Return(feedsResp, nil)
+
+	p := &oracle.Price{Wat: "AAABBB", Val: big.NewInt(30), Age: time.Unix(200, 0)}
+	s.On("Recover", p.Signature(), mock.Anything).Return(&common.Address{0x02}, nil)
+
+	// Call PokeCalldata function:
+	_, err = m.PokeCalldata(context.Background(), []*oracle.Price{p})
+
+	// Verify:
+	assert.Error(t, err)
+}
+
+func TestMedian(t *testing.T) {
+	assert.Equal(t, big.NewInt(2), median([]*big.Int{big.NewInt(3), big.NewInt(1), big.NewInt(2)}))
+	assert.Equal(t, big.NewInt(15), median([]*big.Int{big.NewInt(20), big.NewInt(10)}))
+}
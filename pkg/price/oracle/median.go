@@ -25,13 +25,11 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 )
 
-// Median is an interface for the median oracle contract:
-// https://github.com/makerdao/median/
-//
-// Contract documentation:
-// https://docs.makerdao.com/smart-contract-modules/oracle-module/median-detailed-documentation
-type Median interface {
-	// Address returns medianizer contract address.
+// Oracle is an interface for oracle contracts that can be updated using
+// prices signed by feeders. It is implemented by both the Median and
+// the Scribe contracts, so relayers can update them in the same way.
+type Oracle interface {
+	// Address returns oracle contract address.
 	Address() common.Address
 	// Age returns the value from contract's age method. The age is the block
 	// timestamp of last price val update.
@@ -51,6 +49,35 @@ type Median interface {
 	// set to true, then transaction will be simulated on the EVM before actual
 	// transaction will be send.
 	Poke(ctx context.Context, prices []*Price, simulateBeforeRun bool) (*ethereum.Hash, error)
+	// PokeCalldata returns the raw transaction data that would be sent by the
+	// Poke method for the given prices, without sending the transaction.
+	PokeCalldata(ctx context.Context, prices []*Price) ([]byte, error)
+}
+
+// AddressStringer is implemented by Oracles deployed outside Ethereum, like
+// Starknet, whose addresses cannot be represented as Ethereum addresses.
+type AddressStringer interface {
+	// AddressString returns the contract address as a hex string.
+	AddressString() string
+}
+
+// AddressString returns the contract address of the Oracle as a hex string.
+// Unlike the Address method, it returns the actual address also for Oracles
+// that implement the AddressStringer interface.
+func AddressString(o Oracle) string {
+	if a, ok := o.(AddressStringer); ok {
+		return a.AddressString()
+	}
+	return o.Address().String()
+}
+
+// Median is an interface for the median oracle contract:
+// https://github.com/makerdao/median/
+//
+// Contract documentation:
+// https://docs.makerdao.com/smart-contract-modules/oracle-module/median-detailed-documentation
+type Median interface {
+	Oracle
 	// Lift sends transaction to the smart contract which invokes contract's
 	// lift method, which sends  adds given addresses to the feeders list (orcls).
 	// If simulateBeforeRun is set to true, then transaction will be simulated
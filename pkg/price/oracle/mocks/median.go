@@ -0,0 +1,88 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mocks
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
+)
+
+type Median struct {
+	mock.Mock
+}
+
+func (m *Median) Address() ethereum.Address {
+	args := m.Called()
+	return args.Get(0).(ethereum.Address)
+}
+
+func (m *Median) Age(ctx context.Context) (time.Time, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(time.Time), args.Error(1)
+}
+
+func (m *Median) Bar(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *Median) Val(ctx context.Context) (*big.Int, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(*big.Int), args.Error(1)
+}
+
+func (m *Median) Wat(ctx context.Context) (string, error) {
+	args := m.Called(ctx)
+	return args.String(0), args.Error(1)
+}
+
+func (m *Median) Feeds(ctx context.Context) ([]ethereum.Address, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]ethereum.Address), args.Error(1)
+}
+
+func (m *Median) Poke(ctx context.Context, prices []*oracle.Price, simulateBeforeRun bool) (*ethereum.Hash, error) {
+	args := m.Called(ctx, prices, simulateBeforeRun)
+	return args.Get(0).(*ethereum.Hash), args.Error(1)
+}
+
+func (m *Median) PokeCalldata(ctx context.Context, prices []*oracle.Price) ([]byte, error) {
+	args := m.Called(ctx, prices)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *Median) Lift(ctx context.Context, addresses []ethereum.Address, simulateBeforeRun bool) (*ethereum.Hash, error) {
+	args := m.Called(ctx, addresses, simulateBeforeRun)
+	return args.Get(0).(*ethereum.Hash), args.Error(1)
+}
+
+func (m *Median) Drop(ctx context.Context, addresses []ethereum.Address, simulateBeforeRun bool) (*ethereum.Hash, error) {
+	args := m.Called(ctx, addresses, simulateBeforeRun)
+	return args.Get(0).(*ethereum.Hash), args.Error(1)
+}
+
+func (m *Median) SetBar(ctx context.Context, bar *big.Int, simulateBeforeRun bool) (*ethereum.Hash, error) {
+	args := m.Called(ctx, bar, simulateBeforeRun)
+	return args.Get(0).(*ethereum.Hash), args.Error(1)
+}
+
+var _ oracle.Median = (*Median)(nil)
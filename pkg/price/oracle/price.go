@@ -27,10 +27,14 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 const PriceMultiplier = 1e18
 
+// priceDecimals is the number of decimals of the Val field.
+const priceDecimals = 18
+
 var ErrPriceNotSet = errors.New("unable to sign a price because the price is not set")
 var ErrUnmarshallingFailure = errors.New("unable to unmarshal given JSON")
 
@@ -75,6 +79,12 @@ func (p *Price) SetFloat64Price(price float64) {
 	p.Val = pi
 }
 
+// SetDecimalPrice sets the price without losing precision. Digits beyond
+// the precision of the Val field are truncated.
+func (p *Price) SetDecimalPrice(price decimal.Decimal) {
+	p.Val = price.BigInt(priceDecimals)
+}
+
 func (p *Price) Float64Price() float64 {
 	x := new(big.Float).SetInt(p.Val)
 	x = new(big.Float).Quo(x, new(big.Float).SetFloat64(PriceMultiplier))
@@ -23,9 +23,11 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 // Hash for the AAABBB asset pair, with the price set to 42 and the age to 1605371361:
@@ -63,6 +65,21 @@ func TestPrice_SetFloat64Price(t *testing.T) {
 	}
 }
 
+func TestPrice_SetDecimalPrice(t *testing.T) {
+	p := &Price{Wat: "AAABBB"}
+
+	// Large price with more significant digits than float64 can hold:
+	d, err := decimal.FromString("123456789.123456789123456789")
+	require.NoError(t, err)
+	p.SetDecimalPrice(d)
+	assert.Equal(t, "123456789123456789123456789", p.Val.String())
+
+	// Digits beyond 18 decimal places are truncated:
+	d = decimal.FromInt64(10).Quo(decimal.FromInt64(3))
+	p.SetDecimalPrice(d)
+	assert.Equal(t, "3333333333333333333", p.Val.String())
+}
+
 func TestPrice_Sign(t *testing.T) {
 	s := &mocks.Signer{}
 	p := &Price{Wat: "AAABBB"}
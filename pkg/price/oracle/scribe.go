@@ -0,0 +1,67 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package oracle
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+)
+
+// AggregatedPrice is a price attested by multiple feeders with a single
+// signature.
+type AggregatedPrice struct {
+	Wat string    // Wat is the asset name.
+	Val *big.Int  // Val is the asset price multiplied by PriceMultiplier.
+	Age time.Time // Age is the time when the price was obtained.
+
+	// Signature is the aggregated signature of all feeders or, for
+	// optimistic updates, the signature of the relayer that vouches for
+	// the price.
+	Signature []byte
+	// Feeders is the bitmap of feeders that attested the price. The n-th bit
+	// is set if the n-th feeder from the Feeds list attested the price.
+	Feeders *big.Int
+}
+
+// Scribe is an interface for the oracle contract that is updated with
+// a single aggregated signature and a bitmap of feeders, instead of separate
+// signatures of every feeder.
+//
+// The Poke method inherited from the Oracle interface aggregates prices
+// optimistically: the price is signed by the relayer and it is up to
+// the contract to allow challenging it using the feeders' signatures.
+type Scribe interface {
+	Oracle
+	// PokeAggregated sends transaction to the smart contract which invokes
+	// contract's poke method with the already aggregated price. If
+	// simulateBeforeRun is set to true, then transaction will be simulated
+	// on the EVM before actual transaction will be send.
+	PokeAggregated(ctx context.Context, price *AggregatedPrice, simulateBeforeRun bool) (*ethereum.Hash, error)
+	// Lift sends transaction to the smart contract which invokes contract's
+	// lift method, which adds given addresses to the feeders list.
+	Lift(ctx context.Context, addresses []common.Address, simulateBeforeRun bool) (*ethereum.Hash, error)
+	// Drop sends transaction to the smart contract which invokes contract's
+	// drop method, which removes given addresses from the feeders list.
+	Drop(ctx context.Context, addresses []common.Address, simulateBeforeRun bool) (*ethereum.Hash, error)
+	// SetBar sends transaction to the smart contract which invokes contract's
+	// setBar method, which sets bar variable (quorum).
+	SetBar(ctx context.Context, bar *big.Int, simulateBeforeRun bool) (*ethereum.Hash, error)
+}
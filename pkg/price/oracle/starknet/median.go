@@ -0,0 +1,240 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package starknet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
+	"github.com/chronicleprotocol/oracle-suite/pkg/starknet"
+)
+
+// ErrFeedsNotSupported is returned by the Feeds method because the Starknet
+// median contract authorizes feeders by their Stark public keys instead of
+// Ethereum addresses.
+var ErrFeedsNotSupported = errors.New("starknet median does not support listing feeds as Ethereum addresses")
+
+// ErrMissingStarkSignature is returned when one of the prices is not signed
+// using the Stark signature.
+var ErrMissingStarkSignature = errors.New("price does not have a Stark signature")
+
+// Sequencer is the subset of the Starknet sequencer API used by the Median.
+type Sequencer interface {
+	CallContract(ctx context.Context, call *starknet.Call) ([]*starknet.Felt, error)
+	AddTransaction(ctx context.Context, tx *starknet.Transaction) (*starknet.AddTransactionResult, error)
+}
+
+// Median implements the oracle.Oracle interface for the median contract
+// deployed on Starknet. Prices are verified by the contract using the Stark
+// signatures, so the poke transaction is sent as an invoke transaction
+// directly to the median contract.
+type Median struct {
+	sequencer Sequencer
+	address   *starknet.Felt
+	maxFee    *starknet.Felt
+}
+
+// NewMedian creates the new Median instance. The maxFee is the maximum fee
+// (in Wei) the relayer is willing to pay for the poke transaction.
+func NewMedian(sequencer Sequencer, address *starknet.Felt, maxFee *big.Int) *Median {
+	if maxFee == nil {
+		maxFee = big.NewInt(0)
+	}
+	return &Median{
+		sequencer: sequencer,
+		address:   address,
+		maxFee:    starknet.BigToFelt(maxFee),
+	}
+}
+
+// Address implements the oracle.Oracle interface. Starknet addresses do not
+// fit into Ethereum addresses, hence the zero address is returned. Use
+// ContractAddress or AddressString to get the actual address.
+func (m *Median) Address() common.Address {
+	return common.Address{}
+}
+
+// AddressString implements the oracle.AddressStringer interface.
+func (m *Median) AddressString() string {
+	return "0x" + m.address.Text(16)
+}
+
+// ContractAddress returns the address of the median contract on Starknet.
+func (m *Median) ContractAddress() *starknet.Felt {
+	return m.address
+}
+
+// Age implements the oracle.Oracle interface.
+func (m *Median) Age(ctx context.Context) (time.Time, error) {
+	r, err := m.read(ctx, "age")
+	if err != nil {
+		return time.Unix(0, 0), err
+	}
+
+	return time.Unix(r.Int64(), 0), nil
+}
+
+// Bar implements the oracle.Oracle interface.
+func (m *Median) Bar(ctx context.Context) (int64, error) {
+	r, err := m.read(ctx, "bar")
+	if err != nil {
+		return 0, err
+	}
+
+	return r.Int64(), nil
+}
+
+// Val implements the oracle.Oracle interface.
+func (m *Median) Val(ctx context.Context) (*big.Int, error) {
+	r, err := m.read(ctx, "read")
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Int, nil
+}
+
+// Wat implements the oracle.Oracle interface.
+func (m *Median) Wat(ctx context.Context) (string, error) {
+	r, err := m.read(ctx, "wat")
+	if err != nil {
+		return "", err
+	}
+
+	return r.ShortString(), nil
+}
+
+// Feeds implements the oracle.Oracle interface. It always returns the
+// ErrFeedsNotSupported error.
+func (m *Median) Feeds(_ context.Context) ([]ethereum.Address, error) {
+	return nil, ErrFeedsNotSupported
+}
+
+// Poke implements the oracle.Oracle interface.
+func (m *Median) Poke(ctx context.Context, prices []*oracle.Price, simulateBeforeRun bool) (*ethereum.Hash, error) {
+	calldata, err := pokeCalldata(prices)
+	if err != nil {
+		return nil, err
+	}
+
+	if simulateBeforeRun {
+		if _, err := m.call(ctx, "poke", calldata); err != nil {
+			return nil, err
+		}
+	}
+
+	res, err := m.sequencer.AddTransaction(ctx, &starknet.Transaction{
+		Type:               starknet.TransactionTypeInvoke,
+		ContractAddress:    m.address,
+		EntryPointSelector: starknet.Selector("poke"),
+		Calldata:           calldata,
+		Signature:          []*starknet.Felt{},
+		MaxFee:             m.maxFee,
+		Version:            starknet.BigToFelt(big.NewInt(0)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if res.TransactionHash == nil {
+		return nil, fmt.Errorf("transaction rejected by the sequencer: %s", res.Code)
+	}
+
+	hash := common.BigToHash(res.TransactionHash.Int)
+	return &hash, nil
+}
+
+// PokeCalldata implements the oracle.Oracle interface. The calldata is
+// returned as a concatenation of 32-byte big-endian felts.
+func (m *Median) PokeCalldata(_ context.Context, prices []*oracle.Price) ([]byte, error) {
+	calldata, err := pokeCalldata(prices)
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	for _, f := range calldata {
+		b = append(b, common.BigToHash(f.Int).Bytes()...)
+	}
+	return b, nil
+}
+
+func (m *Median) read(ctx context.Context, method string) (*starknet.Felt, error) {
+	r, err := m.call(ctx, method, []*starknet.Felt{})
+	if err != nil {
+		return nil, err
+	}
+	if len(r) == 0 {
+		return nil, fmt.Errorf("empty result returned by the %s method", method)
+	}
+
+	return r[0], nil
+}
+
+func (m *Median) call(ctx context.Context, method string, calldata []*starknet.Felt) ([]*starknet.Felt, error) {
+	return m.sequencer.CallContract(ctx, &starknet.Call{
+		ContractAddress:    m.address,
+		EntryPointSelector: starknet.Selector(method),
+		Calldata:           calldata,
+		Signature:          []*starknet.Felt{},
+	})
+}
+
+// pokeCalldata converts prices to the calldata of the poke method. Every
+// argument of the poke method is an array, so it is prefixed with its
+// length, as required by the Cairo ABI:
+//
+//	poke(val_len, val*, age_len, age*, r_len, r*, s_len, s*, pk_len, pk*)
+func pokeCalldata(prices []*oracle.Price) ([]*starknet.Felt, error) {
+	// It's important to send prices in correct order, otherwise contract will
+	// fail. The prices are copied to not modify the caller's slice:
+	prices = append([]*oracle.Price{}, prices...)
+	sort.Slice(prices, func(i, j int) bool {
+		return prices[i].Val.Cmp(prices[j].Val) < 0
+	})
+
+	n := starknet.BigToFelt(big.NewInt(int64(len(prices))))
+	val := []*starknet.Felt{n}
+	age := []*starknet.Felt{n}
+	r := []*starknet.Felt{n}
+	s := []*starknet.Felt{n}
+	pk := []*starknet.Felt{n}
+	for _, p := range prices {
+		if len(p.StarkR) == 0 || len(p.StarkS) == 0 || len(p.StarkPK) == 0 {
+			return nil, ErrMissingStarkSignature
+		}
+		val = append(val, starknet.BigToFelt(p.Val))
+		age = append(age, starknet.BigToFelt(big.NewInt(p.Age.Unix())))
+		r = append(r, starknet.BytesToFelt(p.StarkR))
+		s = append(s, starknet.BytesToFelt(p.StarkS))
+		pk = append(pk, starknet.BytesToFelt(p.StarkPK))
+	}
+
+	var calldata []*starknet.Felt
+	calldata = append(calldata, val...)
+	calldata = append(calldata, age...)
+	calldata = append(calldata, r...)
+	calldata = append(calldata, s...)
+	calldata = append(calldata, pk...)
+	return calldata, nil
+}
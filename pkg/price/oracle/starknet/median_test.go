@@ -0,0 +1,167 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package starknet
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
+	"github.com/chronicleprotocol/oracle-suite/pkg/starknet"
+	"github.com/chronicleprotocol/oracle-suite/pkg/starknet/mocks"
+)
+
+var testAddress = starknet.HexToFelt("0x197f9e93cfaf7068ca2daf3ec89c2b91d051505c2231a0a0b9f70801a91fb24")
+
+func matchCall(method string) interface{} {
+	return mock.MatchedBy(func(c *starknet.Call) bool {
+		return c.ContractAddress.Cmp(testAddress.Int) == 0 &&
+			c.EntryPointSelector.Cmp(starknet.Selector(method).Int) == 0
+	})
+}
+
+func TestMedian_Read(t *testing.T) {
+	// Prepare test data:
+	s := &mocks.Sequencer{}
+	m := NewMedian(s, testAddress, nil)
+
+	s.On("CallContract", mock.Anything, matchCall("age")).Return([]*starknet.Felt{starknet.BigToFelt(big.NewInt(123456))}, nil)
+	s.On("CallContract", mock.Anything, matchCall("bar")).Return([]*starknet.Felt{starknet.BigToFelt(big.NewInt(13))}, nil)
+	s.On("CallContract", mock.Anything, matchCall("read")).Return([]*starknet.Felt{starknet.BigToFelt(big.NewInt(42))}, nil)
+	s.On("CallContract", mock.Anything, matchCall("wat")).Return([]*starknet.Felt{starknet.StringToFelt("ETHUSD")}, nil)
+
+	// Call read functions:
+	age, err := m.Age(context.Background())
+	require.NoError(t, err)
+	bar, err := m.Bar(context.Background())
+	require.NoError(t, err)
+	val, err := m.Val(context.Background())
+	require.NoError(t, err)
+	wat, err := m.Wat(context.Background())
+	require.NoError(t, err)
+
+	// Verify:
+	assert.Equal(t, int64(123456), age.Unix())
+	assert.Equal(t, int64(13), bar)
+	assert.Equal(t, int64(42), val.Int64())
+	assert.Equal(t, "ETHUSD", wat)
+}
+
+func TestMedian_Read_EmptyResult(t *testing.T) {
+	s := &mocks.Sequencer{}
+	m := NewMedian(s, testAddress, nil)
+
+	s.On("CallContract", mock.Anything, matchCall("bar")).Return([]*starknet.Felt{}, nil)
+
+	_, err := m.Bar(context.Background())
+	assert.Error(t, err)
+}
+
+func TestMedian_Poke(t *testing.T) {
+	// Prepare test data:
+	s := &mocks.Sequencer{}
+	m := NewMedian(s, testAddress, big.NewInt(1000))
+
+	prices := []*oracle.Price{
+		{
+			Wat:     "ETHUSD",
+			Val:     big.NewInt(20),
+			Age:     time.Unix(0xAAAA, 0),
+			StarkR:  []byte{0x01},
+			StarkS:  []byte{0x02},
+			StarkPK: []byte{0x03},
+		},
+		{
+			Wat:     "ETHUSD",
+			Val:     big.NewInt(10),
+			Age:     time.Unix(0xBBBB, 0),
+			StarkR:  []byte{0x04},
+			StarkS:  []byte{0x05},
+			StarkPK: []byte{0x06},
+		},
+	}
+
+	s.On("CallContract", mock.Anything, matchCall("poke")).Return([]*starknet.Felt{}, nil)
+	s.On("AddTransaction", mock.Anything, mock.Anything).Return(&starknet.AddTransactionResult{
+		Code:            "TRANSACTION_RECEIVED",
+		TransactionHash: starknet.HexToFelt("0xdeadbeef"),
+	}, nil)
+
+	// Call Poke function:
+	hash, err := m.Poke(context.Background(), prices, true)
+	require.NoError(t, err)
+
+	// Verify:
+	assert.Equal(t, "0x00000000000000000000000000000000000000000000000000000000deadbeef", hash.String())
+
+	tx := s.Calls()[1].Arguments.Get(1).(*starknet.Transaction)
+	assert.Equal(t, starknet.TransactionTypeInvoke, tx.Type)
+	assert.Equal(t, testAddress, tx.ContractAddress)
+	assert.Equal(t, starknet.Selector("poke").Int, tx.EntryPointSelector.Int)
+	assert.Equal(t, int64(1000), tx.MaxFee.Int64())
+
+	var calldata []int64
+	for _, f := range tx.Calldata {
+		calldata = append(calldata, f.Int64())
+	}
+	assert.Equal(t, []int64{
+		2, 10, 20, // val
+		2, 0xBBBB, 0xAAAA, // age
+		2, 0x04, 0x01, // r
+		2, 0x05, 0x02, // s
+		2, 0x06, 0x03, // pk
+	}, calldata)
+	// The caller's slice must not be sorted:
+	assert.Equal(t, int64(20), prices[0].Val.Int64())
+}
+
+func TestMedian_AddressString(t *testing.T) {
+	m := NewMedian(&mocks.Sequencer{}, starknet.HexToFelt("0x197f9e93"), nil)
+	assert.Equal(t, "0x197f9e93", m.AddressString())
+	assert.Equal(t, "0x197f9e93", oracle.AddressString(m))
+}
+
+func TestMedian_Poke_SimulationFailed(t *testing.T) {
+	s := &mocks.Sequencer{}
+	m := NewMedian(s, testAddress, nil)
+
+	prices := []*oracle.Price{
+		{Wat: "ETHUSD", Val: big.NewInt(10), Age: time.Unix(1, 0), StarkR: []byte{1}, StarkS: []byte{2}, StarkPK: []byte{3}},
+	}
+
+	s.On("CallContract", mock.Anything, matchCall("poke")).Return([]*starknet.Felt{}, errors.New("invalid signature"))
+
+	_, err := m.Poke(context.Background(), prices, true)
+	assert.Error(t, err)
+	s.AssertNotCalled(t, "AddTransaction", mock.Anything, mock.Anything)
+}
+
+func TestMedian_PokeCalldata_MissingStarkSignature(t *testing.T) {
+	s := &mocks.Sequencer{}
+	m := NewMedian(s, testAddress, nil)
+
+	_, err := m.PokeCalldata(context.Background(), []*oracle.Price{
+		{Wat: "ETHUSD", Val: big.NewInt(10), Age: time.Unix(1, 0)},
+	})
+	assert.ErrorIs(t, err, ErrMissingStarkSignature)
+}
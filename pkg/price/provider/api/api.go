@@ -0,0 +1,269 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver"
+	"github.com/chronicleprotocol/oracle-suite/pkg/httpserver/middleware"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/marshal"
+)
+
+const LoggerTag = "PRICE_API"
+
+// defaultTimeout is the default timeout for the HTTP server.
+const defaultTimeout = 10 * time.Second
+
+// PriceAPI provides an HTTP JSON API for a price provider. It exposes the
+// same data as the RPC agent for clients that cannot use Go's net/rpc.
+//
+// It provides the following GET endpoints:
+// /pairs - list of supported pairs
+// /prices - prices for pairs given in the "pair" query parameter, or for all
+// supported pairs if the parameter is missing
+// /models - price models for pairs given in the "pair" query parameter
+// /health - returns 200 if the service is running
+//
+// The "pair" parameter may be repeated, pairs must be formatted as
+// "BASE/QUOTE". Prices are encoded in the same way as the JSON output of
+// the gofer CLI.
+type PriceAPI struct {
+	ctx context.Context
+
+	srv      *httpserver.HTTPServer
+	provider provider.Provider
+	log      log.Logger
+}
+
+// Config is the configuration for the PriceAPI.
+type Config struct {
+	// Provider is the price provider to use.
+	Provider provider.Provider
+	// Address specifies the TCP address for the server to listen on in the
+	// form "host:port".
+	Address string
+	// Logger is a current logger used by the PriceAPI.
+	Logger log.Logger
+}
+
+type jsonModel struct {
+	Type       string            `json:"type"`
+	Pair       string            `json:"pair"`
+	Parameters map[string]string `json:"params,omitempty"`
+	Models     []*jsonModel      `json:"models,omitempty"`
+}
+
+type jsonError struct {
+	Error string `json:"error"`
+}
+
+// New returns a new instance of the PriceAPI struct.
+func New(cfg Config) (*PriceAPI, error) {
+	if cfg.Provider == nil {
+		return nil, errors.New("price provider must not be nil")
+	}
+	if cfg.Address == "" {
+		return nil, errors.New("address must not be empty")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	api := &PriceAPI{
+		provider: cfg.Provider,
+		log:      cfg.Logger.WithField("tag", LoggerTag),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/pairs", api.pairsHandler)
+	mux.HandleFunc("/prices", api.pricesHandler)
+	mux.HandleFunc("/models", api.modelsHandler)
+	api.srv = httpserver.New(&http.Server{
+		Addr:              cfg.Address,
+		Handler:           mux,
+		IdleTimeout:       defaultTimeout,
+		ReadTimeout:       defaultTimeout,
+		WriteTimeout:      defaultTimeout,
+		ReadHeaderTimeout: defaultTimeout,
+	})
+	api.srv.Use(&middleware.Recover{})
+	api.srv.Use(&middleware.CORS{
+		Origin:  func(*http.Request) string { return "*" },
+		Headers: func(*http.Request) string { return "Content-Type" },
+		Methods: func(*http.Request) string { return "GET" },
+	})
+	api.srv.Use(&middleware.HealthCheck{
+		Path:  "/health",
+		Check: func(r *http.Request) bool { return true },
+	})
+	api.srv.Use(&middleware.Logger{Log: api.log})
+	return api, nil
+}
+
+// Start starts HTTP server.
+func (a *PriceAPI) Start(ctx context.Context) error {
+	if a.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	a.log.Infof("Starting")
+	a.ctx = ctx
+	err := a.srv.Start(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to start the HTTP server: %w", err)
+	}
+	go a.contextCancelHandler()
+	return nil
+}
+
+// Wait waits until the context is canceled or until an error occurs.
+func (a *PriceAPI) Wait() chan error {
+	return a.srv.Wait()
+}
+
+// pairsHandler is the HTTP handler for the /pairs endpoint.
+func (a *PriceAPI) pairsHandler(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	pairs, err := a.provider.Pairs()
+	if err != nil {
+		a.writeError(res, err)
+		return
+	}
+	r := make([]string, 0, len(pairs))
+	for _, p := range pairs {
+		r = append(r, p.String())
+	}
+	sort.Strings(r)
+	writeJSON(res, http.StatusOK, r)
+}
+
+// pricesHandler is the HTTP handler for the /prices endpoint.
+func (a *PriceAPI) pricesHandler(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	pairs, err := provider.NewPairs(req.URL.Query()["pair"]...)
+	if err != nil {
+		writeJSON(res, http.StatusBadRequest, jsonError{Error: err.Error()})
+		return
+	}
+	prices, err := a.provider.Prices(pairs...)
+	if err != nil {
+		a.writeError(res, err)
+		return
+	}
+	ps := make([]*provider.Price, 0, len(prices))
+	for _, p := range prices {
+		ps = append(ps, p)
+	}
+	sort.Slice(ps, func(i, j int) bool {
+		return ps[i].Pair.String() < ps[j].Pair.String()
+	})
+	items := make([]interface{}, 0, len(ps))
+	for _, p := range ps {
+		items = append(items, p)
+	}
+	if len(items) == 0 {
+		writeJSON(res, http.StatusOK, items)
+		return
+	}
+	bts, err := marshal.Marshall(marshal.JSON, items...)
+	if err != nil {
+		a.writeError(res, err)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(http.StatusOK)
+	_, _ = res.Write(bts)
+}
+
+// modelsHandler is the HTTP handler for the /models endpoint.
+func (a *PriceAPI) modelsHandler(res http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		res.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	pairs, err := provider.NewPairs(req.URL.Query()["pair"]...)
+	if err != nil {
+		writeJSON(res, http.StatusBadRequest, jsonError{Error: err.Error()})
+		return
+	}
+	models, err := a.provider.Models(pairs...)
+	if err != nil {
+		a.writeError(res, err)
+		return
+	}
+	r := make([]*jsonModel, 0, len(models))
+	for _, m := range models {
+		r = append(r, mapModel(m))
+	}
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].Pair < r[j].Pair
+	})
+	writeJSON(res, http.StatusOK, r)
+}
+
+// writeError writes a provider error as an HTTP response. Unknown pairs are
+// reported with the 404 status code.
+func (a *PriceAPI) writeError(res http.ResponseWriter, err error) {
+	var pnf graph.ErrPairNotFound
+	if errors.As(err, &pnf) {
+		writeJSON(res, http.StatusNotFound, jsonError{Error: err.Error()})
+		return
+	}
+	a.log.WithError(err).Error("Price provider error")
+	writeJSON(res, http.StatusInternalServerError, jsonError{Error: err.Error()})
+}
+
+func (a *PriceAPI) contextCancelHandler() {
+	defer a.log.Info("Stopped")
+	<-a.ctx.Done()
+}
+
+// mapModel converts a provider model to a JSON model to be returned as HTTP
+// response.
+func mapModel(m *provider.Model) *jsonModel {
+	j := &jsonModel{
+		Type:       m.Type,
+		Pair:       m.Pair.String(),
+		Parameters: m.Parameters,
+	}
+	for _, c := range m.Models {
+		j.Models = append(j.Models, mapModel(c))
+	}
+	return j
+}
+
+func writeJSON(res http.ResponseWriter, status int, v interface{}) {
+	res.Header().Set("Content-Type", "application/json")
+	res.WriteHeader(status)
+	_ = json.NewEncoder(res).Encode(v)
+}
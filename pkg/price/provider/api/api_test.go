@@ -0,0 +1,129 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/mocks"
+)
+
+func TestPriceAPI(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	ab := provider.Pair{Base: "A", Quote: "B"}
+	cd := provider.Pair{Base: "C", Quote: "D"}
+	xy := provider.Pair{Base: "X", Quote: "Y"}
+	ts := time.Unix(1, 0)
+
+	pro := &mocks.Provider{}
+	pro.On("Pairs").Return([]provider.Pair{cd, ab}, nil)
+	pro.On("Prices", ab).Return(map[provider.Pair]*provider.Price{
+		ab: {Type: "median", Pair: ab, Price: 10, Time: ts},
+	}, nil)
+	pro.On("Prices").Return(map[provider.Pair]*provider.Price{
+		ab: {Type: "median", Pair: ab, Price: 10, Time: ts},
+		cd: {Type: "median", Pair: cd, Price: 20, Time: ts, Error: "err"},
+	}, nil)
+	pro.On("Prices", xy).Return(map[provider.Pair]*provider.Price(nil), graph.ErrPairNotFound{Pair: xy})
+	pro.On("Models", ab).Return(map[provider.Pair]*provider.Model{
+		ab: {
+			Type:       "median",
+			Pair:       ab,
+			Parameters: map[string]string{"minimumSuccessfulSources": "1"},
+			Models:     []*provider.Model{{Type: "origin", Pair: ab, Parameters: map[string]string{"origin": "a"}}},
+		},
+	}, nil)
+
+	api, err := New(Config{
+		Provider: pro,
+		Address:  "127.0.0.1:0",
+		Logger:   null.New(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, api.Start(ctx))
+	defer func() {
+		cancelFunc()
+		require.NoError(t, <-api.Wait())
+	}()
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{
+			path:   "/pairs",
+			status: http.StatusOK,
+			body:   `["A/B","C/D"]`,
+		},
+		{
+			path:   "/prices?pair=A/B",
+			status: http.StatusOK,
+			body:   `[{"type":"median","base":"A","quote":"B","price":10,"bid":0,"ask":0,"vol24h":0,"ts":"1970-01-01T00:00:01Z"}]`,
+		},
+		{
+			path:   "/prices",
+			status: http.StatusOK,
+			body:   `[{"type":"median","base":"A","quote":"B","price":10,"bid":0,"ask":0,"vol24h":0,"ts":"1970-01-01T00:00:01Z"},{"type":"median","base":"C","quote":"D","price":20,"bid":0,"ask":0,"vol24h":0,"ts":"1970-01-01T00:00:01Z","error":"err"}]`,
+		},
+		{
+			path:   "/prices?pair=X/Y",
+			status: http.StatusNotFound,
+			body:   `{"error":"unable to find the X/Y pair"}`,
+		},
+		{
+			path:   "/prices?pair=AB",
+			status: http.StatusBadRequest,
+			body:   `{"error":"couldn't parse pair \"AB\""}`,
+		},
+		{
+			path:   "/models?pair=A/B",
+			status: http.StatusOK,
+			body:   `[{"type":"median","pair":"A/B","params":{"minimumSuccessfulSources":"1"},"models":[{"type":"origin","pair":"A/B","params":{"origin":"a"}}]}]`,
+		},
+		{
+			path:   "/health",
+			status: http.StatusOK,
+			body:   ``,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			res, err := http.Get(fmt.Sprintf("http://%s%s", api.srv.Addr().String(), tt.path))
+			require.NoError(t, err)
+			defer res.Body.Close()
+			body, err := io.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, res.StatusCode)
+			if tt.body == "" {
+				assert.Empty(t, body)
+			} else {
+				assert.JSONEq(t, tt.body, string(body))
+			}
+		})
+	}
+}
@@ -29,6 +29,11 @@ import (
 
 const LoggerTag = "ASYNC_GOFER"
 
+const (
+	streamMinBackoff = time.Second // Delay before retrying a failed subscription.
+	streamMaxBackoff = time.Minute // Maximum delay between subscription retries.
+)
+
 // AsyncProvider implements the provider.Provider interface. It works just like Graph
 // but allows updating prices asynchronously.
 type AsyncProvider struct {
@@ -38,6 +43,9 @@ type AsyncProvider struct {
 	feeder *feeder.Feeder
 	nodes  []nodes.Node
 	log    log.Logger
+
+	streamMinBackoff time.Duration
+	streamMaxBackoff time.Duration
 }
 
 // NewAsyncProvider returns a new AsyncGofer instance.
@@ -54,6 +62,9 @@ func NewAsyncProvider(
 		feeder:   feeder,
 		nodes:    nodes,
 		log:      logger.WithField("tag", LoggerTag),
+
+		streamMinBackoff: streamMinBackoff,
+		streamMaxBackoff: streamMaxBackoff,
 	}, nil
 }
 
@@ -71,7 +82,8 @@ func (a *AsyncProvider) Start(ctx context.Context) error {
 	// To ensure that broken origins do not affect the fetching of prices from
 	// other origins, all nodes are grouped by origin, and a separate goroutine
 	// is created for each of them. In this way, problems with one origin should
-	// not delay the fetching of prices from other origins.
+	// not delay the fetching of prices from other origins. Streaming origins
+	// are not polled, instead prices are fed as soon as they are received.
 	originNodes := map[string][]nodes.Node{}
 	for _, graph := range a.graphs {
 		nodes.Walk(func(node nodes.Node) {
@@ -81,8 +93,12 @@ func (a *AsyncProvider) Start(ctx context.Context) error {
 			}
 		}, graph)
 	}
-	for _, ns := range originNodes {
-		ns := ns
+	for origin, ns := range originNodes {
+		origin, ns := origin, ns
+		if a.feeder.IsStreaming(origin) {
+			go a.stream(origin, ns)
+			continue
+		}
 		ttl := gcdTTL(ns)
 		if ttl < time.Second {
 			ttl = time.Second
@@ -91,7 +107,7 @@ func (a *AsyncProvider) Start(ctx context.Context) error {
 			// We have to add ttl to the current time because we want
 			// to find all nodes that will expire before the next tick.
 			t := time.Now().Add(ttl)
-			warns := a.feeder.Feed(ns, t)
+			warns := a.feeder.Feed(a.ctx, ns, t)
 			if len(warns.List) > 0 {
 				a.log.WithError(warns.ToError()).Warn("Unable to feed some nodes")
 			}
@@ -115,6 +131,38 @@ func (a *AsyncProvider) Start(ctx context.Context) error {
 	return nil
 }
 
+// stream feeds nodes with prices from the given streaming origin. If the
+// subscription fails, it is retried with an exponential backoff until the
+// context is canceled.
+func (a *AsyncProvider) stream(origin string, ns []nodes.Node) {
+	backoff := a.streamMinBackoff
+	for {
+		err := a.feeder.Stream(a.ctx, origin, ns)
+		if err == nil || a.ctx.Err() != nil {
+			return
+		}
+		a.log.
+			WithError(err).
+			WithField("origin", origin).
+			WithField("retryIn", backoff).
+			Error("Unable to stream prices")
+		select {
+		case <-a.ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > a.streamMaxBackoff {
+			backoff = a.streamMaxBackoff
+		}
+	}
+}
+
+// OriginsHealth implements the provider.OriginsHealthProvider interface.
+func (a *AsyncProvider) OriginsHealth() (map[string]*provider.OriginHealth, error) {
+	return a.feeder.OriginsHealth(), nil
+}
+
 // Wait waits until the context is canceled or until an error occurs.
 func (a *AsyncProvider) Wait() chan error {
 	return a.waitCh
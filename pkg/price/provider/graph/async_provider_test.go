@@ -1,15 +1,44 @@
 package graph
 
 import (
+	"context"
+	"errors"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/feeder"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/nodes"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/origins"
 )
 
+// failingStreamHandler fails the first failures subscriptions.
+type failingStreamHandler struct {
+	mu         sync.Mutex
+	failures   int
+	subscribes int
+	ch         chan origins.FetchResult
+}
+
+func (h *failingStreamHandler) Fetch(pairs []origins.Pair) []origins.FetchResult {
+	return nil
+}
+
+func (h *failingStreamHandler) Subscribe(_ context.Context, _ []origins.Pair) (<-chan origins.FetchResult, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.subscribes++
+	if h.subscribes <= h.failures {
+		return nil, errors.New("subscribe failed")
+	}
+	return h.ch, nil
+}
+
 func Test_gcdTTL(t *testing.T) {
 	p := provider.Pair{Base: "A", Quote: "B"}
 	root := nodes.NewMedianAggregatorNode(p, 1)
@@ -24,3 +53,25 @@ func Test_gcdTTL(t *testing.T) {
 
 	assert.Equal(t, 2*time.Second, gcdTTL([]nodes.Node{root}))
 }
+
+func TestAsyncProvider_StreamRetry(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	p := provider.Pair{Base: "A", Quote: "B"}
+	h := &failingStreamHandler{failures: 2, ch: make(chan origins.FetchResult)}
+	root := nodes.NewMedianAggregatorNode(p, 1)
+	on := nodes.NewOriginNode(nodes.OriginPair{Origin: "stream", Pair: p}, 0, time.Minute)
+	root.AddChild(on)
+
+	f := feeder.NewFeeder(origins.NewSet(map[string]origins.Handler{"stream": h}), null.New())
+	a, err := NewAsyncProvider(map[provider.Pair]nodes.Aggregator{p: root}, f, nil, null.New())
+	require.NoError(t, err)
+	a.streamMinBackoff = time.Millisecond
+	require.NoError(t, a.Start(ctx))
+
+	// The price must be fed after the subscription succeeds:
+	h.ch <- origins.FetchResult{Price: origins.Price{Pair: origins.Pair{Base: "A", Quote: "B"}, Price: 10, Timestamp: time.Now()}}
+	assert.Eventually(t, func() bool { return on.Price().Price == 10 }, time.Second, time.Millisecond)
+	assert.Equal(t, 3, h.subscribes)
+}
@@ -0,0 +1,165 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package discovery
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/nodes"
+)
+
+const defaultMaxHops = 2
+const defaultMaxPaths = 5
+
+// Options configures the path search.
+type Options struct {
+	// MaxHops is the maximum number of pairs in a path. If zero, the default
+	// value of 2 is used.
+	MaxHops int
+	// MaxPaths is the maximum number of returned paths. If zero, the default
+	// value of 5 is used.
+	MaxPaths int
+	// Bridges is a list of preferred intermediate assets, ordered from
+	// the most preferred one. Paths that use preferred assets are returned
+	// before other paths with the same number of hops.
+	Bridges []string
+}
+
+// Path is a list of origin pairs that can be used to calculate the price
+// of a pair using the nodes.IndirectAggregatorNode.
+type Path []nodes.OriginPair
+
+// String returns a string representation of the path, e.g.
+// "A/C binance -> C/B kraken".
+func (p Path) String() string {
+	s := make([]string, len(p))
+	for i, op := range p {
+		s[i] = op.String()
+	}
+	return strings.Join(s, " -> ")
+}
+
+// Paths returns paths from the base asset to the quote asset of the given
+// pair using the given origin pairs as edges of the graph. The direction of
+// pairs does not matter, except for single-hop paths, which must match
+// the given pair exactly. Every asset appears at most once in a path.
+//
+// Paths are sorted by the number of hops, then by preference of the bridge
+// assets and then alphabetically.
+func Paths(edges []nodes.OriginPair, pair provider.Pair, opts Options) []Path {
+	if opts.MaxHops <= 0 {
+		opts.MaxHops = defaultMaxHops
+	}
+	if opts.MaxPaths <= 0 {
+		opts.MaxPaths = defaultMaxPaths
+	}
+
+	// Index edges by assets:
+	adj := map[string][]nodes.OriginPair{}
+	seen := map[nodes.OriginPair]struct{}{}
+	for _, e := range edges {
+		if _, ok := seen[e]; ok {
+			continue
+		}
+		seen[e] = struct{}{}
+		adj[e.Pair.Base] = append(adj[e.Pair.Base], e)
+		if e.Pair.Base != e.Pair.Quote {
+			adj[e.Pair.Quote] = append(adj[e.Pair.Quote], e)
+		}
+	}
+
+	var paths []Path
+	visited := map[string]bool{pair.Base: true}
+	var recur func(asset string, path Path)
+	recur = func(asset string, path Path) {
+		if len(path) >= opts.MaxHops {
+			return
+		}
+		for _, e := range adj[asset] {
+			next := e.Pair.Quote
+			if next == asset {
+				next = e.Pair.Base
+			}
+			if visited[next] {
+				continue
+			}
+			p := append(append(Path{}, path...), e)
+			if next == pair.Quote {
+				if len(p) > 1 || e.Pair.Equal(pair) {
+					paths = append(paths, p)
+				}
+				continue
+			}
+			visited[next] = true
+			recur(next, p)
+			visited[next] = false
+		}
+	}
+	recur(pair.Base, nil)
+
+	type scoredPath struct {
+		path  Path
+		score int
+		key   string
+	}
+	scored := make([]scoredPath, len(paths))
+	for i, p := range paths {
+		scored[i] = scoredPath{path: p, score: bridgeScore(p, pair, opts.Bridges), key: p.String()}
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		a, b := scored[i], scored[j]
+		if len(a.path) != len(b.path) {
+			return len(a.path) < len(b.path)
+		}
+		if a.score != b.score {
+			return a.score < b.score
+		}
+		return a.key < b.key
+	})
+
+	var res []Path
+	for i := 0; i < len(scored) && i < opts.MaxPaths; i++ {
+		res = append(res, scored[i].path)
+	}
+	return res
+}
+
+// bridgeScore returns the sum of ranks of intermediate assets in the path.
+// The rank of the asset is its index in the bridges list or the length of
+// the list if the asset is not preferred. The lower score is better.
+func bridgeScore(p Path, pair provider.Pair, bridges []string) int {
+	rank := func(asset string) int {
+		for i, b := range bridges {
+			if strings.EqualFold(b, asset) {
+				return i
+			}
+		}
+		return len(bridges)
+	}
+	score := 0
+	asset := pair.Base
+	for _, op := range p[:len(p)-1] {
+		if op.Pair.Base == asset {
+			asset = op.Pair.Quote
+		} else {
+			asset = op.Pair.Base
+		}
+		score += rank(asset)
+	}
+	return score
+}
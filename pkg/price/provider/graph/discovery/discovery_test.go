@@ -0,0 +1,116 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package discovery
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/nodes"
+)
+
+func op(origin, pair string) nodes.OriginPair {
+	p, _ := provider.NewPair(pair)
+	return nodes.OriginPair{Origin: origin, Pair: p}
+}
+
+func pathStrings(ps []Path) []string {
+	var s []string
+	for _, p := range ps {
+		s = append(s, p.String())
+	}
+	return s
+}
+
+func TestPaths(t *testing.T) {
+	edges := []nodes.OriginPair{
+		op("a", "ETH/USD"),
+		op("a", "USD/ETH"), // Inverted single hop is not a valid path.
+		op("b", "ETH/BTC"),
+		op("c", "BTC/USD"),
+		op("d", "ETH/USDT"),
+		op("d", "USDT/USD"),
+		op("e", "ETH/DAI"),
+		op("e", "USD/DAI"),
+		op("f", "BTC/USDT"),
+		op("f", "BTC/USDT"), // Duplicated edges are ignored.
+	}
+	tests := []struct {
+		name  string
+		pair  string
+		opts  Options
+		paths []string
+	}{
+		{
+			name: "default",
+			pair: "ETH/USD",
+			opts: Options{},
+			paths: []string{
+				"ETH/USD a",
+				"ETH/BTC b -> BTC/USD c",
+				"ETH/DAI e -> USD/DAI e",
+				"ETH/USDT d -> USDT/USD d",
+			},
+		},
+		{
+			name: "preferred-bridges",
+			pair: "ETH/USD",
+			opts: Options{Bridges: []string{"usdt", "dai"}},
+			paths: []string{
+				"ETH/USD a",
+				"ETH/USDT d -> USDT/USD d",
+				"ETH/DAI e -> USD/DAI e",
+				"ETH/BTC b -> BTC/USD c",
+			},
+		},
+		{
+			name: "max-paths",
+			pair: "ETH/USD",
+			opts: Options{MaxPaths: 2, Bridges: []string{"BTC"}},
+			paths: []string{
+				"ETH/USD a",
+				"ETH/BTC b -> BTC/USD c",
+			},
+		},
+		{
+			name: "max-hops",
+			pair: "ETH/USD",
+			opts: Options{MaxHops: 3, MaxPaths: 10},
+			paths: []string{
+				"ETH/USD a",
+				"ETH/BTC b -> BTC/USD c",
+				"ETH/DAI e -> USD/DAI e",
+				"ETH/USDT d -> USDT/USD d",
+				"ETH/BTC b -> BTC/USDT f -> USDT/USD d",
+				"ETH/USDT d -> BTC/USDT f -> BTC/USD c",
+			},
+		},
+		{
+			name:  "no-paths",
+			pair:  "ETH/EUR",
+			opts:  Options{},
+			paths: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, _ := provider.NewPair(tt.pair)
+			assert.Equal(t, tt.paths, pathStrings(Paths(edges, p, tt.opts)))
+		})
+	}
+}
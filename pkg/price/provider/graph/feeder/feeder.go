@@ -16,19 +16,30 @@
 package feeder
 
 import (
+	"context"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/nodes"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/origins"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
+	"github.com/chronicleprotocol/oracle-suite/pkg/tracing"
 )
 
 const LoggerTag = "FEEDER"
 
+var tracer = otel.Tracer("github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/feeder")
+
 // Warnings contains a list of minor errors which occurred during fetching
 // prices.
 type Warnings struct {
@@ -67,26 +78,106 @@ type Feedable interface {
 }
 
 // Feeder sets prices from origins to the Feedable nodes.
+//
+// Feeder tracks the health of every origin. Origins that fail too many
+// times in a row are temporarily disabled, as configured by the
+// CircuitBreakerConfig.
 type Feeder struct {
 	waitCh chan error
 	set    *origins.Set
+	health *healthTracker
 	log    log.Logger
 }
 
-// NewFeeder creates new Feeder instance.
+// NewFeeder creates new Feeder instance with the default circuit breaker
+// configuration.
 func NewFeeder(set *origins.Set, log log.Logger) *Feeder {
+	return NewFeederWithCircuitBreaker(set, log, DefaultCircuitBreakerConfig)
+}
+
+// NewFeederWithCircuitBreaker creates new Feeder instance with the given
+// circuit breaker configuration.
+func NewFeederWithCircuitBreaker(set *origins.Set, log log.Logger, cfg CircuitBreakerConfig) *Feeder {
 	return &Feeder{
 		set:    set,
+		health: newHealthTracker(cfg),
 		log:    log.WithField("tag", LoggerTag),
 		waitCh: make(chan error),
 	}
 }
 
+// OriginsHealth returns the health of all origins used so far.
+func (f *Feeder) OriginsHealth() map[string]*provider.OriginHealth {
+	return f.health.health()
+}
+
 // Feed sets Prices to Feedable nodes. This method takes list of root nodes
 // and sets prices to all of their children that implement the Feedable interface.
 // The t parameter represents the time against which the price expiration is compared.
-func (f *Feeder) Feed(ns []nodes.Node, t time.Time) Warnings {
-	return f.feedNodes(f.findFeedableNodes(ns, t))
+//
+// Every origin fetch is traced in a separate span, which is a child of the span
+// from the given context. The trace context of the fetch is assigned to the
+// fetched prices.
+func (f *Feeder) Feed(ctx context.Context, ns []nodes.Node, t time.Time) Warnings {
+	ctx, span := tracer.Start(ctx, "feeder.Feed")
+	defer span.End()
+	warns := f.feedNodes(ctx, f.findFeedableNodes(ns, t))
+	span.SetAttributes(attribute.Int("warnings", len(warns.List)))
+	return warns
+}
+
+// IsStreaming returns true if the given origin pushes prices using
+// a streaming connection instead of being polled.
+func (f *Feeder) IsStreaming(origin string) bool {
+	_, ok := f.set.Handlers()[origin].(origins.StreamHandler)
+	return ok
+}
+
+// Stream subscribes to prices from the given streaming origin and sets them
+// to Feedable nodes as soon as they are received. This method takes list of
+// root nodes and only nodes for the given origin are fed. It blocks until
+// the context is canceled.
+func (f *Feeder) Stream(ctx context.Context, origin string, ns []nodes.Node) error {
+	handler, ok := f.set.Handlers()[origin].(origins.StreamHandler)
+	if !ok {
+		return fmt.Errorf("origin %s does not support streaming", origin)
+	}
+
+	nodesMap := map[origins.Pair][]Feedable{}
+	var pairs []origins.Pair
+	nodes.Walk(func(n nodes.Node) {
+		if feedable, ok := n.(Feedable); ok && feedable.OriginPair().Origin == origin {
+			pair := origins.Pair{
+				Base:  feedable.OriginPair().Pair.Base,
+				Quote: feedable.OriginPair().Pair.Quote,
+			}
+			nodesMap[pair] = appendNodeIfUnique(nodesMap[pair], feedable)
+			pairs = appendPairIfUnique(pairs, pair)
+		}
+	}, ns...)
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	ch, err := handler.Subscribe(ctx, pairs)
+	if err != nil {
+		return err
+	}
+	for fr := range ch {
+		f.health.record(origin, 0, []origins.FetchResult{fr})
+		for _, feedable := range nodesMap[fr.Price.Pair] {
+			price := mapOriginResult(origin, fr)
+
+			// If the connection was lost but previous Price is still
+			// not expired, do not try to override it:
+			if price.Error != nil && !feedable.Expired() {
+				f.log.WithError(price.Error).WithField("origin", origin).Warn("Unable to stream price")
+			} else if iErr := feedable.Ingest(price); iErr != nil {
+				f.log.WithError(iErr).WithField("origin", origin).Warn("Unable to ingest streamed price")
+			}
+		}
+	}
+	return nil
 }
 
 // findFeedableNodes returns a list of children nodes from given root nodes
@@ -105,7 +196,7 @@ func (f *Feeder) findFeedableNodes(ns []nodes.Node, t time.Time) []Feedable {
 	return feedables
 }
 
-func (f *Feeder) feedNodes(ns []Feedable) Warnings {
+func (f *Feeder) feedNodes(ctx context.Context, ns []Feedable) Warnings {
 	var warns Warnings
 
 	// originPair is used as a key in a map to easily find
@@ -138,8 +229,8 @@ func (f *Feeder) feedNodes(ns []Feedable) Warnings {
 		)
 	}
 
-	for origin, frs := range f.set.Fetch(pairsMap) {
-		for _, fr := range frs {
+	for origin, of := range f.fetch(ctx, pairsMap) {
+		for _, fr := range of.results {
 			op := originPair{
 				origin: origin,
 				pair:   fr.Price.Pair,
@@ -147,6 +238,7 @@ func (f *Feeder) feedNodes(ns []Feedable) Warnings {
 
 			for _, feedable := range nodesMap[op] {
 				price := mapOriginResult(origin, fr)
+				price.TraceContext = of.traceContext
 
 				// If there was an error during fetching a Price but previous Price is still
 				// not expired, do not try to override it:
@@ -162,6 +254,89 @@ func (f *Feeder) feedNodes(ns []Feedable) Warnings {
 	return warns
 }
 
+// originFetch contains results of fetching prices from a single origin.
+type originFetch struct {
+	results []origins.FetchResult
+	// traceContext is the trace context of the span in which prices were
+	// fetched. It is nil if the origin was not called.
+	traceContext map[string]string
+}
+
+// fetch fetches prices from origins and records their health. Disabled
+// origins are not called, instead an error is returned for all of their
+// pairs.
+func (f *Feeder) fetch(ctx context.Context, pairsMap map[string][]origins.Pair) map[string]originFetch {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	frs := map[string]originFetch{}
+	for origin, pairs := range pairsMap {
+		origin, pairs := origin, pairs
+		if until := f.health.disabledUntil(origin); !until.IsZero() {
+			err := fmt.Errorf("%w (%s) until %s", ErrOriginDisabled, origin, until.Format(time.RFC3339))
+			var r []origins.FetchResult
+			for _, pair := range pairs {
+				r = append(r, origins.FetchResult{Price: origins.Price{Pair: pair, Timestamp: time.Now()}, Error: err})
+			}
+			metrics.OriginFetchErrors.WithLabelValues(origin).Add(float64(len(r)))
+			mu.Lock()
+			frs[origin] = originFetch{results: r}
+			mu.Unlock()
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, span := tracer.Start(ctx, "feeder.fetch", trace.WithAttributes(
+				attribute.String("origin", origin),
+				attribute.Int("pairs", len(pairs)),
+			))
+			defer span.End()
+			t := time.Now()
+			r := f.set.Fetch(map[string][]origins.Pair{origin: pairs})[origin]
+			d := time.Since(t)
+			f.health.record(origin, d, r)
+			recordFetchMetrics(origin, d, r)
+			recordFetchSpan(span, r)
+			mu.Lock()
+			frs[origin] = originFetch{results: r, traceContext: tracing.Inject(ctx)}
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return frs
+}
+
+// recordFetchMetrics updates Prometheus metrics for a single origin fetch.
+func recordFetchMetrics(origin string, latency time.Duration, frs []origins.FetchResult) {
+	metrics.OriginFetchDuration.WithLabelValues(origin).Observe(latency.Seconds())
+	var failed int
+	for _, fr := range frs {
+		if fr.Error != nil {
+			failed++
+		}
+	}
+	if failed > 0 {
+		metrics.OriginFetchErrors.WithLabelValues(origin).Add(float64(failed))
+	}
+}
+
+// recordFetchSpan adds results of a single origin fetch to the span.
+func recordFetchSpan(span trace.Span, frs []origins.FetchResult) {
+	var failed int
+	for _, fr := range frs {
+		if fr.Error != nil {
+			if failed == 0 {
+				span.RecordError(fr.Error)
+			}
+			failed++
+		}
+	}
+	span.SetAttributes(attribute.Int("errors", failed))
+	if failed > 0 {
+		span.SetStatus(codes.Error, fmt.Sprintf("unable to fetch %d of %d prices", failed, len(frs)))
+	}
+}
+
 func appendPairIfUnique(pairs []origins.Pair, pair origins.Pair) []origins.Pair {
 	exists := false
 	for _, p := range pairs {
@@ -199,11 +374,12 @@ func mapOriginResult(origin string, fr origins.FetchResult) nodes.OriginPrice {
 				Base:  fr.Price.Pair.Base,
 				Quote: fr.Price.Pair.Quote,
 			},
-			Price:     fr.Price.Price,
-			Bid:       fr.Price.Bid,
-			Ask:       fr.Price.Ask,
-			Volume24h: fr.Price.Volume24h,
-			Time:      fr.Price.Timestamp,
+			Price:        fr.Price.Price,
+			Bid:          fr.Price.Bid,
+			Ask:          fr.Price.Ask,
+			Volume24h:    fr.Price.Volume24h,
+			Time:         fr.Price.Timestamp,
+			DecimalPrice: fr.Price.DecimalPrice,
 		},
 		Origin: origin,
 		Error:  fr.Error,
@@ -16,6 +16,8 @@
 package feeder
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -59,6 +61,19 @@ func (m *mockHandler) Fetch(pairs []origins.Pair) []origins.FetchResult {
 	return fr
 }
 
+type mockStreamHandler struct {
+	mockHandler
+	// ch is a channel returned by the Subscribe method
+	ch chan origins.FetchResult
+	// subscribePairs is a list of pairs provided to Subscribe method
+	subscribePairs []origins.Pair
+}
+
+func (m *mockStreamHandler) Subscribe(_ context.Context, pairs []origins.Pair) (<-chan origins.FetchResult, error) {
+	m.subscribePairs = pairs
+	return m.ch, nil
+}
+
 func originsSetMock(prices map[string][]origins.Price) *origins.Set {
 	handlers := map[string]origins.Handler{}
 	for origin, prices := range prices {
@@ -75,7 +90,7 @@ func TestFeeder_Feed_EmptyGraph(t *testing.T) {
 	f := NewFeeder(originsSetMock(nil), null.New())
 
 	// Feed method shouldn't panic
-	warns := f.Feed(nil, time.Now())
+	warns := f.Feed(context.Background(), nil, time.Now())
 
 	assert.Len(t, warns.List, 0)
 }
@@ -85,7 +100,7 @@ func TestFeeder_Feed_NoFeedableNodes(t *testing.T) {
 	f := NewFeeder(originsSetMock(nil), null.New())
 
 	// Feed method shouldn't panic
-	warns := f.Feed([]nodes.Node{nodes.Node(g)}, time.Now())
+	warns := f.Feed(context.Background(), []nodes.Node{nodes.Node(g)}, time.Now())
 
 	assert.Len(t, warns.List, 0)
 }
@@ -112,7 +127,7 @@ func TestFeeder_Feed_OneOriginNode(t *testing.T) {
 
 	g.AddChild(o)
 	f := NewFeeder(s, null.New())
-	warns := f.Feed([]nodes.Node{g}, time.Now())
+	warns := f.Feed(context.Background(), []nodes.Node{g}, time.Now())
 
 	assert.Len(t, warns.List, 0)
 	assert.Equal(t, provider.Pair{Base: "A", Quote: "B"}, o.Price().Pair)
@@ -184,7 +199,7 @@ func TestFeeder_Feed_ManyOriginNodes(t *testing.T) {
 	g.AddChild(o4)
 
 	f := NewFeeder(s, null.New())
-	warns := f.Feed([]nodes.Node{g}, time.Now())
+	warns := f.Feed(context.Background(), []nodes.Node{g}, time.Now())
 
 	assert.Len(t, warns.List, 0)
 
@@ -249,7 +264,7 @@ func TestFeeder_Feed_NestedOriginNode(t *testing.T) {
 	i.AddChild(o)
 
 	f := NewFeeder(s, null.New())
-	warns := f.Feed([]nodes.Node{g}, time.Now())
+	warns := f.Feed(context.Background(), []nodes.Node{g}, time.Now())
 
 	assert.Len(t, warns.List, 0)
 	assert.Equal(t, provider.Pair{Base: "A", Quote: "B"}, o.Price().Pair)
@@ -296,7 +311,7 @@ func TestFeeder_Feed_BelowMinTTL(t *testing.T) {
 	g.AddChild(o)
 
 	f := NewFeeder(s, null.New())
-	warns := f.Feed([]nodes.Node{g}, time.Now())
+	warns := f.Feed(context.Background(), []nodes.Node{g}, time.Now())
 
 	// OriginNode shouldn't be updated because time diff is below MinTTL setting:
 	assert.Len(t, warns.List, 0)
@@ -343,7 +358,7 @@ func TestFeeder_Feed_BetweenTTLs(t *testing.T) {
 	g.AddChild(o)
 
 	f := NewFeeder(s, null.New())
-	warns := f.Feed([]nodes.Node{g}, time.Now())
+	warns := f.Feed(context.Background(), []nodes.Node{g}, time.Now())
 
 	// OriginNode should be updated because time diff is above MinTTL setting:
 	assert.Len(t, warns.List, 0)
@@ -353,3 +368,41 @@ func TestFeeder_Feed_BetweenTTLs(t *testing.T) {
 	assert.Equal(t, 12.0, o.Price().Ask)
 	assert.Equal(t, 11.0, o.Price().Volume24h)
 }
+
+func TestFeeder_Stream(t *testing.T) {
+	h := &mockStreamHandler{ch: make(chan origins.FetchResult)}
+	s := origins.NewSet(map[string]origins.Handler{"stream": h, "test": &mockHandler{}})
+
+	g := nodes.NewMedianAggregatorNode(provider.Pair{Base: "A", Quote: "B"}, 1)
+	o1 := nodes.NewOriginNode(nodes.OriginPair{
+		Origin: "stream",
+		Pair:   provider.Pair{Base: "A", Quote: "B"},
+	}, 0, time.Minute)
+	o2 := nodes.NewOriginNode(nodes.OriginPair{
+		Origin: "test",
+		Pair:   provider.Pair{Base: "A", Quote: "B"},
+	}, 0, time.Minute)
+	g.AddChild(o1)
+	g.AddChild(o2)
+
+	f := NewFeeder(s, null.New())
+	assert.True(t, f.IsStreaming("stream"))
+	assert.False(t, f.IsStreaming("test"))
+	assert.Error(t, f.Stream(context.Background(), "test", []nodes.Node{g}))
+
+	done := make(chan error)
+	go func() { done <- f.Stream(context.Background(), "stream", []nodes.Node{g}) }()
+
+	pair := origins.Pair{Base: "A", Quote: "B"}
+	h.ch <- origins.FetchResult{Price: origins.Price{Pair: pair, Price: 10, Timestamp: time.Now()}}
+	// An error must not override a price which is not expired yet:
+	h.ch <- origins.FetchResult{Price: origins.Price{Pair: pair, Timestamp: time.Now()}, Error: errors.New("disconnected")}
+	close(h.ch)
+	assert.NoError(t, <-done)
+
+	assert.Equal(t, []origins.Pair{pair}, h.subscribePairs)
+	assert.NoError(t, o1.Price().Error)
+	assert.Equal(t, 10.0, o1.Price().Price)
+	assert.Equal(t, "stream", o1.Price().Origin)
+	assert.Equal(t, 0.0, o2.Price().Price)
+}
@@ -0,0 +1,191 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package feeder
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/origins"
+)
+
+var ErrOriginDisabled = errors.New("origin is temporarily disabled")
+
+// healthEWMAAlpha is the smoothing factor for the error rate and latency.
+const healthEWMAAlpha = 0.2
+
+// CircuitBreakerConfig configures when origins are temporarily disabled.
+type CircuitBreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed fetches after
+	// which an origin is disabled. A fetch is failed if no price could be
+	// fetched. If zero, origins are never disabled.
+	FailureThreshold int
+	// MinBackoff is the time for which an origin is disabled after it
+	// reaches the FailureThreshold. Every next failure doubles that time,
+	// up to MaxBackoff.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// DefaultCircuitBreakerConfig is used by the NewFeeder function.
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	FailureThreshold: 3,
+	MinBackoff:       10 * time.Second,
+	MaxBackoff:       10 * time.Minute,
+}
+
+type originHealth struct {
+	provider.OriginHealth
+	backoff time.Duration
+}
+
+// healthTracker tracks the health of origins and decides whether an origin
+// should be temporarily disabled.
+type healthTracker struct {
+	mu      sync.Mutex
+	cfg     CircuitBreakerConfig
+	origins map[string]*originHealth
+	now     func() time.Time
+}
+
+func newHealthTracker(cfg CircuitBreakerConfig) *healthTracker {
+	if cfg.MinBackoff <= 0 {
+		cfg.MinBackoff = DefaultCircuitBreakerConfig.MinBackoff
+	}
+	if cfg.MaxBackoff < cfg.MinBackoff {
+		cfg.MaxBackoff = cfg.MinBackoff
+	}
+	return &healthTracker{
+		cfg:     cfg,
+		origins: map[string]*originHealth{},
+		now:     time.Now,
+	}
+}
+
+// disabledUntil returns the time until which the origin is disabled. If
+// the origin is enabled, the zero time is returned.
+func (h *healthTracker) disabledUntil(origin string) time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if o, ok := h.origins[origin]; ok && h.now().Before(o.DisabledUntil) {
+		return o.DisabledUntil
+	}
+	return time.Time{}
+}
+
+// record updates the origin health using results of a single fetch. The
+// latency is ignored if it is zero.
+func (h *healthTracker) record(origin string, latency time.Duration, frs []origins.FetchResult) {
+	if len(frs) == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	o, ok := h.origins[origin]
+	if !ok {
+		o = &originHealth{OriginHealth: provider.OriginHealth{Origin: origin}}
+		h.origins[origin] = o
+	}
+	now := h.now()
+
+	var failed int
+	var lastErr error
+	var lastPrice time.Time
+	for _, fr := range frs {
+		if fr.Error != nil {
+			failed++
+			lastErr = fr.Error
+		} else if fr.Price.Timestamp.After(lastPrice) {
+			lastPrice = fr.Price.Timestamp
+		}
+	}
+	rate := float64(failed) / float64(len(frs))
+	if o.Requests == 0 {
+		o.ErrorRate = rate
+	} else {
+		o.ErrorRate += healthEWMAAlpha * (rate - o.ErrorRate)
+	}
+	if latency > 0 {
+		if o.Latency == 0 {
+			o.Latency = latency
+		} else {
+			o.Latency += time.Duration(healthEWMAAlpha * float64(latency-o.Latency))
+		}
+	}
+	o.Requests++
+
+	if failed < len(frs) {
+		o.ConsecutiveFailures = 0
+		o.DisabledUntil = time.Time{}
+		o.backoff = 0
+		o.LastSuccess = now
+		if lastPrice.After(o.LastPriceTime) {
+			o.LastPriceTime = lastPrice
+		}
+		return
+	}
+	o.Errors++
+	o.ConsecutiveFailures++
+	o.LastError = lastErr.Error()
+	if h.cfg.FailureThreshold > 0 && o.ConsecutiveFailures >= h.cfg.FailureThreshold {
+		if o.backoff == 0 {
+			o.backoff = h.cfg.MinBackoff
+		} else {
+			o.backoff *= 2
+		}
+		if o.backoff > h.cfg.MaxBackoff {
+			o.backoff = h.cfg.MaxBackoff
+		}
+		o.DisabledUntil = now.Add(o.backoff)
+	}
+}
+
+// health returns the health of all origins for which at least one fetch
+// was recorded.
+func (h *healthTracker) health() map[string]*provider.OriginHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.now()
+	r := map[string]*provider.OriginHealth{}
+	for name, o := range h.origins {
+		oh := o.OriginHealth
+		oh.Score = healthScore(&oh, now)
+		r[name] = &oh
+	}
+	return r
+}
+
+// healthScore calculates the origin health score. The score is reduced
+// proportionally to the error rate, and to the latency and staleness if
+// they exceed one second and one minute respectively. Disabled origins
+// have a score of zero.
+func healthScore(h *provider.OriginHealth, now time.Time) float64 {
+	if now.Before(h.DisabledUntil) {
+		return 0
+	}
+	score := 1 - h.ErrorRate
+	if h.Latency > time.Second {
+		score *= float64(time.Second) / float64(h.Latency)
+	}
+	if !h.LastPriceTime.IsZero() {
+		if age := now.Sub(h.LastPriceTime); age > time.Minute {
+			score *= float64(time.Minute) / float64(age)
+		}
+	}
+	return score
+}
@@ -0,0 +1,144 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package feeder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/nodes"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/origins"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+type failingHandler struct {
+	calls int
+}
+
+func (h *failingHandler) Fetch(pairs []origins.Pair) []origins.FetchResult {
+	h.calls++
+	var frs []origins.FetchResult
+	for _, pair := range pairs {
+		frs = append(frs, origins.FetchResult{Price: origins.Price{Pair: pair}, Error: errors.New("failed")})
+	}
+	return frs
+}
+
+func TestHealthTracker_CircuitBreaker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	h := newHealthTracker(CircuitBreakerConfig{FailureThreshold: 2, MinBackoff: 10 * time.Second, MaxBackoff: 25 * time.Second})
+	h.now = func() time.Time { return now }
+
+	ok := []origins.FetchResult{{Price: origins.Price{Timestamp: now}}}
+	failed := []origins.FetchResult{{Error: errors.New("failed")}}
+
+	h.record("a", time.Second, failed)
+	assert.True(t, h.disabledUntil("a").IsZero())
+
+	// The second failure in a row disables the origin.
+	h.record("a", time.Second, failed)
+	assert.Equal(t, now.Add(10*time.Second), h.disabledUntil("a"))
+
+	// After the backoff time, the origin is enabled again, but the next
+	// failure disables it for twice as long, up to the max backoff.
+	now = now.Add(10 * time.Second)
+	assert.True(t, h.disabledUntil("a").IsZero())
+	h.record("a", time.Second, failed)
+	assert.Equal(t, now.Add(20*time.Second), h.disabledUntil("a"))
+	now = now.Add(20 * time.Second)
+	h.record("a", time.Second, failed)
+	assert.Equal(t, now.Add(25*time.Second), h.disabledUntil("a"))
+
+	// A success resets the circuit breaker.
+	now = now.Add(25 * time.Second)
+	h.record("a", time.Second, ok)
+	assert.True(t, h.disabledUntil("a").IsZero())
+	h.record("a", time.Second, failed)
+	assert.True(t, h.disabledUntil("a").IsZero())
+
+	health := h.health()["a"]
+	require.NotNil(t, health)
+	assert.Equal(t, 6, health.Requests)
+	assert.Equal(t, 5, health.Errors)
+	assert.Equal(t, 1, health.ConsecutiveFailures)
+	assert.Equal(t, "failed", health.LastError)
+	assert.Equal(t, now, health.LastSuccess)
+	assert.Equal(t, time.Second, health.Latency)
+	assert.Greater(t, health.ErrorRate, 0.0)
+	assert.Less(t, health.ErrorRate, 1.0)
+	assert.InDelta(t, 1-health.ErrorRate, health.Score, 1e-9)
+}
+
+func TestHealthTracker_Disabled(t *testing.T) {
+	h := newHealthTracker(CircuitBreakerConfig{})
+	for i := 0; i < 10; i++ {
+		h.record("a", 0, []origins.FetchResult{{Error: errors.New("failed")}})
+	}
+	assert.True(t, h.disabledUntil("a").IsZero())
+	assert.Equal(t, 1.0, h.health()["a"].ErrorRate)
+	assert.Equal(t, 0.0, h.health()["a"].Score)
+}
+
+func Test_healthScore(t *testing.T) {
+	now := time.Unix(10000, 0)
+	tests := []struct {
+		name   string
+		health provider.OriginHealth
+		want   float64
+	}{
+		{name: "healthy", health: provider.OriginHealth{Latency: time.Second, LastPriceTime: now}, want: 1},
+		{name: "errors", health: provider.OriginHealth{ErrorRate: 0.25}, want: 0.75},
+		{name: "slow", health: provider.OriginHealth{Latency: 4 * time.Second}, want: 0.25},
+		{name: "stale", health: provider.OriginHealth{LastPriceTime: now.Add(-2 * time.Minute)}, want: 0.5},
+		{name: "disabled", health: provider.OriginHealth{DisabledUntil: now.Add(time.Second)}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.InDelta(t, tt.want, healthScore(&tt.health, now), 1e-9)
+		})
+	}
+}
+
+func TestFeeder_Feed_DisablesFailingOrigin(t *testing.T) {
+	h := &failingHandler{}
+	f := NewFeederWithCircuitBreaker(
+		origins.NewSet(map[string]origins.Handler{"test": h}),
+		null.New(),
+		CircuitBreakerConfig{FailureThreshold: 2, MinBackoff: time.Minute, MaxBackoff: time.Minute},
+	)
+	o := nodes.NewOriginNode(nodes.OriginPair{
+		Origin: "test",
+		Pair:   provider.Pair{Base: "A", Quote: "B"},
+	}, 0, 0)
+
+	for i := 0; i < 4; i++ {
+		f.Feed(context.Background(), []nodes.Node{o}, time.Now())
+		assert.Error(t, o.Price().Error)
+	}
+
+	// After two failures, the origin is not called anymore.
+	assert.Equal(t, 2, h.calls)
+	assert.ErrorIs(t, o.Price().Error, ErrOriginDisabled)
+	assert.True(t, f.OriginsHealth()["test"].Disabled())
+	assert.Equal(t, 2, f.OriginsHealth()["test"].Requests)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 type ErrPrice struct {
@@ -201,7 +202,8 @@ func (n *IndirectAggregatorNode) Price() AggregatorPrice {
 }
 
 // crossRate returns a calculated price from the list of prices. Prices order
-// is important because prices are calculated from first to last.
+// is important because prices are calculated from first to last. The price
+// is calculated using exact numbers, bid and ask prices using floats.
 //
 // TODO: Decide what to do with division by zero during calculating Bid/Ask prices.
 //nolint:gocyclo,funlen
@@ -217,17 +219,18 @@ func crossRate(t []PairPrice) (PairPrice, error) {
 		b := t[i+1]
 
 		var pair provider.Pair
-		var price, bid, ask float64
+		var price decimal.Decimal
+		var bid, ask float64
+		ap, bp := a.decimalPrice(), b.decimalPrice()
 		switch {
 		case a.Pair.Quote == b.Pair.Quote: // A/C, B/C
 			pair.Base = a.Pair.Base
 			pair.Quote = b.Pair.Base
 
-			if b.Price > 0 {
-				price = a.Price / b.Price
+			if bp.Sign() > 0 {
+				price = ap.Quo(bp)
 			} else {
 				err = multierror.Append(err, ErrDivByZero{a.Pair, b.Pair})
-				price = 0
 			}
 
 			if b.Bid > 0 {
@@ -245,11 +248,10 @@ func crossRate(t []PairPrice) (PairPrice, error) {
 			pair.Base = a.Pair.Quote
 			pair.Quote = b.Pair.Quote
 
-			if a.Price > 0 {
-				price = b.Price / a.Price
+			if ap.Sign() > 0 {
+				price = bp.Quo(ap)
 			} else {
 				err = multierror.Append(err, ErrDivByZero{a.Pair, b.Pair})
-				price = 0
 			}
 
 			if a.Bid > 0 {
@@ -266,18 +268,17 @@ func crossRate(t []PairPrice) (PairPrice, error) {
 		case a.Pair.Quote == b.Pair.Base: // A/C, C/B
 			pair.Base = a.Pair.Base
 			pair.Quote = b.Pair.Quote
-			price = a.Price * b.Price
+			price = ap.Mul(bp)
 			bid = a.Bid * b.Bid
 			ask = a.Ask * b.Ask
 		case a.Pair.Base == b.Pair.Quote: // C/A, B/C -> A/B
 			pair.Base = a.Pair.Quote
 			pair.Quote = b.Pair.Base
 
-			if a.Price > 0 && b.Price > 0 {
-				price = (float64(1) / b.Price) / a.Price
+			if ap.Sign() > 0 && bp.Sign() > 0 {
+				price = ap.Mul(bp).Inv()
 			} else {
 				err = multierror.Append(err, ErrDivByZero{a.Pair, b.Pair})
-				price = 0
 			}
 
 			if a.Bid > 0 && b.Bid > 0 {
@@ -298,7 +299,8 @@ func crossRate(t []PairPrice) (PairPrice, error) {
 		}
 
 		b.Pair = pair
-		b.Price = price
+		b.Price = price.Float64()
+		b.DecimalPrice = price
 		b.Bid = bid
 		b.Ask = ask
 		b.Volume24h = 0
@@ -21,8 +21,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 const testTTL = 10 * time.Second
@@ -109,12 +111,13 @@ func TestIndirectAggregatorNode_Price_ThreeOriginPrices(t *testing.T) {
 
 	expected := AggregatorPrice{
 		PairPrice: PairPrice{
-			Pair:      pf,
-			Price:     6000,
-			Bid:       6000,
-			Ask:       6000,
-			Volume24h: 0,
-			Time:      n,
+			Pair:         pf,
+			Price:        6000,
+			Bid:          6000,
+			Ask:          6000,
+			Volume24h:    0,
+			Time:         n,
+			DecimalPrice: decimal.FromInt64(6000),
 		},
 		OriginPrices:     []OriginPrice{c1.Price(), c2.Price(), c3.Price()},
 		AggregatorPrices: nil,
@@ -192,12 +195,13 @@ func TestIndirectAggregatorNode_Price_ThreeAggregatorPrices(t *testing.T) {
 
 	expected := AggregatorPrice{
 		PairPrice: PairPrice{
-			Pair:      pf,
-			Price:     6000,
-			Bid:       6000,
-			Ask:       6000,
-			Volume24h: 0,
-			Time:      n,
+			Pair:         pf,
+			Price:        6000,
+			Bid:          6000,
+			Ask:          6000,
+			Volume24h:    0,
+			Time:         n,
+			DecimalPrice: decimal.FromInt64(6000),
 		},
 		OriginPrices: nil,
 		AggregatorPrices: []AggregatorPrice{
@@ -798,3 +802,28 @@ func Test_crossRate(t *testing.T) {
 		})
 	}
 }
+
+func Test_crossRate_DecimalPrice(t *testing.T) {
+	mustDecimal := func(s string) decimal.Decimal {
+		d, err := decimal.FromString(s)
+		require.NoError(t, err)
+		return d
+	}
+
+	// A/C = A/B * B/C, where B/C is given only as a float:
+	got, err := crossRate([]PairPrice{
+		{Pair: provider.Pair{Base: "A", Quote: "B"}, DecimalPrice: mustDecimal("1234567.123456789123456789")},
+		{Pair: provider.Pair{Base: "B", Quote: "C"}, Price: 0.1},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.DecimalPrice.Cmp(mustDecimal("123456.7123456789123456789")))
+
+	// C/A, C/B -> A/B; the result of the division is exact:
+	got, err = crossRate([]PairPrice{
+		{Pair: provider.Pair{Base: "C", Quote: "A"}, DecimalPrice: mustDecimal("3")},
+		{Pair: provider.Pair{Base: "C", Quote: "B"}, DecimalPrice: mustDecimal("1")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 0, got.DecimalPrice.Mul(mustDecimal("3")).Cmp(mustDecimal("1")))
+	assert.Equal(t, float64(1)/3, got.Price)
+}
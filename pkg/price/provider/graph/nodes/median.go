@@ -24,6 +24,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 type ErrNotEnoughSources struct {
@@ -64,9 +65,14 @@ func (e ErrIncompatiblePairs) Error() string {
 //                                                 -- ...
 //
 // All children of this node must return a Price for the same pair.
+//
+// If the outlier filter is enabled, prices that are recognized as outliers
+// are excluded before calculating the final median and are not counted as
+// successful sources.
 type MedianAggregatorNode struct {
 	pair       provider.Pair
 	minSources int
+	filter     OutlierFilter
 	children   []Node
 }
 
@@ -77,6 +83,21 @@ func NewMedianAggregatorNode(pair provider.Pair, minSources int) *MedianAggregat
 	}
 }
 
+// NewMedianAggregatorNodeWithOutlierFilter creates a MedianAggregatorNode
+// which excludes outliers using the given filter.
+func NewMedianAggregatorNodeWithOutlierFilter(
+	pair provider.Pair,
+	minSources int,
+	filter OutlierFilter,
+) *MedianAggregatorNode {
+
+	return &MedianAggregatorNode{
+		pair:       pair,
+		minSources: minSources,
+		filter:     filter,
+	}
+}
+
 // Children implements the Node interface.
 func (n *MedianAggregatorNode) Children() []Node {
 	return n.children
@@ -91,45 +112,25 @@ func (n *MedianAggregatorNode) Pair() provider.Pair {
 	return n.pair
 }
 
-func (n *MedianAggregatorNode) Price() AggregatorPrice {
-	var ts time.Time
-	var prices, bids, asks []float64
-	var originPrices []OriginPrice
-	var aggregatorPrices []AggregatorPrice
-	var err error
-
-	for i, c := range n.children {
-		// There is no need to copy errors from prices to the MedianAggregatorNode
-		// because there may be enough remaining prices to calculate median price.
+// OutlierFilter returns the outlier filter used by the node.
+func (n *MedianAggregatorNode) OutlierFilter() OutlierFilter {
+	return n.filter
+}
 
-		var price PairPrice
-		switch typedNode := c.(type) {
-		case Origin:
-			originPrice := typedNode.Price()
-			originPrices = append(originPrices, originPrice)
-			price = originPrice.PairPrice
-			if originPrice.Error != nil {
-				continue
-			}
-		case Aggregator:
-			aggregatorPrice := typedNode.Price()
-			aggregatorPrices = append(aggregatorPrices, aggregatorPrice)
-			price = aggregatorPrice.PairPrice
-			if aggregatorPrice.Error != nil {
-				continue
-			}
-		}
+func (n *MedianAggregatorNode) Price() AggregatorPrice {
+	var prices []decimal.Decimal
+	var bids, asks []float64
+	var outliers []Outlier
 
-		if !n.pair.Equal(price.Pair) {
-			err = multierror.Append(
-				err,
-				ErrIncompatiblePairs{Given: price.Pair, Expected: n.pair},
-			)
+	c := collectPrices(n.pair, n.children)
+	reasons := n.filter.outliers(c.prices)
+	for i, price := range c.prices {
+		if reasons[i] != "" {
+			outliers = append(outliers, Outlier{PairPrice: price, Index: c.sources[i], Reason: reasons[i]})
 			continue
 		}
-
-		if price.Price > 0 {
-			prices = append(prices, price.Price)
+		if p := price.decimalPrice(); p.Sign() > 0 {
+			prices = append(prices, p)
 		}
 		if price.Bid > 0 {
 			bids = append(bids, price.Bid)
@@ -137,11 +138,9 @@ func (n *MedianAggregatorNode) Price() AggregatorPrice {
 		if price.Ask > 0 {
 			asks = append(asks, price.Ask)
 		}
-		if i == 0 || price.Time.Before(ts) {
-			ts = price.Time
-		}
 	}
 
+	err := c.err
 	if len(prices) < n.minSources {
 		err = multierror.Append(
 			err,
@@ -149,22 +148,99 @@ func (n *MedianAggregatorNode) Price() AggregatorPrice {
 		)
 	}
 
+	params := map[string]string{"method": "median", "minimumSuccessfulSources": strconv.Itoa(n.minSources)}
+	n.filter.parameters(params)
+
+	price := medianDecimal(prices)
 	return AggregatorPrice{
 		PairPrice: PairPrice{
-			Pair:      n.pair,
-			Price:     median(prices),
-			Bid:       median(bids),
-			Ask:       median(asks),
-			Volume24h: 0,
-			Time:      ts,
+			Pair:         n.pair,
+			Price:        price.Float64(),
+			Bid:          median(bids),
+			Ask:          median(asks),
+			Volume24h:    0,
+			Time:         c.time,
+			DecimalPrice: price,
 		},
-		OriginPrices:     originPrices,
-		AggregatorPrices: aggregatorPrices,
-		Parameters:       map[string]string{"method": "median", "minimumSuccessfulSources": strconv.Itoa(n.minSources)},
+		OriginPrices:     c.originPrices,
+		AggregatorPrices: c.aggregatorPrices,
+		Outliers:         outliers,
+		Parameters:       params,
 		Error:            err,
 	}
 }
 
+// childPrices contains prices collected from children of an aggregator node.
+type childPrices struct {
+	// prices is a list of valid prices for the aggregator's pair.
+	prices []PairPrice
+	// indices contains indices of the children from which the prices
+	// were obtained.
+	indices []int
+	// sources contains indices of the prices in the list created by
+	// concatenating originPrices and aggregatorPrices.
+	sources          []int
+	originPrices     []OriginPrice
+	aggregatorPrices []AggregatorPrice
+	time             time.Time
+	err              error
+}
+
+// collectPrices gets prices from all children. Prices with errors are
+// skipped, but there is no need to copy their errors to the aggregator
+// because there may be enough remaining prices to calculate the price.
+func collectPrices(pair provider.Pair, children []Node) childPrices {
+	var c childPrices
+	var isOrigin []bool
+	for i, child := range children {
+		var price PairPrice
+		var source int
+		var origin bool
+		switch typedNode := child.(type) {
+		case Origin:
+			originPrice := typedNode.Price()
+			source = len(c.originPrices)
+			origin = true
+			c.originPrices = append(c.originPrices, originPrice)
+			price = originPrice.PairPrice
+			if originPrice.Error != nil {
+				continue
+			}
+		case Aggregator:
+			aggregatorPrice := typedNode.Price()
+			source = len(c.aggregatorPrices)
+			c.aggregatorPrices = append(c.aggregatorPrices, aggregatorPrice)
+			price = aggregatorPrice.PairPrice
+			if aggregatorPrice.Error != nil {
+				continue
+			}
+		}
+
+		if !pair.Equal(price.Pair) {
+			c.err = multierror.Append(
+				c.err,
+				ErrIncompatiblePairs{Given: price.Pair, Expected: pair},
+			)
+			continue
+		}
+
+		c.prices = append(c.prices, price)
+		c.indices = append(c.indices, i)
+		c.sources = append(c.sources, source)
+		isOrigin = append(isOrigin, origin)
+		if i == 0 || price.Time.Before(c.time) {
+			c.time = price.Time
+		}
+	}
+	// Aggregator prices are placed after origin prices:
+	for i := range c.sources {
+		if !isOrigin[i] {
+			c.sources[i] += len(c.originPrices)
+		}
+	}
+	return c
+}
+
 func median(xs []float64) float64 {
 	count := len(xs)
 	if count == 0 {
@@ -181,3 +257,21 @@ func median(xs []float64) float64 {
 
 	return xs[(count-1)/2]
 }
+
+// medianDecimal works like median but for exact numbers.
+func medianDecimal(xs []decimal.Decimal) decimal.Decimal {
+	count := len(xs)
+	if count == 0 {
+		return decimal.Decimal{}
+	}
+
+	sort.Slice(xs, func(i, j int) bool {
+		return xs[i].Cmp(xs[j]) < 0
+	})
+	if count%2 == 0 {
+		m := count / 2
+		return xs[m-1].Add(xs[m]).Quo(decimal.FromInt64(2))
+	}
+
+	return xs[(count-1)/2]
+}
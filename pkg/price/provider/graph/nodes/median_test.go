@@ -23,6 +23,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 const medianTestTTL = 10 * time.Second
@@ -105,12 +106,13 @@ func TestMedianAggregatorNode_Price_ThreeOriginPrices(t *testing.T) {
 
 	expected := AggregatorPrice{
 		PairPrice: PairPrice{
-			Pair:      p,
-			Price:     20,
-			Bid:       20,
-			Ask:       20,
-			Volume24h: 0,
-			Time:      n,
+			Pair:         p,
+			Price:        20,
+			Bid:          20,
+			Ask:          20,
+			Volume24h:    0,
+			Time:         n,
+			DecimalPrice: decimal.FromInt64(20),
 		},
 		OriginPrices:     []OriginPrice{c1.Price(), c2.Price(), c3.Price()},
 		AggregatorPrices: nil,
@@ -184,23 +186,25 @@ func TestMedianAggregatorNode_Price_ThreeAggregatorPrices(t *testing.T) {
 
 	expected := AggregatorPrice{
 		PairPrice: PairPrice{
-			Pair:      p,
-			Price:     20,
-			Bid:       20,
-			Ask:       20,
-			Volume24h: 0,
-			Time:      n,
+			Pair:         p,
+			Price:        20,
+			Bid:          20,
+			Ask:          20,
+			Volume24h:    0,
+			Time:         n,
+			DecimalPrice: decimal.FromInt64(20),
 		},
 		OriginPrices: nil,
 		AggregatorPrices: []AggregatorPrice{
 			{
 				PairPrice: PairPrice{
-					Pair:      p,
-					Price:     10,
-					Bid:       10,
-					Ask:       10,
-					Volume24h: 0,
-					Time:      n,
+					Pair:         p,
+					Price:        10,
+					Bid:          10,
+					Ask:          10,
+					Volume24h:    0,
+					Time:         n,
+					DecimalPrice: decimal.FromInt64(10),
 				},
 				OriginPrices:     []OriginPrice{c1.Price()},
 				AggregatorPrices: nil,
@@ -209,12 +213,13 @@ func TestMedianAggregatorNode_Price_ThreeAggregatorPrices(t *testing.T) {
 			},
 			{
 				PairPrice: PairPrice{
-					Pair:      p,
-					Price:     20,
-					Bid:       20,
-					Ask:       20,
-					Volume24h: 0,
-					Time:      n,
+					Pair:         p,
+					Price:        20,
+					Bid:          20,
+					Ask:          20,
+					Volume24h:    0,
+					Time:         n,
+					DecimalPrice: decimal.FromInt64(20),
 				},
 				OriginPrices:     []OriginPrice{c2.Price()},
 				AggregatorPrices: nil,
@@ -223,12 +228,13 @@ func TestMedianAggregatorNode_Price_ThreeAggregatorPrices(t *testing.T) {
 			},
 			{
 				PairPrice: PairPrice{
-					Pair:      p,
-					Price:     30,
-					Bid:       30,
-					Ask:       30,
-					Volume24h: 0,
-					Time:      n,
+					Pair:         p,
+					Price:        30,
+					Bid:          30,
+					Ask:          30,
+					Volume24h:    0,
+					Time:         n,
+					DecimalPrice: decimal.FromInt64(30),
 				},
 				OriginPrices:     []OriginPrice{c3.Price()},
 				AggregatorPrices: nil,
@@ -438,6 +444,42 @@ func TestMedianAggregatorNode_Price_FilterOutPricesLteZero(t *testing.T) {
 	assert.Equal(t, float64(10), price.Ask)
 }
 
+func TestMedianAggregatorNode_Price_Outliers(t *testing.T) {
+	p := provider.Pair{Base: "A", Quote: "B"}
+	n := time.Now()
+	m := NewMedianAggregatorNodeWithOutlierFilter(p, 4, OutlierFilter{MaxDeviation: 0.1})
+
+	c1 := newTestOriginNode(p, "a", 100, 0, n)
+	c2 := newTestOriginNode(p, "b", 102, 0, n)
+	c3 := newTestOriginNode(p, "c", 150, 0, n)
+	i1 := NewMedianAggregatorNode(p, 1)
+	i1.AddChild(newTestOriginNode(p, "d", 98, 0, n))
+
+	m.AddChild(c1)
+	m.AddChild(i1)
+	m.AddChild(c2)
+	m.AddChild(c3)
+
+	price := m.Price()
+
+	// Outlier is not counted as a successful source:
+	assert.ErrorAs(t, price.Error, &ErrNotEnoughSources{})
+	assert.Equal(t, float64(100), price.Price)
+	assert.Equal(t, float64(100), price.Bid)
+	assert.Equal(t, float64(100), price.Ask)
+	assert.Equal(t, map[string]string{
+		"method":                   "median",
+		"minimumSuccessfulSources": "4",
+		"maxDeviation":             "0.1",
+	}, price.Parameters)
+	if assert.Len(t, price.Outliers, 1) {
+		// Index points to the c3 price, origin prices are before aggregator prices:
+		assert.Equal(t, 2, price.Outliers[0].Index)
+		assert.Equal(t, float64(150), price.Outliers[0].Price)
+		assert.Equal(t, "deviation of 48.51% from the median exceeds 10.00%", price.Outliers[0].Reason)
+	}
+}
+
 func Test_median(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -473,3 +515,16 @@ func Test_median(t *testing.T) {
 		})
 	}
 }
+
+func Test_medianDecimal(t *testing.T) {
+	xs := []decimal.Decimal{
+		decimal.FromFloat64(0.2),
+		decimal.FromFloat64(0.1),
+		decimal.FromFloat64(0.3),
+	}
+	assert.Equal(t, "0.2", medianDecimal(xs).String())
+	// (0.1 + 0.2) / 2 is exactly 0.15, while for floats it would be
+	// 0.15000000000000002.
+	assert.Equal(t, "0.15", medianDecimal(xs[:2]).String())
+	assert.True(t, medianDecimal(nil).IsZero())
+}
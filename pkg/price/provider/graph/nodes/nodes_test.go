@@ -17,6 +17,7 @@ package nodes
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -63,3 +64,20 @@ func TestDetectCycles(t *testing.T) {
 	assert.Equal(t, []Node{cyclic, cyclicC3}, DetectCycle(cyclic))
 	assert.Equal(t, []Node{r, c2, cyclic, cyclicC3}, DetectCycle(r))
 }
+
+// newTestOriginNode returns an origin node with the given price ingested.
+func newTestOriginNode(p provider.Pair, origin string, price, volume float64, ts time.Time) *OriginNode {
+	n := NewOriginNode(OriginPair{Pair: p, Origin: origin}, time.Minute, time.Minute)
+	_ = n.Ingest(OriginPrice{
+		PairPrice: PairPrice{
+			Pair:      p,
+			Price:     price,
+			Bid:       price,
+			Ask:       price,
+			Volume24h: volume,
+			Time:      ts,
+		},
+		Origin: origin,
+	})
+	return n
+}
@@ -0,0 +1,104 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// madScale scales the median absolute deviation so that it is a consistent
+// estimator of the standard deviation for normally distributed data.
+const madScale = 0.6745
+
+// OutlierFilter describes how outliers are detected before calculating
+// the median price. Zero values disable the corresponding check.
+type OutlierFilter struct {
+	// MaxDeviation is the maximum relative deviation of a price from the
+	// provisional median, e.g. 0.05 for 5%.
+	MaxDeviation float64
+	// MaxZScore is the maximum modified z-score of a price, calculated using
+	// the median absolute deviation (MAD).
+	MaxZScore float64
+}
+
+// Enabled returns true if any of the checks is enabled.
+func (f OutlierFilter) Enabled() bool {
+	return f.MaxDeviation > 0 || f.MaxZScore > 0
+}
+
+// parameters returns the filter configuration as the aggregator parameters.
+func (f OutlierFilter) parameters(params map[string]string) {
+	if f.MaxDeviation > 0 {
+		params["maxDeviation"] = strconv.FormatFloat(f.MaxDeviation, 'f', -1, 64)
+	}
+	if f.MaxZScore > 0 {
+		params["maxZScore"] = strconv.FormatFloat(f.MaxZScore, 'f', -1, 64)
+	}
+}
+
+// outliers returns the reasons for which prices were recognized as outliers.
+// The reason is an empty string for prices which are not outliers. Prices
+// lower than or equal to zero are ignored.
+func (f OutlierFilter) outliers(prices []PairPrice) []string {
+	reasons := make([]string, len(prices))
+	if !f.Enabled() {
+		return reasons
+	}
+
+	var xs []float64
+	for _, p := range prices {
+		if p.Price > 0 {
+			xs = append(xs, p.Price)
+		}
+	}
+	if len(xs) == 0 {
+		return reasons
+	}
+
+	m := median(xs)
+	deviations := make([]float64, len(xs))
+	for i, x := range xs {
+		deviations[i] = math.Abs(x - m)
+	}
+	mad := median(deviations)
+
+	for i, p := range prices {
+		if p.Price <= 0 {
+			continue
+		}
+		d := math.Abs(p.Price - m)
+		if f.MaxDeviation > 0 {
+			if r := d / m; r > f.MaxDeviation {
+				reasons[i] = fmt.Sprintf(
+					"deviation of %.2f%% from the median exceeds %.2f%%",
+					r*100,
+					f.MaxDeviation*100,
+				)
+				continue
+			}
+		}
+		// If more than half of prices are equal, MAD is zero, and the z-score
+		// cannot be calculated.
+		if f.MaxZScore > 0 && mad != 0 {
+			if z := madScale * d / mad; z > f.MaxZScore {
+				reasons[i] = fmt.Sprintf("modified z-score of %.2f exceeds %.2f", z, f.MaxZScore)
+			}
+		}
+	}
+	return reasons
+}
@@ -0,0 +1,77 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutlierFilter_outliers(t *testing.T) {
+	prices := func(xs ...float64) []PairPrice {
+		var ps []PairPrice
+		for _, x := range xs {
+			ps = append(ps, PairPrice{Price: x})
+		}
+		return ps
+	}
+	tests := []struct {
+		name    string
+		filter  OutlierFilter
+		prices  []PairPrice
+		outlier []bool
+	}{
+		{
+			name:    "disabled",
+			filter:  OutlierFilter{},
+			prices:  prices(10, 10, 1000),
+			outlier: []bool{false, false, false},
+		},
+		{
+			name:    "max-deviation",
+			filter:  OutlierFilter{MaxDeviation: 0.1},
+			prices:  prices(100, 105, 115, 89),
+			outlier: []bool{false, false, true, true},
+		},
+		{
+			name:    "max-z-score",
+			filter:  OutlierFilter{MaxZScore: 3.5},
+			prices:  prices(100, 101, 99, 102, 98, 150),
+			outlier: []bool{false, false, false, false, false, true},
+		},
+		{
+			name:    "max-z-score-zero-mad",
+			filter:  OutlierFilter{MaxZScore: 3.5},
+			prices:  prices(100, 100, 100, 150),
+			outlier: []bool{false, false, false, false},
+		},
+		{
+			name:    "non-positive-prices-are-ignored",
+			filter:  OutlierFilter{MaxDeviation: 0.1},
+			prices:  prices(0, 100, 100),
+			outlier: []bool{false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := tt.filter.outliers(tt.prices)
+			for i, r := range reasons {
+				assert.Equal(t, tt.outlier[i], r != "", "price %d", i)
+			}
+		})
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 type OriginPair struct {
@@ -38,6 +39,19 @@ type PairPrice struct {
 	Ask       float64
 	Volume24h float64
 	Time      time.Time
+	// DecimalPrice is the exact price used to calculate other prices and
+	// the signed price. Price is its approximation kept for display. If
+	// DecimalPrice is zero, Price is used instead.
+	DecimalPrice decimal.Decimal
+}
+
+// decimalPrice returns the exact price, or the float price if the exact
+// one is not set.
+func (p PairPrice) decimalPrice() decimal.Decimal {
+	if p.DecimalPrice.IsZero() {
+		return decimal.FromFloat64(p.Price)
+	}
+	return p.DecimalPrice
 }
 
 // OriginPrice represent a price which was sourced directly from an origin.
@@ -49,6 +63,9 @@ type OriginPrice struct {
 	// calculating the price. If this string is not empty, then the price
 	// value is not reliable.
 	Error error
+	// TraceContext is the trace context of the span in which the price was
+	// fetched. It is used to link operations on the price to the fetch.
+	TraceContext map[string]string
 }
 
 // AggregatorPrice represent a price which was calculated by using other prices.
@@ -58,6 +75,8 @@ type AggregatorPrice struct {
 	OriginPrices []OriginPrice
 	// AggregatorPrices is a list of all OriginPrices used to calculate Price.
 	AggregatorPrices []AggregatorPrice
+	// Outliers is a list of prices that were excluded from the calculation.
+	Outliers []Outlier
 	// Parameters is a custom list of optional parameters returned by an aggregator.
 	Parameters map[string]string
 	// Errors is a list of optional error messages which may occur during
@@ -65,3 +84,14 @@ type AggregatorPrice struct {
 	// is not reliable.
 	Error error
 }
+
+// Outlier represents a price that was excluded by an aggregator from
+// the calculation of the price.
+type Outlier struct {
+	PairPrice
+	// Index is the index of the excluded price in the list created by
+	// concatenating the aggregator's OriginPrices and AggregatorPrices.
+	Index int
+	// Reason describes why the price was excluded.
+	Reason string
+}
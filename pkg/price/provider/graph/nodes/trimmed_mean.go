@@ -0,0 +1,166 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
+)
+
+// TrimmedMeanAggregatorNode gets Prices from all of its children, discards
+// the given fraction of the lowest and the highest prices and calculates
+// the mean of the remaining ones.
+//
+//                                 -- [Origin A/B]
+//                                /
+//  [TrimmedMeanAggregatorNode] ---- [Origin A/B]
+//                                \
+//                                 -- [AggregatorNode A/B]
+//
+// All children of this node must return a Price for the same pair.
+type TrimmedMeanAggregatorNode struct {
+	pair       provider.Pair
+	minSources int
+	trim       float64
+	children   []Node
+}
+
+// NewTrimmedMeanAggregatorNode creates a new TrimmedMeanAggregatorNode. The
+// trim argument is the fraction of prices discarded from each end and must be
+// in the [0, 0.5) range.
+func NewTrimmedMeanAggregatorNode(pair provider.Pair, minSources int, trim float64) *TrimmedMeanAggregatorNode {
+	return &TrimmedMeanAggregatorNode{
+		pair:       pair,
+		minSources: minSources,
+		trim:       trim,
+	}
+}
+
+// Children implements the Node interface.
+func (n *TrimmedMeanAggregatorNode) Children() []Node {
+	return n.children
+}
+
+// AddChild implements the Parent interface.
+func (n *TrimmedMeanAggregatorNode) AddChild(node Node) {
+	n.children = append(n.children, node)
+}
+
+func (n *TrimmedMeanAggregatorNode) Pair() provider.Pair {
+	return n.pair
+}
+
+// Trim returns the fraction of prices discarded from each end.
+func (n *TrimmedMeanAggregatorNode) Trim() float64 {
+	return n.trim
+}
+
+func (n *TrimmedMeanAggregatorNode) Price() AggregatorPrice {
+	var prices []decimal.Decimal
+	var bids, asks []float64
+
+	c := collectPrices(n.pair, n.children)
+	for _, price := range c.prices {
+		if p := price.decimalPrice(); p.Sign() > 0 {
+			prices = append(prices, p)
+		}
+		if price.Bid > 0 {
+			bids = append(bids, price.Bid)
+		}
+		if price.Ask > 0 {
+			asks = append(asks, price.Ask)
+		}
+	}
+
+	err := c.err
+	if len(prices) < n.minSources {
+		err = multierror.Append(
+			err,
+			ErrNotEnoughSources{Given: len(prices), Min: n.minSources},
+		)
+	}
+
+	price := trimmedMeanDecimal(prices, n.trim)
+	return AggregatorPrice{
+		PairPrice: PairPrice{
+			Pair:         n.pair,
+			Price:        price.Float64(),
+			Bid:          trimmedMean(bids, n.trim),
+			Ask:          trimmedMean(asks, n.trim),
+			Volume24h:    0,
+			Time:         c.time,
+			DecimalPrice: price,
+		},
+		OriginPrices:     c.originPrices,
+		AggregatorPrices: c.aggregatorPrices,
+		Parameters: map[string]string{
+			"method":                   "trimmedMean",
+			"minimumSuccessfulSources": strconv.Itoa(n.minSources),
+			"trim":                     strconv.FormatFloat(n.trim, 'f', -1, 64),
+		},
+		Error: err,
+	}
+}
+
+// trimmedMean discards floor(len(xs)*trim) values from each end of the
+// sorted list and returns the mean of the remaining values.
+func trimmedMean(xs []float64, trim float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+
+	sort.Float64s(xs)
+	k := trimCount(len(xs), trim)
+
+	var sum float64
+	for _, x := range xs[k : len(xs)-k] {
+		sum += x
+	}
+	return sum / float64(len(xs)-2*k)
+}
+
+// trimmedMeanDecimal works like trimmedMean but for exact numbers.
+func trimmedMeanDecimal(xs []decimal.Decimal, trim float64) decimal.Decimal {
+	if len(xs) == 0 {
+		return decimal.Decimal{}
+	}
+
+	sort.Slice(xs, func(i, j int) bool {
+		return xs[i].Cmp(xs[j]) < 0
+	})
+	k := trimCount(len(xs), trim)
+
+	var sum decimal.Decimal
+	for _, x := range xs[k : len(xs)-k] {
+		sum = sum.Add(x)
+	}
+	return sum.Quo(decimal.FromInt64(int64(len(xs) - 2*k)))
+}
+
+// trimCount returns the number of values discarded from each end of the
+// sorted list of n values. At least one value is always left.
+func trimCount(n int, trim float64) int {
+	k := int(float64(n) * trim)
+	if 2*k >= n {
+		k = (n - 1) / 2
+	}
+	return k
+}
@@ -0,0 +1,108 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+)
+
+func TestTrimmedMeanAggregatorNode_Price(t *testing.T) {
+	p := provider.Pair{Base: "A", Quote: "B"}
+	n := time.Now()
+	m := NewTrimmedMeanAggregatorNode(p, 4, 0.25)
+
+	m.AddChild(newTestOriginNode(p, "a", 1, 0, n))
+	m.AddChild(newTestOriginNode(p, "b", 20, 0, n))
+	m.AddChild(newTestOriginNode(p, "c", 30, 0, n))
+	m.AddChild(newTestOriginNode(p, "d", 1000, 0, n))
+
+	price := m.Price()
+	require.NoError(t, price.Error)
+	assert.Equal(t, p, m.Pair())
+	assert.Equal(t, 0.25, m.Trim())
+	assert.Len(t, m.Children(), 4)
+	assert.Equal(t, float64(25), price.Price)
+	assert.Equal(t, float64(25), price.Bid)
+	assert.Equal(t, float64(25), price.Ask)
+	assert.Equal(t, map[string]string{
+		"method":                   "trimmedMean",
+		"minimumSuccessfulSources": "4",
+		"trim":                     "0.25",
+	}, price.Parameters)
+}
+
+func TestTrimmedMeanAggregatorNode_Price_NotEnoughSources(t *testing.T) {
+	p := provider.Pair{Base: "A", Quote: "B"}
+	m := NewTrimmedMeanAggregatorNode(p, 2, 0.1)
+
+	m.AddChild(newTestOriginNode(p, "a", 10, 0, time.Now()))
+
+	price := m.Price()
+	assert.Equal(t, float64(10), price.Price)
+	assert.ErrorAs(t, price.Error, &ErrNotEnoughSources{})
+}
+
+func Test_trimmedMean(t *testing.T) {
+	tests := []struct {
+		name   string
+		prices []float64
+		trim   float64
+		want   float64
+	}{
+		{
+			name:   "no-prices",
+			prices: []float64{},
+			trim:   0.2,
+			want:   float64(0),
+		},
+		{
+			name:   "no-trim",
+			prices: []float64{10, 20, 60},
+			trim:   0,
+			want:   float64(30),
+		},
+		{
+			name:   "trim-one-from-each-end",
+			prices: []float64{100, 10, 20, 30, 0.1},
+			trim:   0.2,
+			want:   float64(20),
+		},
+		{
+			name:   "trim-rounds-down",
+			prices: []float64{10, 20, 60},
+			trim:   0.2,
+			want:   float64(30),
+		},
+		{
+			name:   "at-least-one-price-left",
+			prices: []float64{10, 20},
+			trim:   0.5,
+			want:   float64(15),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, trimmedMean(tt.prices, tt.trim))
+		})
+	}
+}
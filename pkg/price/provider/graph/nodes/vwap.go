@@ -0,0 +1,139 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"strconv"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
+)
+
+// VWAPAggregatorNode gets Prices from all of its children and calculates
+// the volume-weighted average price using the Volume24h of each price.
+// Prices without the volume are skipped.
+//
+//                          -- [Origin A/B]
+//                         /
+//  [VWAPAggregatorNode] ---- [Origin A/B]
+//                         \
+//                          -- [AggregatorNode A/B]
+//
+// All children of this node must return a Price for the same pair.
+type VWAPAggregatorNode struct {
+	pair       provider.Pair
+	minSources int
+	children   []Node
+}
+
+func NewVWAPAggregatorNode(pair provider.Pair, minSources int) *VWAPAggregatorNode {
+	return &VWAPAggregatorNode{
+		pair:       pair,
+		minSources: minSources,
+	}
+}
+
+// Children implements the Node interface.
+func (n *VWAPAggregatorNode) Children() []Node {
+	return n.children
+}
+
+// AddChild implements the Parent interface.
+func (n *VWAPAggregatorNode) AddChild(node Node) {
+	n.children = append(n.children, node)
+}
+
+func (n *VWAPAggregatorNode) Pair() provider.Pair {
+	return n.pair
+}
+
+func (n *VWAPAggregatorNode) Price() AggregatorPrice {
+	var prices, priceVolumes []decimal.Decimal
+	var bids, asks, bidVolumes, askVolumes []float64
+	var volume float64
+
+	c := collectPrices(n.pair, n.children)
+	for _, price := range c.prices {
+		if price.Volume24h <= 0 {
+			continue
+		}
+		if p := price.decimalPrice(); p.Sign() > 0 {
+			prices = append(prices, p)
+			priceVolumes = append(priceVolumes, decimal.FromFloat64(price.Volume24h))
+			volume += price.Volume24h
+		}
+		if price.Bid > 0 {
+			bids = append(bids, price.Bid)
+			bidVolumes = append(bidVolumes, price.Volume24h)
+		}
+		if price.Ask > 0 {
+			asks = append(asks, price.Ask)
+			askVolumes = append(askVolumes, price.Volume24h)
+		}
+	}
+
+	err := c.err
+	if len(prices) < n.minSources {
+		err = multierror.Append(
+			err,
+			ErrNotEnoughSources{Given: len(prices), Min: n.minSources},
+		)
+	}
+
+	price := weightedMeanDecimal(prices, priceVolumes)
+	return AggregatorPrice{
+		PairPrice: PairPrice{
+			Pair:         n.pair,
+			Price:        price.Float64(),
+			Bid:          weightedMean(bids, bidVolumes),
+			Ask:          weightedMean(asks, askVolumes),
+			Volume24h:    volume,
+			Time:         c.time,
+			DecimalPrice: price,
+		},
+		OriginPrices:     c.originPrices,
+		AggregatorPrices: c.aggregatorPrices,
+		Parameters:       map[string]string{"method": "vwap", "minimumSuccessfulSources": strconv.Itoa(n.minSources)},
+		Error:            err,
+	}
+}
+
+func weightedMean(xs, ws []float64) float64 {
+	var sum, total float64
+	for i, x := range xs {
+		sum += x * ws[i]
+		total += ws[i]
+	}
+	if total == 0 {
+		return 0
+	}
+	return sum / total
+}
+
+// weightedMeanDecimal works like weightedMean but for exact numbers.
+func weightedMeanDecimal(xs, ws []decimal.Decimal) decimal.Decimal {
+	var sum, total decimal.Decimal
+	for i, x := range xs {
+		sum = sum.Add(x.Mul(ws[i]))
+		total = total.Add(ws[i])
+	}
+	if total.IsZero() {
+		return decimal.Decimal{}
+	}
+	return sum.Quo(total)
+}
@@ -0,0 +1,73 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+)
+
+func TestVWAPAggregatorNode_Price(t *testing.T) {
+	p := provider.Pair{Base: "A", Quote: "B"}
+	n := time.Now()
+	m := NewVWAPAggregatorNode(p, 2)
+
+	c1 := newTestOriginNode(p, "a", 10, 30, n)
+	c2 := newTestOriginNode(p, "b", 20, 10, n)
+	// Price without volume is skipped:
+	c3 := newTestOriginNode(p, "c", 1000, 0, n)
+
+	m.AddChild(c1)
+	m.AddChild(c2)
+	m.AddChild(c3)
+
+	price := m.Price()
+	require.NoError(t, price.Error)
+	assert.Equal(t, p, m.Pair())
+	assert.Len(t, m.Children(), 3)
+	assert.Equal(t, 12.5, price.Price)
+	assert.Equal(t, 12.5, price.Bid)
+	assert.Equal(t, 12.5, price.Ask)
+	assert.Equal(t, float64(40), price.Volume24h)
+	assert.Equal(t, []OriginPrice{c1.Price(), c2.Price(), c3.Price()}, price.OriginPrices)
+	assert.Equal(t, map[string]string{"method": "vwap", "minimumSuccessfulSources": "2"}, price.Parameters)
+}
+
+func TestVWAPAggregatorNode_Price_NotEnoughSources(t *testing.T) {
+	p := provider.Pair{Base: "A", Quote: "B"}
+	m := NewVWAPAggregatorNode(p, 2)
+
+	m.AddChild(newTestOriginNode(p, "a", 10, 30, time.Now()))
+	m.AddChild(newTestOriginNode(p, "b", 20, 0, time.Now()))
+
+	price := m.Price()
+	assert.Equal(t, float64(10), price.Price)
+	assert.ErrorAs(t, price.Error, &ErrNotEnoughSources{})
+}
+
+func TestVWAPAggregatorNode_Price_NoChildrenNodes(t *testing.T) {
+	p := provider.Pair{Base: "A", Quote: "B"}
+	m := NewVWAPAggregatorNode(p, 0)
+
+	price := m.Price()
+	assert.NoError(t, price.Error)
+	assert.Equal(t, float64(0), price.Price)
+}
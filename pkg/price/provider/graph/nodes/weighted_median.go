@@ -0,0 +1,198 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-multierror"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
+)
+
+// WeightedMedianAggregatorNode gets Prices from all of its children and
+// calculates the weighted median price. Each child has its own weight, which
+// is 1 by default.
+//
+//                                   -- [Origin A/B] (weight: 2)
+//                                  /
+//  [WeightedMedianAggregatorNode] ---- [Origin A/B] (weight: 1)
+//                                  \
+//                                   -- [AggregatorNode A/B] (weight: 1)
+//
+// All children of this node must return a Price for the same pair.
+type WeightedMedianAggregatorNode struct {
+	pair       provider.Pair
+	minSources int
+	children   []Node
+	weights    []float64
+}
+
+func NewWeightedMedianAggregatorNode(pair provider.Pair, minSources int) *WeightedMedianAggregatorNode {
+	return &WeightedMedianAggregatorNode{
+		pair:       pair,
+		minSources: minSources,
+	}
+}
+
+// Children implements the Node interface.
+func (n *WeightedMedianAggregatorNode) Children() []Node {
+	return n.children
+}
+
+// AddChild implements the Parent interface. The child is added with
+// the weight of 1.
+func (n *WeightedMedianAggregatorNode) AddChild(node Node) {
+	n.AddChildWithWeight(node, 1)
+}
+
+// AddChildWithWeight adds a child with the given weight.
+func (n *WeightedMedianAggregatorNode) AddChildWithWeight(node Node, weight float64) {
+	n.children = append(n.children, node)
+	n.weights = append(n.weights, weight)
+}
+
+// Weights returns weights of the children in the same order as the Children
+// method returns them.
+func (n *WeightedMedianAggregatorNode) Weights() []float64 {
+	return n.weights
+}
+
+func (n *WeightedMedianAggregatorNode) Pair() provider.Pair {
+	return n.pair
+}
+
+func (n *WeightedMedianAggregatorNode) Price() AggregatorPrice {
+	var prices []decimal.Decimal
+	var bids, asks, priceWeights, bidWeights, askWeights []float64
+
+	c := collectPrices(n.pair, n.children)
+	for i, price := range c.prices {
+		w := n.weights[c.indices[i]]
+		if w <= 0 {
+			continue
+		}
+		if p := price.decimalPrice(); p.Sign() > 0 {
+			prices = append(prices, p)
+			priceWeights = append(priceWeights, w)
+		}
+		if price.Bid > 0 {
+			bids = append(bids, price.Bid)
+			bidWeights = append(bidWeights, w)
+		}
+		if price.Ask > 0 {
+			asks = append(asks, price.Ask)
+			askWeights = append(askWeights, w)
+		}
+	}
+
+	err := c.err
+	if len(prices) < n.minSources {
+		err = multierror.Append(
+			err,
+			ErrNotEnoughSources{Given: len(prices), Min: n.minSources},
+		)
+	}
+
+	price := weightedMedianDecimal(prices, priceWeights)
+	return AggregatorPrice{
+		PairPrice: PairPrice{
+			Pair:         n.pair,
+			Price:        price.Float64(),
+			Bid:          weightedMedian(bids, bidWeights),
+			Ask:          weightedMedian(asks, askWeights),
+			Volume24h:    0,
+			Time:         c.time,
+			DecimalPrice: price,
+		},
+		OriginPrices:     c.originPrices,
+		AggregatorPrices: c.aggregatorPrices,
+		Parameters: map[string]string{
+			"method":                   "weightedMedian",
+			"minimumSuccessfulSources": strconv.Itoa(n.minSources),
+			"weights":                  formatWeights(n.weights),
+		},
+		Error: err,
+	}
+}
+
+// weightedMedian returns the value for which the sum of weights of smaller
+// values and the sum of weights of larger values are both at most the half
+// of the total weight. If there are two such values, their mean is returned,
+// so for equal weights the result is the same as for the median function.
+func weightedMedian(xs, ws []float64) float64 {
+	if len(xs) == 0 {
+		return 0
+	}
+	a, b := weightedMedianIndices(len(xs), ws, func(i, j int) bool {
+		return xs[i] < xs[j]
+	})
+	return (xs[a] + xs[b]) / 2
+}
+
+// weightedMedianDecimal works like weightedMedian but for exact numbers.
+func weightedMedianDecimal(xs []decimal.Decimal, ws []float64) decimal.Decimal {
+	if len(xs) == 0 {
+		return decimal.Decimal{}
+	}
+	a, b := weightedMedianIndices(len(xs), ws, func(i, j int) bool {
+		return xs[i].Cmp(xs[j]) < 0
+	})
+	if a == b {
+		return xs[a]
+	}
+	return xs[a].Add(xs[b]).Quo(decimal.FromInt64(2))
+}
+
+// weightedMedianIndices returns indices of the values whose mean is the
+// weighted median of n values, ordered by the less function. If there is
+// a single such value, both indices are the same.
+func weightedMedianIndices(n int, ws []float64, less func(i, j int) bool) (int, int) {
+	idx := make([]int, n)
+	total := 0.0
+	for i := range idx {
+		idx[i] = i
+		total += ws[i]
+	}
+	sort.SliceStable(idx, func(i, j int) bool {
+		return less(idx[i], idx[j])
+	})
+
+	half := total / 2
+	cumulative := 0.0
+	for i, k := range idx {
+		cumulative += ws[k]
+		if cumulative == half && i < len(idx)-1 {
+			return k, idx[i+1]
+		}
+		if cumulative > half {
+			return k, k
+		}
+	}
+
+	return idx[n-1], idx[n-1]
+}
+
+func formatWeights(ws []float64) string {
+	s := make([]string, len(ws))
+	for i, w := range ws {
+		s[i] = strconv.FormatFloat(w, 'f', -1, 64)
+	}
+	return strings.Join(s, ",")
+}
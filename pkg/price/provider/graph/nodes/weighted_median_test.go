@@ -0,0 +1,127 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package nodes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+)
+
+func TestWeightedMedianAggregatorNode_Children(t *testing.T) {
+	p := provider.Pair{Base: "A", Quote: "B"}
+	m := NewWeightedMedianAggregatorNode(p, 2)
+
+	c1 := NewOriginNode(OriginPair{Pair: p, Origin: "a"}, 0, 0)
+	c2 := NewOriginNode(OriginPair{Pair: p, Origin: "b"}, 0, 0)
+
+	m.AddChild(c1)
+	m.AddChildWithWeight(c2, 3)
+
+	assert.Equal(t, p, m.Pair())
+	assert.Equal(t, []Node{c1, c2}, m.Children())
+	assert.Equal(t, []float64{1, 3}, m.Weights())
+}
+
+func TestWeightedMedianAggregatorNode_Price(t *testing.T) {
+	p := provider.Pair{Base: "A", Quote: "B"}
+	n := time.Now()
+	m := NewWeightedMedianAggregatorNode(p, 3)
+
+	c1 := newTestOriginNode(p, "a", 10, 0, n)
+	c2 := newTestOriginNode(p, "b", 20, 0, n)
+	c3 := newTestOriginNode(p, "c", 30, 0, n.Add(-time.Second))
+
+	m.AddChildWithWeight(c1, 1)
+	m.AddChildWithWeight(c2, 1)
+	m.AddChildWithWeight(c3, 3)
+
+	price := m.Price()
+	require.NoError(t, price.Error)
+	assert.Equal(t, float64(30), price.Price)
+	assert.Equal(t, float64(30), price.Bid)
+	assert.Equal(t, float64(30), price.Ask)
+	assert.Equal(t, n.Add(-time.Second), price.Time)
+	assert.Equal(t, []OriginPrice{c1.Price(), c2.Price(), c3.Price()}, price.OriginPrices)
+	assert.Equal(t, map[string]string{
+		"method":                   "weightedMedian",
+		"minimumSuccessfulSources": "3",
+		"weights":                  "1,1,3",
+	}, price.Parameters)
+}
+
+func TestWeightedMedianAggregatorNode_Price_NotEnoughSources(t *testing.T) {
+	p := provider.Pair{Base: "A", Quote: "B"}
+	m := NewWeightedMedianAggregatorNode(p, 2)
+
+	m.AddChildWithWeight(newTestOriginNode(p, "a", 10, 0, time.Now()), 1)
+	// Sources with zero weight are not used:
+	m.AddChildWithWeight(newTestOriginNode(p, "b", 20, 0, time.Now()), 0)
+
+	price := m.Price()
+	assert.Equal(t, float64(10), price.Price)
+	assert.ErrorAs(t, price.Error, &ErrNotEnoughSources{})
+}
+
+func Test_weightedMedian(t *testing.T) {
+	tests := []struct {
+		name    string
+		prices  []float64
+		weights []float64
+		want    float64
+	}{
+		{
+			name:    "no-prices",
+			prices:  []float64{},
+			weights: []float64{},
+			want:    float64(0),
+		},
+		{
+			name:    "equal-weights-odd",
+			prices:  []float64{30, 10, 20},
+			weights: []float64{1, 1, 1},
+			want:    float64(20),
+		},
+		{
+			name:    "equal-weights-even",
+			prices:  []float64{40, 30, 20, 10},
+			weights: []float64{1, 1, 1, 1},
+			want:    float64(25),
+		},
+		{
+			name:    "heavy-lowest",
+			prices:  []float64{10, 20, 30},
+			weights: []float64{5, 1, 1},
+			want:    float64(10),
+		},
+		{
+			name:    "heavy-middle",
+			prices:  []float64{10, 20, 30, 40},
+			weights: []float64{1, 2, 1, 1},
+			want:    float64(20),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, weightedMedian(tt.prices, tt.weights))
+		})
+	}
+}
@@ -16,8 +16,10 @@
 package graph
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -70,7 +72,7 @@ func (g *Provider) Price(pair provider.Pair) (*provider.Price, error) {
 		return nil, ErrPairNotFound{Pair: pair}
 	}
 	if g.feeder != nil {
-		g.feeder.Feed([]nodes.Node{n}, time.Now())
+		g.feeder.Feed(context.Background(), []nodes.Node{n}, time.Now())
 	}
 	return mapGraphPrice(n.Price()), nil
 }
@@ -82,7 +84,7 @@ func (g *Provider) Prices(pairs ...provider.Pair) (map[provider.Pair]*provider.P
 		return nil, err
 	}
 	if g.feeder != nil {
-		g.feeder.Feed(ns, time.Now())
+		g.feeder.Feed(context.Background(), ns, time.Now())
 	}
 	res := make(map[provider.Pair]*provider.Price)
 	for _, n := range ns {
@@ -102,6 +104,14 @@ func (g *Provider) Pairs() ([]provider.Pair, error) {
 	return ps, nil
 }
 
+// OriginsHealth implements the provider.OriginsHealthProvider interface.
+func (g *Provider) OriginsHealth() (map[string]*provider.OriginHealth, error) {
+	if g.feeder == nil {
+		return map[string]*provider.OriginHealth{}, nil
+	}
+	return g.feeder.OriginsHealth(), nil
+}
+
 // findNodes return root nodes for given pairs. If no nodes are specified,
 // then all root nodes are returned.
 func (g *Provider) findNodes(pairs ...provider.Pair) ([]nodes.Node, error) {
@@ -135,6 +145,27 @@ func mapGraphNodes(n nodes.Node) *provider.Model {
 	case *nodes.MedianAggregatorNode:
 		gn.Type = "median"
 		gn.Pair = typedNode.Pair()
+		if f := typedNode.OutlierFilter(); f.MaxDeviation > 0 {
+			gn.Parameters["maxDeviation"] = strconv.FormatFloat(f.MaxDeviation, 'f', -1, 64)
+		}
+		if f := typedNode.OutlierFilter(); f.MaxZScore > 0 {
+			gn.Parameters["maxZScore"] = strconv.FormatFloat(f.MaxZScore, 'f', -1, 64)
+		}
+	case *nodes.WeightedMedianAggregatorNode:
+		gn.Type = "weightedMedian"
+		gn.Pair = typedNode.Pair()
+		var weights []string
+		for _, w := range typedNode.Weights() {
+			weights = append(weights, strconv.FormatFloat(w, 'f', -1, 64))
+		}
+		gn.Parameters["weights"] = strings.Join(weights, ",")
+	case *nodes.VWAPAggregatorNode:
+		gn.Type = "vwap"
+		gn.Pair = typedNode.Pair()
+	case *nodes.TrimmedMeanAggregatorNode:
+		gn.Type = "trimmedMean"
+		gn.Pair = typedNode.Pair()
+		gn.Parameters["trim"] = strconv.FormatFloat(typedNode.Trim(), 'f', -1, 64)
 	case *nodes.OriginNode:
 		gn.Type = "origin"
 		gn.Pair = typedNode.OriginPair().Pair
@@ -164,6 +195,7 @@ func mapGraphPrice(t interface{}) *provider.Price {
 		gt.Ask = typedPrice.Ask
 		gt.Volume24h = typedPrice.Volume24h
 		gt.Time = typedPrice.Time
+		gt.DecimalPrice = typedPrice.DecimalPrice
 		if typedPrice.Error != nil {
 			gt.Error = typedPrice.Error.Error()
 		}
@@ -174,6 +206,20 @@ func mapGraphPrice(t interface{}) *provider.Price {
 		for _, ct := range typedPrice.AggregatorPrices {
 			gt.Prices = append(gt.Prices, mapGraphPrice(ct))
 		}
+		// Mark prices excluded by the aggregator, so it is possible to
+		// see why they were not used:
+		for _, o := range typedPrice.Outliers {
+			if o.Index < 0 || o.Index >= len(gt.Prices) {
+				continue
+			}
+			ct := gt.Prices[o.Index]
+			params := make(map[string]string, len(ct.Parameters)+1)
+			for k, v := range ct.Parameters {
+				params[k] = v
+			}
+			params["outlier"] = o.Reason
+			ct.Parameters = params
+		}
 	case nodes.OriginPrice:
 		gt.Type = "origin"
 		gt.Pair = typedPrice.Pair
@@ -182,10 +228,12 @@ func mapGraphPrice(t interface{}) *provider.Price {
 		gt.Ask = typedPrice.Ask
 		gt.Volume24h = typedPrice.Volume24h
 		gt.Time = typedPrice.Time
+		gt.DecimalPrice = typedPrice.DecimalPrice
 		if typedPrice.Error != nil {
 			gt.Error = typedPrice.Error.Error()
 		}
 		gt.Parameters["origin"] = typedPrice.Origin
+		gt.TraceContext = typedPrice.TraceContext
 	default:
 		panic("unsupported object")
 	}
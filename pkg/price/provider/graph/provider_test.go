@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/feeder"
@@ -28,6 +29,7 @@ import (
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/origins"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 var (
@@ -98,12 +100,13 @@ var (
 				"method":                   "median",
 				"minimumSuccessfulSources": "0",
 			},
-			Pair:      provider.Pair{Base: "A", Quote: "B"},
-			Price:     10,
-			Bid:       9,
-			Ask:       11,
-			Volume24h: 0,
-			Time:      testTime,
+			Pair:         provider.Pair{Base: "A", Quote: "B"},
+			Price:        10,
+			Bid:          9,
+			Ask:          11,
+			Volume24h:    0,
+			Time:         testTime,
+			DecimalPrice: decimal.FromInt64(10),
 			Prices: []*provider.Price{
 				{
 					Type: "origin",
@@ -123,12 +126,13 @@ var (
 						"method":                   "median",
 						"minimumSuccessfulSources": "0",
 					},
-					Pair:      provider.Pair{Base: "A", Quote: "B"},
-					Price:     10,
-					Bid:       9,
-					Ask:       11,
-					Volume24h: 0,
-					Time:      testTime,
+					Pair:         provider.Pair{Base: "A", Quote: "B"},
+					Price:        10,
+					Bid:          9,
+					Ask:          11,
+					Volume24h:    0,
+					Time:         testTime,
+					DecimalPrice: decimal.FromInt64(10),
 					Prices: []*provider.Price{
 						{
 							Type: "origin",
@@ -164,12 +168,13 @@ var (
 				"method":                   "median",
 				"minimumSuccessfulSources": "0",
 			},
-			Pair:      provider.Pair{Base: "X", Quote: "Y"},
-			Price:     10,
-			Bid:       9,
-			Ask:       11,
-			Volume24h: 0,
-			Time:      testTime,
+			Pair:         provider.Pair{Base: "X", Quote: "Y"},
+			Price:        10,
+			Bid:          9,
+			Ask:          11,
+			Volume24h:    0,
+			Time:         testTime,
+			DecimalPrice: decimal.FromInt64(10),
 			Prices: []*provider.Price{
 				{
 					Type: "origin",
@@ -323,3 +328,37 @@ func TestGofer_Prices_MissingPair(t *testing.T) {
 
 	assert.True(t, errors.As(err, &ErrPairNotFound{}))
 }
+
+func TestGofer_Models_AggregatorTypes(t *testing.T) {
+	ab := testPairs["A/B"]
+	c1 := nodes.NewOriginNode(nodes.OriginPair{Origin: "a", Pair: ab}, 0, 0)
+	c2 := nodes.NewOriginNode(nodes.OriginPair{Origin: "b", Pair: ab}, 0, 0)
+
+	weighted := nodes.NewWeightedMedianAggregatorNode(ab, 0)
+	weighted.AddChildWithWeight(c1, 2)
+	weighted.AddChildWithWeight(c2, 0.5)
+	vwap := nodes.NewVWAPAggregatorNode(ab, 0)
+	vwap.AddChild(c1)
+	trimmed := nodes.NewTrimmedMeanAggregatorNode(ab, 0, 0.1)
+	trimmed.AddChild(c1)
+
+	tests := []struct {
+		node       nodes.Aggregator
+		typ        string
+		parameters map[string]string
+	}{
+		{node: weighted, typ: "weightedMedian", parameters: map[string]string{"weights": "2,0.5"}},
+		{node: vwap, typ: "vwap", parameters: map[string]string{}},
+		{node: trimmed, typ: "trimmedMean", parameters: map[string]string{"trim": "0.1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			g := NewProvider(map[provider.Pair]nodes.Aggregator{ab: tt.node}, testFeeder)
+			r, err := g.Models(ab)
+			require.NoError(t, err)
+			assert.Equal(t, tt.typ, r[ab].Type)
+			assert.Equal(t, tt.parameters, r[ab].Parameters)
+			assert.Len(t, r[ab].Models, len(tt.node.Children()))
+		})
+	}
+}
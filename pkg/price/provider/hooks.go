@@ -18,19 +18,33 @@ package provider
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/hooks"
 )
 
+// PostPriceHook applies hooks configured for price models to calculated
+// prices. If a hook rejects a price, the Error field of the price is set.
 type PostPriceHook struct {
 	cli      ethereum.Client
 	ctx      context.Context
-	handlers map[string]interface{}
+	handlers map[string][]namedHook
+}
+
+type namedHook struct {
+	name string
+	hook hooks.Hook
 }
 
 const RocketPoolPair = "RETH/ETH"
 
+// legacyCircuitContractKey is the parameter used by the RocketPool circuit
+// breaker before hooks were configured by name.
+const legacyCircuitContractKey = "circuitContract"
+
+// HookParams contains hooks configuration indexed by a pair name. Every
+// pair configuration is a map of hook parameters indexed by a hook name.
 type HookParams map[string]map[string]interface{}
 
 func NewHookParams() HookParams {
@@ -38,16 +52,26 @@ func NewHookParams() HookParams {
 }
 
 func NewPostPriceHook(ctx context.Context, cli ethereum.Client, params HookParams) (*PostPriceHook, error) {
-	handlers := make(map[string]interface{})
-	for k, v := range params {
-		switch k {
-		case RocketPoolPair:
-			h, err := hooks.NewRocketPoolCircuitBreaker(v)
+	handlers := make(map[string][]namedHook)
+	for pair, pairParams := range params {
+		if _, ok := pairParams[legacyCircuitContractKey]; ok {
+			pairParams = map[string]interface{}{hooks.RocketPoolCircuitBreakerHook: pairParams}
+		}
+		var names []string
+		for name := range pairParams {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			hookParams, err := hookParamsMap(pairParams[name])
+			if err != nil {
+				return nil, fmt.Errorf("invalid parameters of %s post price hook for %s: %w", name, pair, err)
+			}
+			h, err := hooks.New(name, cli, hookParams)
 			if err != nil {
-				return nil, err
+				return nil, fmt.Errorf("unable to create %s post price hook for %s: %w", name, pair, err)
 			}
-			handlers[k] = h
-		default:
+			handlers[pair] = append(handlers[pair], namedHook{name: name, hook: h})
 		}
 	}
 
@@ -58,33 +82,93 @@ func NewPostPriceHook(ctx context.Context, cli ethereum.Client, params HookParam
 	}, nil
 }
 
+// Check runs hooks configured for given prices. Prices rejected by a hook
+// have the Error field set. Prices that already have an error are skipped.
 func (o *PostPriceHook) Check(prices map[Pair]*Price) error {
 	for pair, price := range prices {
-		switch pair.String() {
-		case RocketPoolPair:
-			if _, ok := o.handlers[RocketPoolPair]; !ok {
-				return fmt.Errorf("no post price hook handler found for %s", RocketPoolPair)
+		if price == nil || price.Error != "" {
+			continue
+		}
+		hs, ok := o.handlers[pair.String()]
+		if !ok {
+			continue
+		}
+		p := hookPrice(pair, price)
+		for _, h := range hs {
+			if err := h.hook.Check(o.ctx, p); err != nil {
+				price.Error = fmt.Sprintf("%s post price hook: %s", h.name, err)
+				break
 			}
+		}
+	}
+	return nil
+}
 
-			var refPrice float64
-			for _, origin := range price.Prices {
-				if origin.Parameters["origin"] == "rocketpool" {
-					refPrice = origin.Price
-					break
-				}
+// Record passes given prices to hooks that implement the hooks.Recorder
+// interface. It must be called only for prices that were actually used,
+// e.g. broadcast, after passing the Check method.
+func (o *PostPriceHook) Record(prices map[Pair]*Price) {
+	for pair, price := range prices {
+		if price == nil || price.Error != "" {
+			continue
+		}
+		for _, h := range o.handlers[pair.String()] {
+			if r, ok := h.hook.(hooks.Recorder); ok {
+				r.Record(hookPrice(pair, price))
 			}
-			if refPrice == 0 {
-				prices[pair].Error = fmt.Sprintf("post price hook failed for %s, reference price should be > 0", pair.String())
-				return nil
+		}
+	}
+}
+
+func hookPrice(pair Pair, price *Price) hooks.Price {
+	return hooks.Price{
+		Pair:         pair.String(),
+		Price:        price.Price,
+		Time:         price.Time,
+		OriginPrices: originPrices(price),
+	}
+}
+
+// originPrices returns prices fetched from origins that were used to
+// calculate the given price, indexed by an origin name. If there are more
+// prices from the same origin, the first one is used.
+func originPrices(price *Price) map[string]float64 {
+	m := make(map[string]float64)
+	var walk func(p *Price)
+	walk = func(p *Price) {
+		if p == nil {
+			return
+		}
+		if origin, ok := p.Parameters["origin"]; ok && p.Error == "" {
+			if _, ok := m[origin]; !ok {
+				m[origin] = p.Price
 			}
+		}
+		for _, c := range p.Prices {
+			walk(c)
+		}
+	}
+	walk(price)
+	return m
+}
 
-			err := o.handlers[RocketPoolPair].(*hooks.RocketPoolCircuitBreaker).Check(o.ctx, o.cli, price.Price, refPrice)
-			if err != nil {
-				prices[pair].Error = err.Error()
+func hookParamsMap(v interface{}) (map[string]interface{}, error) {
+	switch p := v.(type) {
+	case nil:
+		return map[string]interface{}{}, nil
+	case map[string]interface{}:
+		return p, nil
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(p))
+		for k, v := range p {
+			ks, ok := k.(string)
+			if !ok {
+				return nil, fmt.Errorf("parameter name must be a string")
 			}
-			return nil
-		default:
+			m[ks] = v
 		}
+		return m, nil
+	default:
+		return nil, fmt.Errorf("parameters must be a map")
 	}
-	return nil
 }
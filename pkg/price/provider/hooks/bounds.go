@@ -0,0 +1,67 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+)
+
+// BoundsHook is the name of the Bounds hook.
+const BoundsHook = "bounds"
+
+// Bounds rejects prices outside the absolute range given by the "min" and
+// "max" parameters. A zero value disables the corresponding check.
+type Bounds struct {
+	min float64
+	max float64
+}
+
+// NewBounds creates a new Bounds hook.
+func NewBounds(_ ethereum.Client, params map[string]interface{}) (Hook, error) {
+	minPrice, err := floatParam(params, "min", 0)
+	if err != nil {
+		return nil, err
+	}
+	maxPrice, err := floatParam(params, "max", 0)
+	if err != nil {
+		return nil, err
+	}
+	if minPrice < 0 || maxPrice < 0 {
+		return nil, errors.New("min and max parameters must not be negative")
+	}
+	if minPrice == 0 && maxPrice == 0 {
+		return nil, errors.New("at least one of min or max parameters is required")
+	}
+	if maxPrice != 0 && minPrice > maxPrice {
+		return nil, errors.New("min parameter must not be greater than max")
+	}
+	return &Bounds{min: minPrice, max: maxPrice}, nil
+}
+
+// Check implements the Hook interface.
+func (b *Bounds) Check(_ context.Context, price Price) error {
+	if b.min != 0 && price.Price < b.min {
+		return fmt.Errorf("price %f for %s is below the minimum %f", price.Price, price.Pair, b.min)
+	}
+	if b.max != 0 && price.Price > b.max {
+		return fmt.Errorf("price %f for %s is above the maximum %f", price.Price, price.Pair, b.max)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+)
+
+// DeviationHook is the name of the Deviation hook.
+const DeviationHook = "deviation"
+
+// Deviation rejects prices that deviate too much from a reference price.
+// The reference price is taken from the origin given in the
+// "referenceOrigin" parameter, which is usually an on-chain origin such as
+// "chainlink" or "uniswapV3TWAP". The origin must be one of the sources of
+// the price model.
+type Deviation struct {
+	referenceOrigin string
+	maxDeviation    float64
+}
+
+// NewDeviation creates a new Deviation hook. Supported parameters are
+// "referenceOrigin" and "maxDeviation", the latter being the maximum
+// allowed relative difference, e.g. 0.05 for 5%.
+func NewDeviation(_ ethereum.Client, params map[string]interface{}) (Hook, error) {
+	ref, err := stringParam(params, "referenceOrigin", "")
+	if err != nil {
+		return nil, err
+	}
+	if ref == "" {
+		return nil, errors.New("referenceOrigin parameter is required")
+	}
+	maxDev, err := floatParam(params, "maxDeviation", 0)
+	if err != nil {
+		return nil, err
+	}
+	if maxDev <= 0 {
+		return nil, errors.New("maxDeviation parameter must be greater than zero")
+	}
+	return &Deviation{referenceOrigin: ref, maxDeviation: maxDev}, nil
+}
+
+// Check implements the Hook interface.
+func (d *Deviation) Check(_ context.Context, price Price) error {
+	ref := price.OriginPrices[d.referenceOrigin]
+	if ref <= 0 {
+		return fmt.Errorf("reference price from %s origin is not available for %s", d.referenceOrigin, price.Pair)
+	}
+	if dev := relativeDiff(price.Price, ref); dev > d.maxDeviation {
+		return fmt.Errorf(
+			"price %f for %s deviates from reference price %f by %f, maximum is %f",
+			price.Price, price.Pair, ref, dev, d.maxDeviation,
+		)
+	}
+	return nil
+}
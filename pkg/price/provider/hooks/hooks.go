@@ -0,0 +1,149 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hooks
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+)
+
+// Price is the price passed to a Hook.
+type Price struct {
+	// Pair is the name of the asset pair, e.g. "ETH/USD".
+	Pair string
+	// Price is the final price calculated by the price model.
+	Price float64
+	// Time is the time of the price.
+	Time time.Time
+	// OriginPrices is a map of prices used to calculate the final price,
+	// indexed by an origin name.
+	OriginPrices map[string]float64
+}
+
+// Hook verifies a price before it is used. If the price must not be used,
+// an error describing the reason is returned.
+type Hook interface {
+	Check(ctx context.Context, price Price) error
+}
+
+// Recorder is an optional interface implemented by hooks that depend on
+// previously used prices. Record is called with a price only after it has
+// been actually used, e.g. broadcast by Ghost.
+type Recorder interface {
+	Record(price Price)
+}
+
+// Factory creates a new Hook instance using parameters from the price
+// model configuration.
+type Factory func(cli ethereum.Client, params map[string]interface{}) (Hook, error)
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register adds a hook factory under the given name. It panics if a hook
+// with the same name is already registered.
+func Register(name string, factory Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if _, ok := factories[name]; ok {
+		panic(fmt.Sprintf("post price hook %s is already registered", name))
+	}
+	factories[name] = factory
+}
+
+// New creates a new instance of the hook registered under the given name.
+func New(name string, cli ethereum.Client, params map[string]interface{}) (Hook, error) {
+	mu.RLock()
+	factory, ok := factories[name]
+	mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown post price hook: %s", name)
+	}
+	return factory(cli, params)
+}
+
+// Names returns sorted names of all registered hooks.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	var names []string
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func init() {
+	Register(RocketPoolCircuitBreakerHook, newRocketPoolHook)
+	Register(DeviationHook, NewDeviation)
+	Register(MaxChangeHook, NewMaxChange)
+	Register(BoundsHook, NewBounds)
+	Register(PegHook, NewPeg)
+}
+
+// floatParam returns the value of a numeric parameter. If the parameter is
+// missing, def is returned.
+func floatParam(params map[string]interface{}, key string, def float64) (float64, error) {
+	v, ok := params[key]
+	if !ok || v == nil {
+		return def, nil
+	}
+	switch n := v.(type) {
+	case int:
+		return float64(n), nil
+	case int64:
+		return float64(n), nil
+	case uint64:
+		return float64(n), nil
+	case float64:
+		return n, nil
+	case float32:
+		return float64(n), nil
+	default:
+		return 0, fmt.Errorf("parameter %s must be a number", key)
+	}
+}
+
+// stringParam returns the value of a string parameter. If the parameter is
+// missing, def is returned.
+func stringParam(params map[string]interface{}, key string, def string) (string, error) {
+	v, ok := params[key]
+	if !ok || v == nil {
+		return def, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return "", fmt.Errorf("parameter %s must be a string", key)
+	}
+	return s, nil
+}
+
+// relativeDiff returns the difference between a and b relative to b.
+func relativeDiff(a, b float64) float64 {
+	d := (a - b) / b
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -0,0 +1,126 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hooks
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, []string{"bounds", "deviation", "maxChange", "peg", "rocketPoolCircuitBreaker"}, Names())
+}
+
+func TestNew_Unknown(t *testing.T) {
+	_, err := New("unknown", nil, nil)
+	assert.Error(t, err)
+}
+
+func TestDeviation(t *testing.T) {
+	_, err := New(DeviationHook, nil, map[string]interface{}{"maxDeviation": 0.1})
+	assert.Error(t, err)
+	_, err = New(DeviationHook, nil, map[string]interface{}{"referenceOrigin": "chainlink"})
+	assert.Error(t, err)
+
+	h, err := New(DeviationHook, nil, map[string]interface{}{"referenceOrigin": "chainlink", "maxDeviation": 0.1})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	ref := map[string]float64{"chainlink": 100}
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 109, OriginPrices: ref}))
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 91, OriginPrices: ref}))
+	assert.Error(t, h.Check(ctx, Price{Pair: "A/B", Price: 111, OriginPrices: ref}))
+	assert.Error(t, h.Check(ctx, Price{Pair: "A/B", Price: 89, OriginPrices: ref}))
+	assert.Error(t, h.Check(ctx, Price{Pair: "A/B", Price: 100, OriginPrices: map[string]float64{"binance": 100}}))
+}
+
+func TestMaxChange(t *testing.T) {
+	_, err := New(MaxChangeHook, nil, map[string]interface{}{})
+	assert.Error(t, err)
+	_, err = New(MaxChangeHook, nil, map[string]interface{}{"maxChange": "10%"})
+	assert.Error(t, err)
+
+	h, err := New(MaxChangeHook, nil, map[string]interface{}{"maxChange": 0.1})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	now := time.Now()
+	r := h.(Recorder)
+	// Without a recorded price, every price is accepted.
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 100, Time: now}))
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 200, Time: now}))
+	r.Record(Price{Pair: "A/B", Price: 100, Time: now})
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 109, Time: now.Add(time.Minute)}))
+	r.Record(Price{Pair: "A/B", Price: 109, Time: now.Add(time.Minute)})
+	// Compared to the last recorded price, which is 109.
+	assert.Error(t, h.Check(ctx, Price{Pair: "A/B", Price: 121, Time: now.Add(2 * time.Minute)}))
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 119, Time: now.Add(2 * time.Minute)}))
+	// The last recorded price is ignored after the resetAfter duration.
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 200, Time: now.Add(time.Minute + time.Hour)}))
+	// Older prices do not replace the recorded one.
+	r.Record(Price{Pair: "A/B", Price: 50, Time: now})
+	assert.Error(t, h.Check(ctx, Price{Pair: "A/B", Price: 50, Time: now.Add(2 * time.Minute)}))
+	// Pairs are tracked separately.
+	assert.NoError(t, h.Check(ctx, Price{Pair: "X/Y", Price: 1, Time: now}))
+
+	h, err = New(MaxChangeHook, nil, map[string]interface{}{"maxChange": 0.1, "resetAfter": 60})
+	require.NoError(t, err)
+	h.(Recorder).Record(Price{Pair: "A/B", Price: 100, Time: now})
+	assert.Error(t, h.Check(ctx, Price{Pair: "A/B", Price: 200, Time: now.Add(59 * time.Second)}))
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 200, Time: now.Add(60 * time.Second)}))
+}
+
+func TestBounds(t *testing.T) {
+	_, err := New(BoundsHook, nil, map[string]interface{}{})
+	assert.Error(t, err)
+	_, err = New(BoundsHook, nil, map[string]interface{}{"min": 10, "max": 5})
+	assert.Error(t, err)
+
+	h, err := New(BoundsHook, nil, map[string]interface{}{"min": 10, "max": 20})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 10}))
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 20}))
+	assert.Error(t, h.Check(ctx, Price{Pair: "A/B", Price: 9.99}))
+	assert.Error(t, h.Check(ctx, Price{Pair: "A/B", Price: 20.01}))
+
+	h, err = New(BoundsHook, nil, map[string]interface{}{"min": 10})
+	require.NoError(t, err)
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 1e9}))
+}
+
+func TestPeg(t *testing.T) {
+	_, err := New(PegHook, nil, map[string]interface{}{})
+	assert.Error(t, err)
+
+	h, err := New(PegHook, nil, map[string]interface{}{"maxDeviation": 0.02})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	assert.NoError(t, h.Check(ctx, Price{Pair: "DAI/USD", Price: 0.99}))
+	assert.NoError(t, h.Check(ctx, Price{Pair: "DAI/USD", Price: 1.01}))
+	assert.Error(t, h.Check(ctx, Price{Pair: "DAI/USD", Price: 0.97}))
+
+	h, err = New(PegHook, nil, map[string]interface{}{"peg": 2, "maxDeviation": 0.02})
+	require.NoError(t, err)
+	assert.NoError(t, h.Check(ctx, Price{Pair: "A/B", Price: 2.01}))
+	assert.Error(t, h.Check(ctx, Price{Pair: "A/B", Price: 1}))
+}
@@ -0,0 +1,99 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+)
+
+// MaxChangeHook is the name of the MaxChange hook.
+const MaxChangeHook = "maxChange"
+
+// defaultMaxChangeResetAfter is the default time after which the last
+// recorded price is no longer used as a reference.
+const defaultMaxChangeResetAfter = time.Hour
+
+// MaxChange rejects prices that changed too much since the last recorded
+// price. Prices are recorded using the Record method, which Ghost calls only
+// after a price has been broadcast.
+//
+// The last recorded price is used as a reference only for the "resetAfter"
+// duration, so a genuine market move cannot stop the price feed permanently.
+type MaxChange struct {
+	mu         sync.Mutex
+	maxChange  float64
+	resetAfter time.Duration
+	last       map[string]Price
+}
+
+// NewMaxChange creates a new MaxChange hook. The "maxChange" parameter is
+// the maximum allowed relative change, e.g. 0.1 for 10%. The "resetAfter"
+// parameter is the number of seconds after which the last recorded price
+// is ignored (default: 3600).
+func NewMaxChange(_ ethereum.Client, params map[string]interface{}) (Hook, error) {
+	maxChange, err := floatParam(params, "maxChange", 0)
+	if err != nil {
+		return nil, err
+	}
+	if maxChange <= 0 {
+		return nil, errors.New("maxChange parameter must be greater than zero")
+	}
+	resetAfter, err := floatParam(params, "resetAfter", defaultMaxChangeResetAfter.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	if resetAfter <= 0 {
+		return nil, errors.New("resetAfter parameter must be greater than zero")
+	}
+	return &MaxChange{
+		maxChange:  maxChange,
+		resetAfter: time.Duration(resetAfter * float64(time.Second)),
+		last:       make(map[string]Price),
+	}, nil
+}
+
+// Check implements the Hook interface.
+func (m *MaxChange) Check(_ context.Context, price Price) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	last, ok := m.last[price.Pair]
+	if !ok || last.Price <= 0 || price.Time.Sub(last.Time) >= m.resetAfter {
+		return nil
+	}
+	if change := relativeDiff(price.Price, last.Price); change > m.maxChange {
+		return fmt.Errorf(
+			"price %f for %s changed by %f since the last price %f, maximum is %f",
+			price.Price, price.Pair, change, last.Price, m.maxChange,
+		)
+	}
+	return nil
+}
+
+// Record implements the Recorder interface.
+func (m *MaxChange) Record(price Price) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if last, ok := m.last[price.Pair]; ok && price.Time.Before(last.Time) {
+		return
+	}
+	m.last[price.Pair] = Price{Pair: price.Pair, Price: price.Price, Time: price.Time}
+}
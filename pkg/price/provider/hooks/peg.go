@@ -0,0 +1,67 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+)
+
+// PegHook is the name of the Peg hook.
+const PegHook = "peg"
+
+// Peg rejects prices of pegged assets, such as stablecoins, that drift too
+// far from the peg. It is meant to detect broken sources rather than real
+// de-pegs, so the allowed deviation should be generous.
+type Peg struct {
+	peg          float64
+	maxDeviation float64
+}
+
+// NewPeg creates a new Peg hook. Supported parameters are "peg", the
+// expected price (default: 1), and "maxDeviation", the maximum allowed
+// relative difference, e.g. 0.02 for 2%.
+func NewPeg(_ ethereum.Client, params map[string]interface{}) (Hook, error) {
+	peg, err := floatParam(params, "peg", 1)
+	if err != nil {
+		return nil, err
+	}
+	if peg <= 0 {
+		return nil, errors.New("peg parameter must be greater than zero")
+	}
+	maxDev, err := floatParam(params, "maxDeviation", 0)
+	if err != nil {
+		return nil, err
+	}
+	if maxDev <= 0 {
+		return nil, errors.New("maxDeviation parameter must be greater than zero")
+	}
+	return &Peg{peg: peg, maxDeviation: maxDev}, nil
+}
+
+// Check implements the Hook interface.
+func (p *Peg) Check(_ context.Context, price Price) error {
+	if dev := relativeDiff(price.Price, p.peg); dev > p.maxDeviation {
+		return fmt.Errorf(
+			"price %f for %s deviates from the peg %f by %f, maximum is %f",
+			price.Price, price.Pair, p.peg, dev, p.maxDeviation,
+		)
+	}
+	return nil
+}
@@ -95,3 +95,37 @@ func (o *RocketPoolCircuitBreaker) Check(ctx context.Context,
 	}
 	return nil
 }
+
+// RocketPoolCircuitBreakerHook is the name under which the
+// RocketPoolCircuitBreaker is registered.
+const RocketPoolCircuitBreakerHook = "rocketPoolCircuitBreaker"
+
+// rocketPoolHook adapts the RocketPoolCircuitBreaker to the Hook interface.
+// The reference price is taken from the "rocketpool" origin, unless
+// a different one is given in the "referenceOrigin" parameter.
+type rocketPoolHook struct {
+	cb              *RocketPoolCircuitBreaker
+	cli             ethereum.Client
+	referenceOrigin string
+}
+
+func newRocketPoolHook(cli ethereum.Client, params map[string]interface{}) (Hook, error) {
+	cb, err := NewRocketPoolCircuitBreaker(params)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := stringParam(params, "referenceOrigin", "rocketpool")
+	if err != nil {
+		return nil, err
+	}
+	return &rocketPoolHook{cb: cb, cli: cli, referenceOrigin: ref}, nil
+}
+
+// Check implements the Hook interface.
+func (h *rocketPoolHook) Check(ctx context.Context, price Price) error {
+	ref := price.OriginPrices[h.referenceOrigin]
+	if ref == 0 {
+		return fmt.Errorf("post price hook failed for %s, reference price should be > 0", price.Pair)
+	}
+	return h.cb.Check(ctx, h.cli, price.Price, ref)
+}
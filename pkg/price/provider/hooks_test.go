@@ -23,6 +23,7 @@ import (
 	ethereumMocks "github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPostPriceHook(t *testing.T) {
@@ -88,3 +89,71 @@ func TestPostPriceHook(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, prices[pair].Error == "")
 }
+
+func TestPostPriceHook_Hooks(t *testing.T) {
+	pairParams := NewHookParams()
+	pairParams["ETH/USD"] = map[string]interface{}{
+		"bounds": map[string]interface{}{"min": 100, "max": 10000},
+		"deviation": map[interface{}]interface{}{
+			"referenceOrigin": "chainlink",
+			"maxDeviation":    0.05,
+		},
+	}
+
+	hook, err := NewPostPriceHook(context.Background(), &ethereumMocks.Client{}, pairParams)
+	require.NoError(t, err)
+
+	pair, err := NewPair("ETH/USD")
+	require.NoError(t, err)
+
+	newPrice := func(price, ref float64) map[Pair]*Price {
+		return map[Pair]*Price{
+			pair: {
+				Price: price,
+				Prices: []*Price{
+					{
+						Type: "median",
+						Prices: []*Price{
+							{Parameters: map[string]string{"origin": "binance"}, Price: price},
+							{Parameters: map[string]string{"origin": "chainlink"}, Price: ref},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	// Valid price.
+	prices := newPrice(1000, 1010)
+	require.NoError(t, hook.Check(prices))
+	assert.Empty(t, prices[pair].Error)
+
+	// Too far from the reference price.
+	prices = newPrice(1000, 1100)
+	require.NoError(t, hook.Check(prices))
+	assert.Contains(t, prices[pair].Error, "deviation")
+
+	// Out of bounds, bounds are checked first.
+	prices = newPrice(50, 1100)
+	require.NoError(t, hook.Check(prices))
+	assert.Contains(t, prices[pair].Error, "bounds")
+
+	// Prices that already have an error are left untouched.
+	prices = newPrice(50, 1100)
+	prices[pair].Error = "err"
+	require.NoError(t, hook.Check(prices))
+	assert.Equal(t, "err", prices[pair].Error)
+}
+
+func TestPostPriceHook_InvalidConfig(t *testing.T) {
+	for _, params := range []map[string]interface{}{
+		{"unknown": map[string]interface{}{}},
+		{"bounds": "invalid"},
+		{"bounds": map[string]interface{}{}},
+	} {
+		pairParams := NewHookParams()
+		pairParams["ETH/USD"] = params
+		_, err := NewPostPriceHook(context.Background(), &ethereumMocks.Client{}, pairParams)
+		assert.Error(t, err)
+	}
+}
@@ -48,6 +48,8 @@ func (j *json) Write(writer io.Writer, item interface{}) error {
 		i = j.handlePrice(typedItem)
 	case *provider.Model:
 		i = j.handleModel(typedItem)
+	case *provider.OriginHealth:
+		i = j.handleOriginHealth(typedItem)
 	case error:
 		i = j.handleError(typedItem)
 	default:
@@ -106,6 +108,10 @@ func (*json) handleModel(node *provider.Model) interface{} {
 	return node.Pair.String()
 }
 
+func (*json) handleOriginHealth(health *provider.OriginHealth) interface{} {
+	return jsonOriginHealthFromGoferOriginHealth(health)
+}
+
 func (*json) handleError(err error) interface{} {
 	return struct {
 		Error string `json:"error"`
@@ -145,3 +151,43 @@ func jsonPriceFromGoferPrice(t *provider.Price) jsonPrice {
 		Error:      t.Error,
 	}
 }
+
+type jsonOriginHealth struct {
+	Origin              string     `json:"origin"`
+	Score               float64    `json:"score"`
+	ErrorRate           float64    `json:"errorRate"`
+	Latency             float64    `json:"latency"`
+	Requests            int        `json:"requests"`
+	Errors              int        `json:"errors"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	LastSuccess         *time.Time `json:"lastSuccess,omitempty"`
+	LastPriceTime       *time.Time `json:"lastPriceTs,omitempty"`
+	LastError           string     `json:"lastError,omitempty"`
+	DisabledUntil       *time.Time `json:"disabledUntil,omitempty"`
+}
+
+func jsonOriginHealthFromGoferOriginHealth(h *provider.OriginHealth) jsonOriginHealth {
+	optTime := func(t time.Time) *time.Time {
+		if t.IsZero() {
+			return nil
+		}
+		u := t.In(time.UTC)
+		return &u
+	}
+	j := jsonOriginHealth{
+		Origin:              h.Origin,
+		Score:               h.Score,
+		ErrorRate:           h.ErrorRate,
+		Latency:             h.Latency.Seconds(),
+		Requests:            h.Requests,
+		Errors:              h.Errors,
+		ConsecutiveFailures: h.ConsecutiveFailures,
+		LastSuccess:         optTime(h.LastSuccess),
+		LastPriceTime:       optTime(h.LastPriceTime),
+		LastError:           h.LastError,
+	}
+	if h.Disabled() {
+		j.DisabledUntil = optTime(h.DisabledUntil)
+	}
+	return j
+}
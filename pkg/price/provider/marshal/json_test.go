@@ -18,6 +18,7 @@ package marshal
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -193,3 +194,42 @@ func TestJSON_Prices(t *testing.T) {
 
 	assert.JSONEq(t, expected, b.String())
 }
+
+func TestJSON_OriginsHealth(t *testing.T) {
+	var err error
+	b := &bytes.Buffer{}
+	m := newJSON(false)
+
+	err = m.Write(b, &provider.OriginHealth{
+		Origin:      "a",
+		Score:       0.5,
+		ErrorRate:   0.5,
+		Latency:     1500 * time.Millisecond,
+		Requests:    2,
+		Errors:      1,
+		LastSuccess: time.Unix(10, 0),
+	})
+	assert.NoError(t, err)
+
+	err = m.Write(b, &provider.OriginHealth{
+		Origin:              "b",
+		ErrorRate:           1,
+		Requests:            3,
+		Errors:              3,
+		ConsecutiveFailures: 3,
+		LastError:           "error",
+		DisabledUntil:       time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+
+	err = m.Flush()
+	assert.NoError(t, err)
+
+	expected := `
+[
+  {"origin":"a","score":0.5,"errorRate":0.5,"latency":1.5,"requests":2,"errors":1,"consecutiveFailures":0,"lastSuccess":"1970-01-01T00:00:10Z"},
+  {"origin":"b","score":0,"errorRate":1,"latency":0,"requests":3,"errors":3,"consecutiveFailures":3,"lastError":"error","disabledUntil":"2999-01-01T00:00:00Z"}
+]`
+
+	assert.JSONEq(t, expected, b.String())
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
 )
@@ -44,6 +45,8 @@ func (p *plain) Write(writer io.Writer, item interface{}) error {
 		i = p.handlePrice(typedItem)
 	case *provider.Model:
 		i = p.handleModel(typedItem)
+	case *provider.OriginHealth:
+		i = p.handleOriginHealth(typedItem)
 	case error:
 		i = []byte(fmt.Sprintf("Error: %s", typedItem.Error()))
 	default:
@@ -80,3 +83,15 @@ func (*plain) handlePrice(price *provider.Price) []byte {
 func (*plain) handleModel(node *provider.Model) []byte {
 	return []byte(node.Pair.String())
 }
+
+func (*plain) handleOriginHealth(health *provider.OriginHealth) []byte {
+	if health.Disabled() {
+		return []byte(fmt.Sprintf(
+			"%s disabled until %s - %s",
+			health.Origin,
+			health.DisabledUntil.In(time.UTC).Format(time.RFC3339),
+			strings.TrimSpace(health.LastError),
+		))
+	}
+	return []byte(fmt.Sprintf("%s %f", health.Origin, health.Score))
+}
@@ -18,6 +18,7 @@ package marshal
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -78,3 +79,29 @@ C/D - something
 
 	assert.Equal(t, expected, b.String())
 }
+
+func TestPlain_OriginsHealth(t *testing.T) {
+	var err error
+	b := &bytes.Buffer{}
+	m := newPlain()
+
+	err = m.Write(b, &provider.OriginHealth{Origin: "a", Score: 0.5})
+	assert.NoError(t, err)
+
+	err = m.Write(b, &provider.OriginHealth{
+		Origin:        "b",
+		LastError:     "something",
+		DisabledUntil: time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+
+	err = m.Flush()
+	assert.NoError(t, err)
+
+	expected := `
+a 0.500000
+b disabled until 2999-01-01T00:00:00Z - something
+`[1:]
+
+	assert.Equal(t, expected, b.String())
+}
@@ -48,6 +48,8 @@ func (t *trace) Write(writer io.Writer, item interface{}) error {
 		i = t.handlePrice(typedItem)
 	case *provider.Model:
 		i = t.handleModel(typedItem)
+	case *provider.OriginHealth:
+		i = t.handleOriginHealth(typedItem)
 	case error:
 		i = []byte(fmt.Sprintf("Error: %s", typedItem.Error()))
 	default:
@@ -133,6 +135,35 @@ func (t *trace) handleModel(node *provider.Model) []byte {
 	return buf.Bytes()
 }
 
+func (t *trace) handleOriginHealth(health *provider.OriginHealth) []byte {
+	var hErr error
+	if health.LastError != "" && health.ConsecutiveFailures > 0 {
+		hErr = errors.New(health.LastError)
+	}
+	params := []param{
+		{key: "score", value: fmt.Sprintf("%.3f", health.Score)},
+		{key: "errorRate", value: fmt.Sprintf("%.3f", health.ErrorRate)},
+		{key: "latency", value: health.Latency.String()},
+		{key: "requests", value: health.Requests},
+		{key: "errors", value: health.Errors},
+		{key: "consecutiveFailures", value: health.ConsecutiveFailures},
+	}
+	if !health.LastPriceTime.IsZero() {
+		params = append(params, param{key: "lastPriceTimestamp", value: health.LastPriceTime.In(time.UTC).Format(time.RFC3339Nano)})
+	}
+	if health.Disabled() {
+		params = append(params, param{key: "disabledUntil", value: health.DisabledUntil.In(time.UTC).Format(time.RFC3339Nano)})
+	}
+	tree := renderTree(func(node interface{}) ([]byte, []interface{}) {
+		return renderNode("origin", params, hErr), nil
+	}, []interface{}{health}, 0)
+
+	buf := bytes.Buffer{}
+	buf.Write([]byte(fmt.Sprintf("Health of %s:\n", health.Origin)))
+	buf.Write(tree)
+	return buf.Bytes()
+}
+
 // param is used to work with lists of sorted key/value pairs.
 type param struct {
 	key   string
@@ -18,10 +18,13 @@ package marshal
 import (
 	"bytes"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/nodes"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/marshal/testutil"
 )
 
@@ -55,6 +58,36 @@ Graph for A/B:
 	assert.Equal(t, expected, b.String())
 }
 
+func TestTrace_Graph_WeightedMedian(t *testing.T) {
+	disableColors()
+
+	var err error
+	b := &bytes.Buffer{}
+	m := newTrace()
+
+	ab := provider.Pair{Base: "A", Quote: "B"}
+	root := nodes.NewWeightedMedianAggregatorNode(ab, 1)
+	root.AddChildWithWeight(nodes.NewOriginNode(nodes.OriginPair{Origin: "a", Pair: ab}, 0, 0), 2)
+	root.AddChildWithWeight(nodes.NewOriginNode(nodes.OriginPair{Origin: "b", Pair: ab}, 0, 0), 1)
+	ns, err := graph.NewProvider(map[provider.Pair]nodes.Aggregator{ab: root}, nil).Models(ab)
+	assert.NoError(t, err)
+
+	err = m.Write(b, ns[ab])
+	assert.NoError(t, err)
+
+	err = m.Flush()
+	assert.NoError(t, err)
+
+	expected := `
+Graph for A/B:
+───weightedMedian(pair:A/B, weights:2,1)
+   ├──origin(origin:a, pair:A/B)
+   └──origin(origin:b, pair:A/B)
+`[1:]
+
+	assert.Equal(t, expected, b.String())
+}
+
 func TestTrace_Prices(t *testing.T) {
 	disableColors()
 
@@ -85,3 +118,44 @@ Price for A/B:
 
 	assert.Equal(t, expected, b.String())
 }
+
+func TestTrace_Prices_Outliers(t *testing.T) {
+	disableColors()
+
+	var err error
+	b := &bytes.Buffer{}
+	m := newTrace()
+
+	ab := provider.Pair{Base: "A", Quote: "B"}
+	ttl := time.Second * time.Duration(time.Now().Unix()+10)
+	root := nodes.NewMedianAggregatorNodeWithOutlierFilter(ab, 2, nodes.OutlierFilter{MaxDeviation: 0.1})
+	for _, o := range []struct {
+		origin string
+		price  float64
+	}{{"a", 10}, {"b", 10}, {"c", 20}} {
+		on := nodes.NewOriginNode(nodes.OriginPair{Origin: o.origin, Pair: ab}, 0, ttl)
+		_ = on.Ingest(nodes.OriginPrice{
+			PairPrice: nodes.PairPrice{Pair: ab, Price: o.price, Time: time.Unix(10, 0)},
+			Origin:    o.origin,
+		})
+		root.AddChild(on)
+	}
+	ts, err := graph.NewProvider(map[provider.Pair]nodes.Aggregator{ab: root}, nil).Prices(ab)
+	assert.NoError(t, err)
+
+	err = m.Write(b, ts[ab])
+	assert.NoError(t, err)
+
+	err = m.Flush()
+	assert.NoError(t, err)
+
+	expected := `
+Price for A/B:
+───aggregator(maxDeviation:0.1, method:median, minimumSuccessfulSources:2, pair:A/B, price:10, timestamp:1970-01-01T00:00:10Z)
+   ├──origin(origin:a, pair:A/B, price:10, timestamp:1970-01-01T00:00:10Z)
+   ├──origin(origin:b, pair:A/B, price:10, timestamp:1970-01-01T00:00:10Z)
+   └──origin(origin:c, outlier:deviation of 100.00% from the median exceeds 10.00%, pair:A/B, price:20, timestamp:1970-01-01T00:00:10Z)
+`[1:]
+
+	assert.Equal(t, expected, b.String())
+}
@@ -47,6 +47,11 @@ func (g *Provider) Pairs() ([]provider.Pair, error) {
 	return args.Get(0).([]provider.Pair), args.Error(1)
 }
 
+func (g *Provider) OriginsHealth() (map[string]*provider.OriginHealth, error) {
+	args := g.Called()
+	return args.Get(0).(map[string]*provider.OriginHealth), args.Error(1)
+}
+
 func interfaceSlice(slice interface{}) []interface{} {
 	s := reflect.ValueOf(slice)
 	if s.Kind() != reflect.Slice {
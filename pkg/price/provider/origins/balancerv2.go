@@ -19,13 +19,13 @@ import (
 	"context"
 	_ "embed"
 	"fmt"
-	"math/big"
 	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 // The three values that can be queried:
@@ -81,7 +81,7 @@ func (s BalancerV2) callOne(pair Pair) (*Price, error) {
 	if indirect {
 		return nil, fmt.Errorf("cannot use indirect pair to retrieve price: %s", pair.String())
 	}
-	var priceFloat *big.Float
+	var price decimal.Decimal
 	{
 		callData, err := s.abi.Pack("getLatest", s.variable)
 		if err != nil {
@@ -92,7 +92,7 @@ func (s BalancerV2) callOne(pair Pair) (*Price, error) {
 		if err != nil {
 			return nil, err
 		}
-		priceFloat = reduceEtherAverageFloat(resp)
+		price = reduceEtherAverageDecimal(resp)
 	}
 
 	token, inverted, ok := s.ContractAddresses.ByPair(Pair{Base: prefixRef + pair.Base, Quote: pair.Quote})
@@ -109,14 +109,13 @@ func (s BalancerV2) callOne(pair Pair) (*Price, error) {
 			return nil, err
 		}
 
-		priceFloat = new(big.Float).Mul(reduceEtherAverageFloat(resp), priceFloat)
+		price = reduceEtherAverageDecimal(resp).Mul(price)
 	}
 
-	price, _ := priceFloat.Float64()
-
 	return &Price{
-		Pair:      pair,
-		Price:     price,
-		Timestamp: time.Now(),
+		Pair:         pair,
+		Price:        price.Float64(),
+		DecimalPrice: price,
+		Timestamp:    time.Now(),
 	}, nil
 }
@@ -0,0 +1,97 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const binanceWSURL = "wss://stream.binance.com:9443/ws"
+
+// NewBinanceStream returns a streaming origin handler that subscribes to
+// the Binance individual symbol ticker streams.
+func NewBinanceStream(url string, aliases SymbolAliases) *WebSocketStream {
+	if url == "" {
+		url = binanceWSURL
+	}
+	return newWebSocketStream(url, binanceStream{}, aliases)
+}
+
+type binanceStream struct{}
+
+type binanceStreamSubscribe struct {
+	Method string   `json:"method"`
+	Params []string `json:"params"`
+	ID     int      `json:"id"`
+}
+
+type binanceStreamMessage struct {
+	Event     string               `json:"e"`
+	EventTime intAsUnixTimestampMs `json:"E"`
+	Symbol    string               `json:"s"`
+	Price     stringAsFloat64      `json:"c"`
+	Bid       stringAsFloat64      `json:"b"`
+	Ask       stringAsFloat64      `json:"a"`
+	Volume    stringAsFloat64      `json:"v"`
+	// Keys that differ from the ones above only by case must be declared
+	// explicitly, otherwise they would be decoded into the fields above.
+	CloseTime json.RawMessage `json:"C"`
+	BidQty    json.RawMessage `json:"B"`
+	AskQty    json.RawMessage `json:"A"`
+	Error     *struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	} `json:"error"`
+}
+
+func (binanceStream) localPairName(pair Pair) string {
+	return strings.ToUpper(pair.Base + pair.Quote)
+}
+
+func (b binanceStream) subscribe(pairs []Pair) []interface{} {
+	var params []string
+	for _, p := range pairs {
+		params = append(params, strings.ToLower(b.localPairName(p))+"@ticker")
+	}
+	return []interface{}{binanceStreamSubscribe{Method: "SUBSCRIBE", Params: params, ID: 1}}
+}
+
+func (b binanceStream) parse(pairs []Pair, msg []byte) ([]Price, error) {
+	var m binanceStreamMessage
+	if err := json.Unmarshal(msg, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+	if m.Error != nil {
+		return nil, fmt.Errorf("binance error %d: %s", m.Error.Code, m.Error.Msg)
+	}
+	if m.Event != "24hrTicker" {
+		return nil, nil
+	}
+	pair, ok := findPair(pairs, m.Symbol, b.localPairName)
+	if !ok {
+		return nil, nil
+	}
+	return []Price{{
+		Pair:      pair,
+		Price:     m.Price.val(),
+		Bid:       m.Bid.val(),
+		Ask:       m.Ask.val(),
+		Volume24h: m.Volume.val(),
+		Timestamp: m.EventTime.val(),
+	}}, nil
+}
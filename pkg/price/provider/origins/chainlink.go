@@ -0,0 +1,110 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
+)
+
+//go:embed chainlink_abi.json
+var chainlinkABI string
+
+// Chainlink reads prices from Chainlink-style aggregator contracts using
+// the latestRoundData method. The time of the last update of the round is
+// used as the price timestamp, so prices from stale feeds will be rejected
+// by the TTL checks.
+type Chainlink struct {
+	ethClient ethereum.Client
+	addrs     ContractAddresses
+	abi       abi.ABI
+}
+
+func NewChainlink(cli ethereum.Client, addrs ContractAddresses) (*Chainlink, error) {
+	a, err := abi.JSON(strings.NewReader(chainlinkABI))
+	if err != nil {
+		return nil, err
+	}
+	return &Chainlink{
+		ethClient: cli,
+		addrs:     addrs,
+		abi:       a,
+	}, nil
+}
+
+func (c Chainlink) PullPrices(pairs []Pair) []FetchResult {
+	return callSinglePairOrigin(&c, pairs)
+}
+
+func (c Chainlink) callOne(pair Pair) (*Price, error) {
+	contract, inverted, err := c.addrs.AddressByPair(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	decimalsData, err := c.abi.Pack("decimals")
+	if err != nil {
+		return nil, err
+	}
+	roundData, err := c.abi.Pack("latestRoundData")
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.ethClient.MultiCall(context.Background(), []ethereum.Call{
+		{Address: contract, Data: decimalsData},
+		{Address: contract, Data: roundData},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != 2 {
+		return nil, ErrEmptyOriginResponse
+	}
+
+	decimals, err := c.abi.Unpack("decimals", resp[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack decimals for pair %s: %w", pair.String(), err)
+	}
+	round, err := c.abi.Unpack("latestRoundData", resp[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack round data for pair %s: %w", pair.String(), err)
+	}
+	answer := round[1].(*big.Int)
+	updatedAt := round[3].(*big.Int)
+	if answer.Sign() <= 0 || updatedAt.Sign() == 0 {
+		return nil, ErrInvalidPrice
+	}
+
+	price := decimal.FromBigInt(answer, int(decimals[0].(uint8)))
+	if inverted {
+		price = price.Inv()
+	}
+	return &Price{
+		Pair:         pair,
+		Price:        price.Float64(),
+		DecimalPrice: price,
+		Timestamp:    time.Unix(updatedAt.Int64(), 0),
+	}, nil
+}
@@ -0,0 +1,126 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	ethereumMocks "github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ChainlinkSuite struct {
+	suite.Suite
+	addresses ContractAddresses
+	client    *ethereumMocks.Client
+	chainlink *Chainlink
+	origin    *BaseExchangeHandler
+}
+
+func (suite *ChainlinkSuite) SetupSuite() {
+	suite.addresses = ContractAddresses{
+		"ETH/USD": "0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419",
+	}
+}
+func (suite *ChainlinkSuite) TearDownSuite() {
+	suite.addresses = nil
+}
+
+func (suite *ChainlinkSuite) SetupTest() {
+	var err error
+	suite.client = &ethereumMocks.Client{}
+	suite.chainlink, err = NewChainlink(suite.client, suite.addresses)
+	suite.NoError(err)
+	suite.origin = NewBaseExchangeHandler(suite.chainlink, nil)
+}
+
+func (suite *ChainlinkSuite) TearDownTest() {
+	suite.client = nil
+	suite.origin = nil
+}
+
+func (suite *ChainlinkSuite) Origin() Handler {
+	return suite.origin
+}
+
+func TestChainlinkSuite(t *testing.T) {
+	suite.Run(t, new(ChainlinkSuite))
+}
+
+func (suite *ChainlinkSuite) mockRound(answer *big.Int, updatedAt int64) {
+	round, err := suite.chainlink.abi.Methods["latestRoundData"].Outputs.Pack(
+		big.NewInt(1),
+		answer,
+		big.NewInt(updatedAt),
+		big.NewInt(updatedAt),
+		big.NewInt(1),
+	)
+	suite.Require().NoError(err)
+	address := ethereum.HexToAddress("0x5f4eC3Df9cbd43714FE2740f5E3616155c5b8419")
+	suite.client.On(
+		"MultiCall",
+		mock.Anything,
+		[]ethereum.Call{
+			{Address: address, Data: ethereum.HexToBytes("0x313ce567")},
+			{Address: address, Data: ethereum.HexToBytes("0xfeaf968c")},
+		},
+	).Return([][]byte{common.BigToHash(big.NewInt(8)).Bytes(), round}, nil).Once()
+}
+
+func (suite *ChainlinkSuite) TestSuccessResponse() {
+	suite.mockRound(big.NewInt(300012345678), 1650000000)
+
+	results := suite.origin.Fetch([]Pair{{Base: "ETH", Quote: "USD"}})
+	suite.Require().NoError(results[0].Error)
+	suite.Equal(3000.12345678, results[0].Price.Price)
+	suite.Equal(time.Unix(1650000000, 0), results[0].Price.Timestamp)
+}
+
+func (suite *ChainlinkSuite) TestSuccessResponse_Inverted() {
+	suite.mockRound(big.NewInt(200000000000), 1650000000)
+
+	results := suite.origin.Fetch([]Pair{{Base: "USD", Quote: "ETH"}})
+	suite.Require().NoError(results[0].Error)
+	suite.Equal(0.0005, results[0].Price.Price)
+	suite.Equal(time.Unix(1650000000, 0), results[0].Price.Timestamp)
+}
+
+func (suite *ChainlinkSuite) TestInvalidAnswer() {
+	suite.mockRound(big.NewInt(-1), 1650000000)
+
+	results := suite.origin.Fetch([]Pair{{Base: "ETH", Quote: "USD"}})
+	suite.Equal(ErrInvalidPrice, results[0].Error)
+}
+
+func (suite *ChainlinkSuite) TestCallError() {
+	suite.client.On("MultiCall", mock.Anything, mock.Anything).Return([][]byte(nil), errors.New("error")).Once()
+
+	results := suite.origin.Fetch([]Pair{{Base: "ETH", Quote: "USD"}})
+	suite.EqualError(results[0].Error, "error")
+}
+
+func (suite *ChainlinkSuite) TestFailOnWrongPair() {
+	cr := suite.origin.Fetch([]Pair{{Base: "x", Quote: "y"}})
+	suite.Require().EqualError(cr[0].Error, "failed to get contract address for pair: x/y")
+}
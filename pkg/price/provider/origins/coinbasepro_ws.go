@@ -0,0 +1,95 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const coinbaseProWSURL = "wss://ws-feed.exchange.coinbase.com"
+
+// NewCoinbaseProStream returns a streaming origin handler that subscribes
+// to the Coinbase Pro ticker channel.
+func NewCoinbaseProStream(url string, aliases SymbolAliases) *WebSocketStream {
+	if url == "" {
+		url = coinbaseProWSURL
+	}
+	return newWebSocketStream(url, coinbaseProStream{}, aliases)
+}
+
+type coinbaseProStream struct{}
+
+type coinbaseProStreamSubscribe struct {
+	Type       string   `json:"type"`
+	ProductIDs []string `json:"product_ids"`
+	Channels   []string `json:"channels"`
+}
+
+type coinbaseProStreamMessage struct {
+	Type      string          `json:"type"`
+	Message   string          `json:"message"`
+	Reason    string          `json:"reason"`
+	ProductID string          `json:"product_id"`
+	Price     stringAsFloat64 `json:"price"`
+	Bid       stringAsFloat64 `json:"best_bid"`
+	Ask       stringAsFloat64 `json:"best_ask"`
+	Volume    stringAsFloat64 `json:"volume_24h"`
+	Time      time.Time       `json:"time"`
+}
+
+func (coinbaseProStream) localPairName(pair Pair) string {
+	return fmt.Sprintf("%s-%s", pair.Base, pair.Quote)
+}
+
+func (c coinbaseProStream) subscribe(pairs []Pair) []interface{} {
+	var ids []string
+	for _, p := range pairs {
+		ids = append(ids, c.localPairName(p))
+	}
+	return []interface{}{coinbaseProStreamSubscribe{Type: "subscribe", ProductIDs: ids, Channels: []string{"ticker"}}}
+}
+
+func (c coinbaseProStream) parse(pairs []Pair, msg []byte) ([]Price, error) {
+	var m coinbaseProStreamMessage
+	if err := json.Unmarshal(msg, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+	switch m.Type {
+	case "error":
+		return nil, fmt.Errorf("coinbase error: %s: %s", m.Message, m.Reason)
+	case "ticker":
+	default:
+		return nil, nil
+	}
+	pair, ok := findPair(pairs, m.ProductID, c.localPairName)
+	if !ok {
+		return nil, nil
+	}
+	ts := m.Time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	return []Price{{
+		Pair:      pair,
+		Price:     m.Price.val(),
+		Bid:       m.Bid.val(),
+		Ask:       m.Ask.val(),
+		Volume24h: m.Volume.val(),
+		Timestamp: ts,
+	}}, nil
+}
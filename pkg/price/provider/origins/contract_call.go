@@ -0,0 +1,269 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
+)
+
+// ContractCallPair describes the contract method which returns a price
+// for a pair.
+type ContractCallPair struct {
+	// Address is the address of the contract.
+	Address ethereum.Address
+	// ABI is a JSON ABI of the contract. It must contain at least
+	// the called method.
+	ABI string
+	// Method is the name of the called method.
+	Method string
+	// Args is a list of method arguments. Arguments are converted to
+	// types defined in the ABI.
+	Args []string
+	// Output is the index of the method output that contains the price.
+	Output int
+	// Decimals is the number of decimals of the returned value.
+	Decimals int
+	// Scale is an optional multiplier applied to the price after
+	// decimals are applied.
+	Scale float64
+}
+
+type contractCall struct {
+	address  ethereum.Address
+	abi      abi.ABI
+	method   string
+	data     []byte
+	output   int
+	decimals int
+	scale    float64
+}
+
+// ContractCall is a generic origin that reads prices by calling contract
+// methods. If more than one block is given, the price is averaged over
+// these blocks.
+type ContractCall struct {
+	ethClient ethereum.Client
+	calls     map[string]contractCall
+	blocks    []int64
+}
+
+func NewContractCall(cli ethereum.Client, pairs map[string]ContractCallPair, blocks []int64) (*ContractCall, error) {
+	calls := map[string]contractCall{}
+	for name, p := range pairs {
+		c, err := newContractCall(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract call for pair %s: %w", name, err)
+		}
+		calls[name] = c
+	}
+	return &ContractCall{
+		ethClient: cli,
+		calls:     calls,
+		blocks:    blocks,
+	}, nil
+}
+
+func newContractCall(p ContractCallPair) (contractCall, error) {
+	a, err := abi.JSON(strings.NewReader(p.ABI))
+	if err != nil {
+		return contractCall{}, fmt.Errorf("unable to parse ABI: %w", err)
+	}
+	m, ok := a.Methods[p.Method]
+	if !ok {
+		return contractCall{}, fmt.Errorf("method %s not found in ABI", p.Method)
+	}
+	if len(p.Args) != len(m.Inputs) {
+		return contractCall{}, fmt.Errorf("method %s expects %d arguments, got %d", p.Method, len(m.Inputs), len(p.Args))
+	}
+	if p.Output < 0 || p.Output >= len(m.Outputs) {
+		return contractCall{}, fmt.Errorf("method %s has no output %d", p.Method, p.Output)
+	}
+	if p.Decimals < 0 {
+		return contractCall{}, errors.New("decimals must not be negative")
+	}
+	var args []interface{}
+	for i, arg := range p.Args {
+		v, err := parseABIArg(m.Inputs[i].Type, arg)
+		if err != nil {
+			return contractCall{}, fmt.Errorf("invalid argument %d: %w", i, err)
+		}
+		args = append(args, v)
+	}
+	data, err := a.Pack(p.Method, args...)
+	if err != nil {
+		return contractCall{}, err
+	}
+	scale := p.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	return contractCall{
+		address:  p.Address,
+		abi:      a,
+		method:   p.Method,
+		data:     data,
+		output:   p.Output,
+		decimals: p.Decimals,
+		scale:    scale,
+	}, nil
+}
+
+func (c ContractCall) PullPrices(pairs []Pair) []FetchResult {
+	return callSinglePairOrigin(&c, pairs)
+}
+
+func (c ContractCall) callOne(pair Pair) (*Price, error) {
+	inverted := false
+	call, ok := c.calls[pair.String()]
+	if !ok {
+		call, ok = c.calls[pair.Inverse().String()]
+		inverted = true
+	}
+	if !ok {
+		return nil, fmt.Errorf("failed to get contract call for pair: %s", pair.String())
+	}
+
+	var resp [][]byte
+	if len(c.blocks) > 0 {
+		r, err := c.ethClient.CallBlocks(context.Background(), ethereum.Call{Address: call.address, Data: call.data}, c.blocks)
+		if err != nil {
+			return nil, err
+		}
+		resp = r
+	} else {
+		r, err := c.ethClient.Call(context.Background(), ethereum.Call{Address: call.address, Data: call.data})
+		if err != nil {
+			return nil, err
+		}
+		resp = [][]byte{r}
+	}
+	if len(resp) == 0 {
+		return nil, ErrEmptyOriginResponse
+	}
+
+	var total decimal.Decimal
+	for _, r := range resp {
+		v, err := call.value(r)
+		if err != nil {
+			return nil, err
+		}
+		total = total.Add(v)
+	}
+	price := total.
+		Quo(decimal.FromInt64(int64(len(resp)))).
+		Mul(decimal.FromBigInt(big.NewInt(1), call.decimals)).
+		Mul(decimal.FromFloat64(call.scale))
+	if inverted {
+		if price.Sign() == 0 {
+			return nil, ErrInvalidPrice
+		}
+		price = price.Inv()
+	}
+	return &Price{
+		Pair:         pair,
+		Price:        price.Float64(),
+		DecimalPrice: price,
+		Timestamp:    time.Now(),
+	}, nil
+}
+
+// value unpacks the method output that contains the price.
+func (c contractCall) value(data []byte) (decimal.Decimal, error) {
+	out, err := c.abi.Unpack(c.method, data)
+	if err != nil {
+		return decimal.Decimal{}, fmt.Errorf("failed to unpack %s response: %w", c.method, err)
+	}
+	if c.output >= len(out) {
+		return decimal.Decimal{}, ErrEmptyOriginResponse
+	}
+	switch v := out[c.output].(type) {
+	case *big.Int:
+		return decimal.FromBigInt(v, 0), nil
+	default:
+		r := reflect.ValueOf(v)
+		switch r.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return decimal.FromInt64(r.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return decimal.FromBigInt(new(big.Int).SetUint64(r.Uint()), 0), nil
+		}
+		return decimal.Decimal{}, fmt.Errorf("output %d of %s is not a number", c.output, c.method)
+	}
+}
+
+// parseABIArg converts a string to a value of the given ABI type.
+func parseABIArg(typ abi.Type, arg string) (interface{}, error) {
+	switch typ.T {
+	case abi.AddressTy:
+		if !ethereum.IsHexAddress(arg) {
+			return nil, fmt.Errorf("invalid address: %s", arg)
+		}
+		return ethereum.HexToAddress(arg), nil
+	case abi.BoolTy:
+		return strconv.ParseBool(arg)
+	case abi.StringTy:
+		return arg, nil
+	case abi.IntTy, abi.UintTy:
+		n, ok := new(big.Int).SetString(arg, 0)
+		if !ok {
+			return nil, fmt.Errorf("invalid number: %s", arg)
+		}
+		if typ.Size > 64 {
+			return n, nil
+		}
+		v := reflect.New(typ.GetType()).Elem()
+		if typ.T == abi.IntTy {
+			if !n.IsInt64() || v.OverflowInt(n.Int64()) {
+				return nil, fmt.Errorf("number out of range: %s", arg)
+			}
+			v.SetInt(n.Int64())
+		} else {
+			if !n.IsUint64() || v.OverflowUint(n.Uint64()) {
+				return nil, fmt.Errorf("number out of range: %s", arg)
+			}
+			v.SetUint(n.Uint64())
+		}
+		return v.Interface(), nil
+	case abi.BytesTy:
+		return hexutil.Decode(arg)
+	case abi.FixedBytesTy:
+		b, err := hexutil.Decode(arg)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) != typ.Size {
+			return nil, fmt.Errorf("expected %d bytes, got %d", typ.Size, len(b))
+		}
+		v := reflect.New(typ.GetType()).Elem()
+		reflect.Copy(v, reflect.ValueOf(b))
+		return v.Interface(), nil
+	default:
+		return nil, fmt.Errorf("unsupported argument type: %s", typ.String())
+	}
+}
@@ -0,0 +1,133 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	ethereumMocks "github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+)
+
+const testContractCallABI = `[
+	{"inputs":[],"name":"stEthPerToken","outputs":[{"name":"","type":"uint256"}],"stateMutability":"view","type":"function"},
+	{"inputs":[{"name":"token","type":"address"},{"name":"precision","type":"uint8"}],"name":"getRate","outputs":[{"name":"ok","type":"bool"},{"name":"rate","type":"int64"}],"stateMutability":"view","type":"function"}
+]`
+
+func TestContractCall_CallBlocks(t *testing.T) {
+	cli := &ethereumMocks.Client{}
+	address := ethereum.HexToAddress("0x7f39C581F595B53c5cb19bD0b3f8dA6c935E2Ca0")
+	origin, err := NewContractCall(cli, map[string]ContractCallPair{
+		"WSTETH/STETH": {
+			Address:  address,
+			ABI:      testContractCallABI,
+			Method:   "stEthPerToken",
+			Decimals: 18,
+		},
+	}, []int64{0, 10, 20})
+	require.NoError(t, err)
+
+	resp := [][]byte{
+		common.BigToHash(big.NewInt(0.94 * 1e18)).Bytes(),
+		common.BigToHash(big.NewInt(0.98 * 1e18)).Bytes(),
+		common.BigToHash(big.NewInt(0.99 * 1e18)).Bytes(),
+	}
+	cli.On(
+		"CallBlocks",
+		mock.Anything,
+		ethereum.Call{Address: address, Data: ethereum.HexToBytes("0x035faf82")},
+		[]int64{0, 10, 20},
+	).Return(resp, nil).Twice()
+
+	frs := NewBaseExchangeHandler(origin, nil).Fetch([]Pair{{Base: "WSTETH", Quote: "STETH"}})
+	require.NoError(t, frs[0].Error)
+	assert.Equal(t, 0.97, frs[0].Price.Price)
+
+	frs = NewBaseExchangeHandler(origin, nil).Fetch([]Pair{{Base: "STETH", Quote: "WSTETH"}})
+	require.NoError(t, frs[0].Error)
+	assert.InDelta(t, 1/0.97, frs[0].Price.Price, 1e-12)
+}
+
+func TestContractCall_CallWithArgs(t *testing.T) {
+	cli := &ethereumMocks.Client{}
+	address := ethereum.HexToAddress("0x7f39C581F595B53c5cb19bD0b3f8dA6c935E2Ca0")
+	origin, err := NewContractCall(cli, map[string]ContractCallPair{
+		"A/B": {
+			Address:  address,
+			ABI:      testContractCallABI,
+			Method:   "getRate",
+			Args:     []string{"0x2d800d93b065ce011af83f316cef9f0d005b0aa4", "8"},
+			Output:   1,
+			Decimals: 8,
+			Scale:    2,
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	a, err := abi.JSON(strings.NewReader(testContractCallABI))
+	require.NoError(t, err)
+	data, err := a.Pack("getRate", ethereum.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4"), uint8(8))
+	require.NoError(t, err)
+	out, err := a.Methods["getRate"].Outputs.Pack(true, int64(150000000))
+	require.NoError(t, err)
+	cli.On("Call", mock.Anything, ethereum.Call{Address: address, Data: data}).Return(out, nil).Once()
+
+	frs := origin.PullPrices([]Pair{{Base: "A", Quote: "B"}})
+	require.NoError(t, frs[0].Error)
+	assert.Equal(t, 3.0, frs[0].Price.Price)
+}
+
+func TestContractCall_Errors(t *testing.T) {
+	cli := &ethereumMocks.Client{}
+	origin, err := NewContractCall(cli, map[string]ContractCallPair{
+		"A/B": {ABI: testContractCallABI, Method: "stEthPerToken"},
+	}, nil)
+	require.NoError(t, err)
+
+	frs := origin.PullPrices([]Pair{{Base: "x", Quote: "y"}})
+	assert.EqualError(t, frs[0].Error, "failed to get contract call for pair: x/y")
+
+	cli.On("Call", mock.Anything, mock.Anything).Return([]byte(nil), errors.New("error")).Once()
+	frs = origin.PullPrices([]Pair{{Base: "A", Quote: "B"}})
+	assert.EqualError(t, frs[0].Error, "error")
+}
+
+func TestNewContractCall_InvalidConfig(t *testing.T) {
+	tests := map[string]ContractCallPair{
+		"invalid ABI":         {ABI: "[", Method: "stEthPerToken"},
+		"unknown method":      {ABI: testContractCallABI, Method: "foo"},
+		"missing arguments":   {ABI: testContractCallABI, Method: "getRate"},
+		"invalid address":     {ABI: testContractCallABI, Method: "getRate", Args: []string{"0x1", "8"}, Output: 1},
+		"number out of range": {ABI: testContractCallABI, Method: "getRate", Args: []string{"0x2d800d93b065ce011af83f316cef9f0d005b0aa4", "256"}, Output: 1},
+		"invalid output":      {ABI: testContractCallABI, Method: "stEthPerToken", Output: 1},
+		"negative decimals":   {ABI: testContractCallABI, Method: "stEthPerToken", Decimals: -1},
+	}
+	for name, p := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewContractCall(nil, map[string]ContractCallPair{"A/B": p}, nil)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,213 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
+)
+
+// GenericJSON is a configurable origin which fetches prices from any REST API
+// that returns JSON responses. A separate request is made for every pair.
+//
+// The URL, Body and all paths may contain the ${base} and ${quote}
+// placeholders which are replaced with the pair symbols. Paths use dot
+// notation, array elements may be accessed either as "list.0" or "list[0]".
+type GenericJSON struct {
+	WorkerPool query.WorkerPool
+	URL        string
+	Method     string
+	Headers    map[string]string
+	Body       string
+	// Paths to values in the JSON response. Only PricePath is required.
+	// If TimestampPath is empty, the current time is used.
+	PricePath     string
+	BidPath       string
+	AskPath       string
+	VolumePath    string
+	TimestampPath string
+}
+
+func (g GenericJSON) Pool() query.WorkerPool {
+	return g.WorkerPool
+}
+
+func (g GenericJSON) PullPrices(pairs []Pair) []FetchResult {
+	return callSinglePairOrigin(&g, pairs)
+}
+
+func (g *GenericJSON) callOne(pair Pair) (*Price, error) {
+	if g.URL == "" {
+		return nil, errors.New("URL is not specified")
+	}
+	if g.PricePath == "" {
+		return nil, errors.New("price path is not specified")
+	}
+	req := &query.HTTPRequest{
+		URL:     g.replace(g.URL, pair),
+		Method:  g.Method,
+		Headers: g.Headers,
+	}
+	if g.Body != "" {
+		req.Body = strings.NewReader(g.replace(g.Body, pair))
+	}
+	res := g.WorkerPool.Query(req)
+	if res == nil {
+		return nil, ErrEmptyOriginResponse
+	}
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	// Numbers are decoded as json.Number to parse prices without losing
+	// precision.
+	var data interface{}
+	dec := json.NewDecoder(bytes.NewReader(res.Body))
+	dec.UseNumber()
+	if err := dec.Decode(&data); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	price := &Price{Pair: pair, Timestamp: time.Now()}
+	var err error
+	if price.DecimalPrice, err = g.number(data, g.PricePath, pair); err != nil {
+		return nil, err
+	}
+	price.Price = price.DecimalPrice.Float64()
+	if price.Bid, err = g.float(data, g.BidPath, pair); err != nil {
+		return nil, err
+	}
+	if price.Ask, err = g.float(data, g.AskPath, pair); err != nil {
+		return nil, err
+	}
+	if price.Volume24h, err = g.float(data, g.VolumePath, pair); err != nil {
+		return nil, err
+	}
+	if g.TimestampPath != "" {
+		v, err := jsonPath(data, g.replace(g.TimestampPath, pair))
+		if err != nil {
+			return nil, err
+		}
+		if price.Timestamp, err = jsonTimestamp(v); err != nil {
+			return nil, fmt.Errorf("invalid timestamp at path %s: %w", g.TimestampPath, err)
+		}
+	}
+	return price, nil
+}
+
+func (g *GenericJSON) replace(s string, pair Pair) string {
+	return strings.NewReplacer("${base}", pair.Base, "${quote}", pair.Quote).Replace(s)
+}
+
+// float returns a number at the given path. If the path is empty, zero
+// is returned.
+func (g *GenericJSON) float(data interface{}, path string, pair Pair) (float64, error) {
+	d, err := g.number(data, path, pair)
+	if err != nil {
+		return 0, err
+	}
+	return d.Float64(), nil
+}
+
+// number returns an exact number at the given path. If the path is empty,
+// zero is returned.
+func (g *GenericJSON) number(data interface{}, path string, pair Pair) (decimal.Decimal, error) {
+	if path == "" {
+		return decimal.Decimal{}, nil
+	}
+	v, err := jsonPath(data, g.replace(path, pair))
+	if err != nil {
+		return decimal.Decimal{}, err
+	}
+	switch t := v.(type) {
+	case json.Number:
+		return decimal.FromString(t.String())
+	case float64:
+		return decimal.FromFloat64(t), nil
+	case string:
+		d, err := decimal.FromString(t)
+		if err != nil {
+			return decimal.Decimal{}, fmt.Errorf("invalid number at path %s: %w", path, err)
+		}
+		return d, nil
+	default:
+		return decimal.Decimal{}, fmt.Errorf("invalid number at path %s", path)
+	}
+}
+
+// jsonPath returns a value from decoded JSON data at the given path.
+func jsonPath(data interface{}, path string) (interface{}, error) {
+	path = strings.NewReplacer("[", ".", "]", "").Replace(path)
+	v := data
+	for _, key := range strings.Split(path, ".") {
+		if key == "" {
+			continue
+		}
+		switch t := v.(type) {
+		case map[string]interface{}:
+			e, ok := t[key]
+			if !ok {
+				return nil, fmt.Errorf("%w: missing key %s in path %s", ErrMissingResponseForPair, key, path)
+			}
+			v = e
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(t) {
+				return nil, fmt.Errorf("%w: invalid index %s in path %s", ErrMissingResponseForPair, key, path)
+			}
+			v = t[i]
+		default:
+			return nil, fmt.Errorf("%w: unable to find key %s in path %s", ErrMissingResponseForPair, key, path)
+		}
+	}
+	return v, nil
+}
+
+// jsonTimestamp converts a timestamp from a JSON value. Numbers are treated
+// as Unix timestamps in seconds, or in milliseconds if they are too large to
+// be seconds. Strings may be either numbers or RFC 3339 dates.
+func jsonTimestamp(v interface{}) (time.Time, error) {
+	var n float64
+	switch t := v.(type) {
+	case float64:
+		n = t
+	case json.Number:
+		f, err := t.Float64()
+		if err != nil {
+			return time.Time{}, err
+		}
+		n = f
+	case string:
+		f, err := strconv.ParseFloat(t, 64)
+		if err != nil {
+			return time.Parse(time.RFC3339, t)
+		}
+		n = f
+	default:
+		return time.Time{}, errors.New("unsupported type")
+	}
+	if n > 1e11 {
+		return time.UnixMilli(int64(n)), nil
+	}
+	return time.Unix(int64(n), 0), nil
+}
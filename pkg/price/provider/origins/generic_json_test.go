@@ -0,0 +1,142 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
+)
+
+func TestGenericJSON_SuccessResponse(t *testing.T) {
+	pool := query.NewMockWorkerPool()
+	origin := NewBaseExchangeHandler(GenericJSON{
+		WorkerPool:    pool,
+		URL:           "https://example.com/ticker?symbol=${base}-${quote}",
+		Method:        "POST",
+		Headers:       map[string]string{"X-Key": "key"},
+		Body:          `{"pair":"${base}${quote}"}`,
+		PricePath:     "data.${base}${quote}.last",
+		BidPath:       "data.${base}${quote}.book[0]",
+		AskPath:       "data.${base}${quote}.book.1",
+		VolumePath:    "data.${base}${quote}.volume",
+		TimestampPath: "time",
+	}, SymbolAliases{"BTC": "XBT"})
+
+	pool.SetRequestAssertions(func(req *query.HTTPRequest) {
+		assert.Equal(t, "https://example.com/ticker?symbol=XBT-USD", req.URL)
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, map[string]string{"X-Key": "key"}, req.Headers)
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, `{"pair":"XBTUSD"}`, string(body))
+	})
+	pool.MockBody(`{"time":1650000000123,"data":{"XBTUSD":{"last":"40000.5","book":[40000,"40001"],"volume":12.5}}}`)
+
+	frs := origin.Fetch([]Pair{{Base: "BTC", Quote: "USD"}})
+	require.Len(t, frs, 1)
+	require.NoError(t, frs[0].Error)
+	assert.Equal(t, Pair{Base: "BTC", Quote: "USD"}, frs[0].Price.Pair)
+	assert.Equal(t, 40000.5, frs[0].Price.Price)
+	assert.Equal(t, 40000.0, frs[0].Price.Bid)
+	assert.Equal(t, 40001.0, frs[0].Price.Ask)
+	assert.Equal(t, 12.5, frs[0].Price.Volume24h)
+	assert.Equal(t, time.UnixMilli(1650000000123), frs[0].Price.Timestamp)
+}
+
+func TestGenericJSON_Errors(t *testing.T) {
+	pool := query.NewMockWorkerPool()
+	origin := GenericJSON{WorkerPool: pool, URL: "https://example.com", PricePath: "price"}
+	pair := Pair{Base: "A", Quote: "B"}
+
+	// Empty response
+	assert.Equal(t, ErrEmptyOriginResponse, origin.PullPrices([]Pair{pair})[0].Error)
+
+	// Request error
+	pool.MockResp(&query.HTTPResponse{Error: errors.New("error")})
+	assert.EqualError(t, origin.PullPrices([]Pair{pair})[0].Error, "error")
+
+	// Invalid JSON
+	pool.MockBody("{")
+	assert.Error(t, origin.PullPrices([]Pair{pair})[0].Error)
+
+	// Missing key
+	pool.MockBody(`{"last":1}`)
+	assert.ErrorIs(t, origin.PullPrices([]Pair{pair})[0].Error, ErrMissingResponseForPair)
+
+	// Invalid number
+	pool.MockBody(`{"price":"abc"}`)
+	assert.Error(t, origin.PullPrices([]Pair{pair})[0].Error)
+
+	// Missing price path
+	origin.PricePath = ""
+	assert.Error(t, origin.PullPrices([]Pair{pair})[0].Error)
+}
+
+func Test_jsonPath(t *testing.T) {
+	data := map[string]interface{}{
+		"a": []interface{}{map[string]interface{}{"b": 1.0}, "c"},
+	}
+	tests := []struct {
+		path    string
+		want    interface{}
+		wantErr bool
+	}{
+		{path: "a[0].b", want: 1.0},
+		{path: "a.0.b", want: 1.0},
+		{path: "a[1]", want: "c"},
+		{path: "a[2]", wantErr: true},
+		{path: "a.x", wantErr: true},
+		{path: "a[1].b", wantErr: true},
+		{path: "b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			v, err := jsonPath(data, tt.path)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, v)
+			}
+		})
+	}
+}
+
+func Test_jsonTimestamp(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  time.Time
+	}{
+		{value: 1650000000.0, want: time.Unix(1650000000, 0)},
+		{value: 1650000000123.0, want: time.UnixMilli(1650000000123)},
+		{value: "1650000000", want: time.Unix(1650000000, 0)},
+		{value: "2022-04-15T05:20:00Z", want: time.Date(2022, 4, 15, 5, 20, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		ts, err := jsonTimestamp(tt.value)
+		require.NoError(t, err)
+		assert.True(t, tt.want.Equal(ts), "%v != %v", tt.want, ts)
+	}
+	_, err := jsonTimestamp(true)
+	assert.Error(t, err)
+}
@@ -0,0 +1,151 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const krakenWSURL = "wss://ws.kraken.com"
+
+// NewKrakenStream returns a streaming origin handler that subscribes to
+// the Kraken ticker channel.
+func NewKrakenStream(url string, aliases SymbolAliases) *WebSocketStream {
+	if url == "" {
+		url = krakenWSURL
+	}
+	return newWebSocketStream(url, krakenStream{}, aliases)
+}
+
+type krakenStream struct{}
+
+type krakenStreamSubscribe struct {
+	Event        string   `json:"event"`
+	Pair         []string `json:"pair"`
+	Subscription struct {
+		Name string `json:"name"`
+	} `json:"subscription"`
+}
+
+type krakenStreamEvent struct {
+	Event        string `json:"event"`
+	Status       string `json:"status"`
+	Pair         string `json:"pair"`
+	ErrorMessage string `json:"errorMessage"`
+}
+
+type krakenStreamTicker struct {
+	Price  krakenStreamValues `json:"c"`
+	Bid    krakenStreamValues `json:"b"`
+	Ask    krakenStreamValues `json:"a"`
+	Volume krakenStreamValues `json:"v"`
+}
+
+// krakenStreamValues is a list of values in which numbers are encoded
+// either as strings or as JSON numbers.
+type krakenStreamValues []float64
+
+func (k *krakenStreamValues) UnmarshalJSON(bytes []byte) error {
+	var vs []interface{}
+	if err := json.Unmarshal(bytes, &vs); err != nil {
+		return err
+	}
+	*k = make([]float64, len(vs))
+	for i, v := range vs {
+		switch t := v.(type) {
+		case string:
+			f, err := strconv.ParseFloat(t, 64)
+			if err != nil {
+				return err
+			}
+			(*k)[i] = f
+		case float64:
+			(*k)[i] = t
+		default:
+			return fmt.Errorf("unexpected value type %T", v)
+		}
+	}
+	return nil
+}
+
+func (k krakenStreamValues) at(i int) float64 {
+	if i >= len(k) {
+		return 0
+	}
+	return k[i]
+}
+
+func (krakenStream) localPairName(pair Pair) string {
+	return pair.String()
+}
+
+func (k krakenStream) subscribe(pairs []Pair) []interface{} {
+	s := krakenStreamSubscribe{Event: "subscribe"}
+	s.Subscription.Name = "ticker"
+	for _, p := range pairs {
+		s.Pair = append(s.Pair, k.localPairName(p))
+	}
+	return []interface{}{s}
+}
+
+func (k krakenStream) parse(pairs []Pair, msg []byte) ([]Price, error) {
+	// Events are sent as objects, and channel data as arrays:
+	// [channelID, ticker, channelName, pair].
+	var data []json.RawMessage
+	if err := json.Unmarshal(msg, &data); err != nil {
+		var e krakenStreamEvent
+		if err := json.Unmarshal(msg, &e); err != nil {
+			return nil, fmt.Errorf("failed to parse message: %w", err)
+		}
+		if e.Event == "subscriptionStatus" && e.Status == "error" {
+			err := fmt.Errorf("kraken error: %s: %s", e.Pair, e.ErrorMessage)
+			if pair, ok := findPair(pairs, e.Pair, k.localPairName); ok {
+				return nil, &streamPairError{pair: pair, err: err}
+			}
+			return nil, err
+		}
+		return nil, nil
+	}
+	if len(data) != 4 {
+		return nil, nil
+	}
+	var name, local string
+	if err := json.Unmarshal(data[2], &name); err != nil || name != "ticker" {
+		return nil, nil
+	}
+	if err := json.Unmarshal(data[3], &local); err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+	pair, ok := findPair(pairs, local, k.localPairName)
+	if !ok {
+		return nil, nil
+	}
+	var t krakenStreamTicker
+	if err := json.Unmarshal(data[1], &t); err != nil {
+		return nil, fmt.Errorf("failed to parse message: %w", err)
+	}
+	return []Price{{
+		Pair:      pair,
+		Price:     t.Price.at(0),
+		Bid:       t.Bid.at(0),
+		Ask:       t.Ask.at(0),
+		Volume24h: t.Volume.at(1),
+		Timestamp: time.Now(),
+	}}, nil
+}
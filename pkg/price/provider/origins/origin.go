@@ -21,6 +21,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 	"github.com/chronicleprotocol/oracle-suite/pkg/util/query"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
@@ -160,6 +161,10 @@ type Price struct {
 	Ask       float64
 	Volume24h float64
 	Timestamp time.Time
+	// DecimalPrice is the exact price. It should be set by origins that
+	// can provide the price with a higher precision than float64, e.g.
+	// on-chain origins. If it is zero, Price is used instead.
+	DecimalPrice decimal.Decimal
 }
 
 type FetchResult struct {
@@ -325,17 +330,17 @@ func buildOriginURL(template, configURL, defaultURL string, a ...interface{}) st
 	return fmt.Sprintf(template, replacement...)
 }
 
-func reduceEtherAverageFloat(r [][]byte) *big.Float {
-	total := new(big.Float).SetInt64(0)
+// etherDecimals is the number of decimals used by most ERC20 tokens.
+const etherDecimals = 18
+
+func reduceEtherAverageDecimal(r [][]byte) decimal.Decimal {
+	var total decimal.Decimal
 	for _, resp := range r {
 		// TODO(jamesr) Always uint256, so even if resp is larger, truncate.
 		// However, this assumes that we only care about the first 32 bytes.
 		// You might want the last 32... perhaps revisit this.
 		price := new(big.Int).SetBytes(resp[0:32])
-		total = new(big.Float).Add(
-			total,
-			new(big.Float).Quo(new(big.Float).SetInt(price), new(big.Float).SetUint64(ether)),
-		)
+		total = total.Add(decimal.FromBigInt(price, etherDecimals))
 	}
-	return new(big.Float).Quo(total, new(big.Float).SetUint64(uint64(len(r))))
+	return total.Quo(decimal.FromInt64(int64(len(r))))
 }
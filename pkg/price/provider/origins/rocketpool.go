@@ -76,11 +76,12 @@ func (s RocketPool) callOne(pair Pair) (*Price, error) {
 	if err != nil {
 		return nil, err
 	}
-	price, _ := reduceEtherAverageFloat(resp).Float64()
+	price := reduceEtherAverageDecimal(resp)
 
 	return &Price{
-		Pair:      pair,
-		Price:     price,
-		Timestamp: time.Now(),
+		Pair:         pair,
+		Price:        price.Float64(),
+		DecimalPrice: price,
+		Timestamp:    time.Now(),
 	}, nil
 }
@@ -0,0 +1,322 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+var ErrStreamDisconnected = errors.New("stream disconnected")
+var ErrNoStreamedPrice = errors.New("no price received from stream yet")
+
+const (
+	defaultStreamMinBackoff   = time.Second
+	defaultStreamMaxBackoff   = time.Minute
+	defaultStreamReadTimeout  = time.Minute
+	defaultStreamFetchTimeout = 10 * time.Second
+)
+
+// StreamHandler is implemented by origins which push prices over a
+// persistent connection instead of being polled.
+type StreamHandler interface {
+	Handler
+	// Subscribe subscribes to prices for the given pairs. Every received
+	// tick is sent to the returned channel. If the connection is lost, an
+	// error result is sent for every pair and the handler reconnects.
+	// The channel is closed after the context is canceled.
+	Subscribe(ctx context.Context, pairs []Pair) (<-chan FetchResult, error)
+}
+
+// streamProtocol implements exchange specific parts of a WebSocket stream.
+type streamProtocol interface {
+	// subscribe returns messages that have to be sent after connecting
+	// to subscribe to the given pairs.
+	subscribe(pairs []Pair) []interface{}
+	// parse parses a message received from the stream. Messages that do
+	// not contain prices, like heartbeats, must return no prices and no
+	// error. If the message reports an error for a single pair, the error
+	// must be a *streamPairError.
+	parse(pairs []Pair, msg []byte) ([]Price, error)
+}
+
+// streamPairError is an error reported by the stream for a single pair.
+type streamPairError struct {
+	pair Pair
+	err  error
+}
+
+func (e *streamPairError) Error() string {
+	return e.err.Error()
+}
+
+func (e *streamPairError) Unwrap() error {
+	return e.err
+}
+
+// WebSocketStream is a streaming origin handler. It keeps a single
+// WebSocket connection per subscription, caches the latest price for every
+// pair and reconnects with an exponential backoff if the connection is lost.
+// Pairs without an active subscription are fetched using a short-lived
+// connection.
+type WebSocketStream struct {
+	mu sync.RWMutex
+
+	url      string
+	protocol streamProtocol
+	aliases  SymbolAliases
+	prices   map[string]Price
+	streamed map[string]int // Number of active subscriptions per pair.
+
+	minBackoff   time.Duration
+	maxBackoff   time.Duration
+	readTimeout  time.Duration
+	fetchTimeout time.Duration
+}
+
+func newWebSocketStream(url string, protocol streamProtocol, aliases SymbolAliases) *WebSocketStream {
+	return &WebSocketStream{
+		url:          url,
+		protocol:     protocol,
+		aliases:      aliases,
+		prices:       map[string]Price{},
+		streamed:     map[string]int{},
+		minBackoff:   defaultStreamMinBackoff,
+		maxBackoff:   defaultStreamMaxBackoff,
+		readTimeout:  defaultStreamReadTimeout,
+		fetchTimeout: defaultStreamFetchTimeout,
+	}
+}
+
+// Subscribe implements the StreamHandler interface.
+func (s *WebSocketStream) Subscribe(ctx context.Context, pairs []Pair) (<-chan FetchResult, error) {
+	if len(pairs) == 0 {
+		return nil, errors.New("no pairs to subscribe")
+	}
+	s.mu.Lock()
+	for _, p := range pairs {
+		s.streamed[p.String()]++
+	}
+	s.mu.Unlock()
+	ch := make(chan FetchResult)
+	go func() {
+		s.run(ctx, pairs, ch)
+		s.mu.Lock()
+		for _, p := range pairs {
+			if s.streamed[p.String()]--; s.streamed[p.String()] <= 0 {
+				delete(s.streamed, p.String())
+			}
+		}
+		s.mu.Unlock()
+	}()
+	return ch, nil
+}
+
+// Fetch implements the Handler interface. For subscribed pairs, it returns
+// the latest prices received from the stream, waiting for the first tick if
+// necessary. Other pairs are fetched using a connection that is closed as
+// soon as prices for all of them are received or the fetch timeout expires.
+func (s *WebSocketStream) Fetch(pairs []Pair) []FetchResult {
+	var streamed, missing []Pair
+	s.mu.RLock()
+	for _, p := range pairs {
+		if s.streamed[p.String()] > 0 {
+			streamed = append(streamed, p)
+		} else {
+			missing = append(missing, p)
+		}
+	}
+	s.mu.RUnlock()
+	fetched := map[string]Price{}
+	if len(missing) > 0 {
+		fetched = s.fetchOnce(missing)
+	}
+	deadline := time.Now().Add(s.fetchTimeout)
+	for !s.hasPrices(streamed) && time.Now().Before(deadline) {
+		time.Sleep(50 * time.Millisecond)
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var frs []FetchResult
+	for _, p := range pairs {
+		if price, ok := fetched[p.String()]; ok {
+			frs = append(frs, fetchResult(price))
+		} else if price, ok := s.prices[p.String()]; ok && s.streamed[p.String()] > 0 {
+			frs = append(frs, fetchResult(price))
+		} else {
+			frs = append(frs, fetchResultWithError(p, ErrNoStreamedPrice))
+		}
+	}
+	return frs
+}
+
+// fetchOnce connects to the stream and returns the first prices received
+// for the given pairs. The connection is closed before the method returns.
+func (s *WebSocketStream) fetchOnce(pairs []Pair) map[string]Price {
+	ctx, cancel := context.WithTimeout(context.Background(), s.fetchTimeout)
+	defer cancel()
+	ch := make(chan FetchResult)
+	go s.run(ctx, pairs, ch)
+	prices := map[string]Price{}
+	for fr := range ch {
+		if fr.Error == nil {
+			prices[fr.Price.Pair.String()] = fr.Price
+		}
+		if len(prices) == len(pairs) {
+			// The channel is closed after the connection is closed:
+			cancel()
+		}
+	}
+	return prices
+}
+
+func (s *WebSocketStream) hasPrices(pairs []Pair) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, p := range pairs {
+		if _, ok := s.prices[p.String()]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// run keeps the connection open until the context is canceled.
+func (s *WebSocketStream) run(ctx context.Context, pairs []Pair, ch chan FetchResult) {
+	defer close(ch)
+	var renamed []Pair
+	for _, p := range pairs {
+		renamed = append(renamed, s.aliases.replacePair(p))
+	}
+	backoff := s.minBackoff
+	for {
+		connected, err := s.connect(ctx, renamed, ch)
+		if ctx.Err() != nil {
+			return
+		}
+		if connected {
+			backoff = s.minBackoff
+		}
+		for _, p := range pairs {
+			if !s.send(ctx, ch, fetchResultWithError(p, fmt.Errorf("%w: %v", ErrStreamDisconnected, err))) {
+				return
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+		if backoff > s.maxBackoff {
+			backoff = s.maxBackoff
+		}
+	}
+}
+
+// connect opens a connection, subscribes to the pairs and reads messages
+// until an error occurs. The returned bool is true if the connection was
+// established.
+func (s *WebSocketStream) connect(ctx context.Context, pairs []Pair, ch chan FetchResult) (bool, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, s.url, nil)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	for _, msg := range s.protocol.subscribe(pairs) {
+		if err := conn.WriteJSON(msg); err != nil {
+			return true, err
+		}
+	}
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(s.readTimeout)); err != nil {
+			return true, err
+		}
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return true, err
+		}
+		prices, err := s.protocol.parse(pairs, msg)
+		if err != nil {
+			// Invalid messages and errors reported by the stream do not
+			// mean that the connection is broken, so they are reported
+			// as results for the affected pairs and reading continues.
+			if !s.sendParseError(ctx, ch, pairs, err) {
+				return true, ctx.Err()
+			}
+			continue
+		}
+		for _, price := range prices {
+			price.Pair = s.aliases.revertPair(price.Pair)
+			s.mu.Lock()
+			s.prices[price.Pair.String()] = price
+			s.mu.Unlock()
+			if !s.send(ctx, ch, fetchResult(price)) {
+				return true, ctx.Err()
+			}
+		}
+	}
+}
+
+// sendParseError sends the error returned by the protocol parser for every
+// pair it concerns. If the error does not concern a single pair, it is sent
+// for all pairs.
+func (s *WebSocketStream) sendParseError(ctx context.Context, ch chan FetchResult, pairs []Pair, err error) bool {
+	var pErr *streamPairError
+	if errors.As(err, &pErr) {
+		pairs = []Pair{pErr.pair}
+	}
+	for _, p := range pairs {
+		if !s.send(ctx, ch, fetchResultWithError(s.aliases.revertPair(p), err)) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *WebSocketStream) send(ctx context.Context, ch chan FetchResult, fr FetchResult) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case ch <- fr:
+		return true
+	}
+}
+
+// findPair returns a pair from the list for which the given function
+// returns a matching local name.
+func findPair(pairs []Pair, name string, localName func(Pair) string) (Pair, bool) {
+	for _, p := range pairs {
+		if localName(p) == name {
+			return p, true
+		}
+	}
+	return Pair{}, false
+}
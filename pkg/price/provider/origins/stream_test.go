@@ -0,0 +1,317 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// wsStub is a WebSocket server stub. For every connection it reads a single
+// subscription message and then sends the messages from the next element of
+// the sessions list. The connection is closed after all messages are sent.
+type wsStub struct {
+	mu         sync.Mutex
+	sessions   [][]string
+	subscribes []string
+	active     int // Number of open connections.
+	server     *httptest.Server
+}
+
+func newWSStub(sessions ...[]string) *wsStub {
+	s := &wsStub{sessions: sessions}
+	s.server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+func (s *wsStub) url() string {
+	return "ws" + strings.TrimPrefix(s.server.URL, "http")
+}
+
+func (s *wsStub) handle(w http.ResponseWriter, r *http.Request) {
+	conn, err := (&websocket.Upgrader{}).Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.active++
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		s.active--
+		s.mu.Unlock()
+	}()
+	defer conn.Close()
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.subscribes = append(s.subscribes, string(msg))
+	var session []string
+	if len(s.sessions) > 0 {
+		session, s.sessions = s.sessions[0], s.sessions[1:]
+	}
+	s.mu.Unlock()
+	for _, m := range session {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(m)); err != nil {
+			return
+		}
+	}
+	if len(session) == 0 {
+		// Keep the connection open if there is nothing more to send.
+		_, _, _ = conn.ReadMessage()
+	}
+}
+
+func (s *wsStub) subscriptions() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string{}, s.subscribes...)
+}
+
+func (s *wsStub) connections() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.active
+}
+
+func receive(t *testing.T, ch <-chan FetchResult) FetchResult {
+	select {
+	case fr := <-ch:
+		return fr
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for a stream result")
+		return FetchResult{}
+	}
+}
+
+func TestWebSocketStream_SubscribeAndReconnect(t *testing.T) {
+	srv := newWSStub(
+		[]string{
+			`{"result":null,"id":1}`,
+			`{"e":"24hrTicker","E":1650000000000,"s":"BTCUSDT","c":"40000.5","b":"40000","a":"40001","v":"1234.5","C":1650000000000,"B":"1","A":"2"}`,
+		},
+		[]string{
+			`{"e":"24hrTicker","E":1650000001000,"s":"BTCUSDT","c":"40010","b":"40009","a":"40011","v":"1235"}`,
+		},
+	)
+	defer srv.server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := NewBinanceStream(srv.url(), SymbolAliases{"USD": "USDT"})
+	stream.minBackoff = 10 * time.Millisecond
+	pair := Pair{Base: "BTC", Quote: "USD"}
+	ch, err := stream.Subscribe(ctx, []Pair{pair})
+	require.NoError(t, err)
+
+	fr := receive(t, ch)
+	require.NoError(t, fr.Error)
+	assert.Equal(t, pair, fr.Price.Pair)
+	assert.Equal(t, 40000.5, fr.Price.Price)
+	assert.Equal(t, 40000.0, fr.Price.Bid)
+	assert.Equal(t, 40001.0, fr.Price.Ask)
+	assert.Equal(t, 1234.5, fr.Price.Volume24h)
+	assert.Equal(t, time.Unix(1650000000, 0), fr.Price.Timestamp)
+
+	// The server closes the connection after sending all messages.
+	fr = receive(t, ch)
+	assert.ErrorIs(t, fr.Error, ErrStreamDisconnected)
+
+	fr = receive(t, ch)
+	require.NoError(t, fr.Error)
+	assert.Equal(t, 40010.0, fr.Price.Price)
+
+	// Fetch returns the latest price from the stream.
+	frs := stream.Fetch([]Pair{pair})
+	require.Len(t, frs, 1)
+	require.NoError(t, frs[0].Error)
+	assert.Equal(t, 40010.0, frs[0].Price.Price)
+
+	subs := srv.subscriptions()
+	require.GreaterOrEqual(t, len(subs), 2)
+	assert.JSONEq(t, `{"method":"SUBSCRIBE","params":["btcusdt@ticker"],"id":1}`, subs[0])
+
+	// The channel is closed after the context is canceled.
+	cancel()
+	for range ch { //nolint:revive
+	}
+}
+
+func TestWebSocketStream_ParseError(t *testing.T) {
+	srv := newWSStub(
+		[]string{
+			`{"event":"subscriptionStatus","status":"error","pair":"ETH/XXX","errorMessage":"Currency pair not supported"}`,
+			`not a json`,
+			`[340,{"c":["40000.5","0.1"]},"ticker","XBT/USD"]`,
+		},
+	)
+	defer srv.server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := NewKrakenStream(srv.url(), nil)
+	stream.minBackoff = time.Hour
+	xbtusd := Pair{Base: "XBT", Quote: "USD"}
+	ethxxx := Pair{Base: "ETH", Quote: "XXX"}
+	ch, err := stream.Subscribe(ctx, []Pair{xbtusd, ethxxx})
+	require.NoError(t, err)
+
+	// An error for a single pair is reported only for that pair:
+	fr := receive(t, ch)
+	assert.Equal(t, ethxxx, fr.Price.Pair)
+	assert.Error(t, fr.Error)
+	assert.NotErrorIs(t, fr.Error, ErrStreamDisconnected)
+
+	// Invalid messages are reported for all pairs:
+	for i := 0; i < 2; i++ {
+		fr = receive(t, ch)
+		assert.Error(t, fr.Error)
+		assert.NotErrorIs(t, fr.Error, ErrStreamDisconnected)
+	}
+
+	// The connection is still used after errors:
+	fr = receive(t, ch)
+	require.NoError(t, fr.Error)
+	assert.Equal(t, xbtusd, fr.Price.Pair)
+	assert.Equal(t, 40000.5, fr.Price.Price)
+	assert.Len(t, srv.subscriptions(), 1)
+}
+
+func TestWebSocketStream_Fetch(t *testing.T) {
+	srv := newWSStub(
+		[]string{
+			`{"type":"subscriptions","channels":[{"name":"ticker","product_ids":["ETH-USD"]}]}`,
+			`{"type":"ticker","product_id":"ETH-USD","price":"3000.1","best_bid":"3000","best_ask":"3000.2","volume_24h":"100","time":"2022-04-15T10:00:00.000000Z"}`,
+		},
+	)
+	defer srv.server.Close()
+
+	stream := NewCoinbaseProStream(srv.url(), nil)
+	stream.minBackoff = time.Hour
+	pair := Pair{Base: "ETH", Quote: "USD"}
+
+	frs := stream.Fetch([]Pair{pair})
+	require.Len(t, frs, 1)
+	require.NoError(t, frs[0].Error)
+	assert.Equal(t, pair, frs[0].Price.Pair)
+	assert.Equal(t, 3000.1, frs[0].Price.Price)
+	assert.Equal(t, time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC), frs[0].Price.Timestamp)
+}
+
+func TestWebSocketStream_FetchTimeout(t *testing.T) {
+	srv := newWSStub()
+	defer srv.server.Close()
+
+	stream := NewKrakenStream(srv.url(), nil)
+	stream.fetchTimeout = 100 * time.Millisecond
+	frs := stream.Fetch([]Pair{{Base: "BTC", Quote: "USD"}})
+	require.Len(t, frs, 1)
+	assert.ErrorIs(t, frs[0].Error, ErrNoStreamedPrice)
+
+	// The connection used by Fetch must be closed:
+	assert.Eventually(t, func() bool {
+		return srv.connections() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestBinanceStream(t *testing.T) {
+	pairs := []Pair{{Base: "BTC", Quote: "USDT"}, {Base: "ETH", Quote: "BTC"}}
+	p := binanceStream{}
+
+	b, err := json.Marshal(p.subscribe(pairs)[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"method":"SUBSCRIBE","params":["btcusdt@ticker","ethbtc@ticker"],"id":1}`, string(b))
+
+	prices, err := p.parse(pairs, []byte(`{"e":"24hrTicker","E":1650000000000,"s":"ETHBTC","c":"0.075","b":"0.074","a":"0.076","v":"10"}`))
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+	assert.Equal(t, pairs[1], prices[0].Pair)
+	assert.Equal(t, 0.075, prices[0].Price)
+
+	prices, err = p.parse(pairs, []byte(`{"result":null,"id":1}`))
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+
+	_, err = p.parse(pairs, []byte(`{"error":{"code":2,"msg":"Invalid request"},"id":1}`))
+	assert.Error(t, err)
+}
+
+func TestCoinbaseProStream(t *testing.T) {
+	pairs := []Pair{{Base: "BTC", Quote: "USD"}}
+	p := coinbaseProStream{}
+
+	b, err := json.Marshal(p.subscribe(pairs)[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"type":"subscribe","product_ids":["BTC-USD"],"channels":["ticker"]}`, string(b))
+
+	prices, err := p.parse(pairs, []byte(`{"type":"ticker","product_id":"BTC-USD","price":"40000","best_bid":"39999","best_ask":"40001","volume_24h":"5000","time":"2022-04-15T10:00:00Z"}`))
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+	assert.Equal(t, 40000.0, prices[0].Price)
+	assert.Equal(t, 39999.0, prices[0].Bid)
+	assert.Equal(t, 40001.0, prices[0].Ask)
+	assert.Equal(t, 5000.0, prices[0].Volume24h)
+
+	prices, err = p.parse(pairs, []byte(`{"type":"heartbeat"}`))
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+
+	_, err = p.parse(pairs, []byte(`{"type":"error","message":"Failed to subscribe","reason":"BTC-XXX is not a valid product"}`))
+	assert.Error(t, err)
+}
+
+func TestKrakenStream(t *testing.T) {
+	pairs := []Pair{{Base: "XBT", Quote: "USD"}}
+	p := krakenStream{}
+
+	b, err := json.Marshal(p.subscribe(pairs)[0])
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"event":"subscribe","pair":["XBT/USD"],"subscription":{"name":"ticker"}}`, string(b))
+
+	prices, err := p.parse(pairs, []byte(`[340,{"a":["40001.0",1,"1.000"],"b":["40000.0",2,"2.000"],"c":["40000.5","0.1"],"v":["100.0","2500.0"]},"ticker","XBT/USD"]`))
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+	assert.Equal(t, 40000.5, prices[0].Price)
+	assert.Equal(t, 40000.0, prices[0].Bid)
+	assert.Equal(t, 40001.0, prices[0].Ask)
+	assert.Equal(t, 2500.0, prices[0].Volume24h)
+
+	prices, err = p.parse(pairs, []byte(`{"event":"heartbeat"}`))
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+
+	_, err = p.parse(pairs, []byte(`{"event":"subscriptionStatus","status":"error","pair":"XBT/XXX","errorMessage":"Currency pair not supported"}`))
+	assert.Error(t, err)
+
+	var pErr *streamPairError
+	_, err = p.parse(pairs, []byte(`{"event":"subscriptionStatus","status":"error","pair":"XBT/USD","errorMessage":"Currency pair not supported"}`))
+	require.ErrorAs(t, err, &pErr)
+	assert.Equal(t, pairs[0], pErr.pair)
+}
@@ -0,0 +1,187 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+)
+
+//go:embed uniswap_v3_abi.json
+var uniswapV3ABI string
+
+const DefaultUniswapV3TWAPWindow = 5 * time.Minute
+
+// UniswapV3TWAP calculates a time-weighted average price using the observe
+// method of Uniswap V3 pools. Pairs in contract addresses must be written in
+// the same order as tokens in the pool (token0/token1).
+type UniswapV3TWAP struct {
+	ethClient ethereum.Client
+	addrs     ContractAddresses
+	abi       abi.ABI
+	window    time.Duration
+}
+
+func NewUniswapV3TWAP(cli ethereum.Client, addrs ContractAddresses, window time.Duration) (*UniswapV3TWAP, error) {
+	a, err := abi.JSON(strings.NewReader(uniswapV3ABI))
+	if err != nil {
+		return nil, err
+	}
+	if window == 0 {
+		window = DefaultUniswapV3TWAPWindow
+	}
+	if window < time.Second {
+		return nil, errors.New("TWAP window must be at least one second")
+	}
+	return &UniswapV3TWAP{
+		ethClient: cli,
+		addrs:     addrs,
+		abi:       a,
+		window:    window,
+	}, nil
+}
+
+func (u UniswapV3TWAP) PullPrices(pairs []Pair) []FetchResult {
+	return callSinglePairOrigin(&u, pairs)
+}
+
+func (u UniswapV3TWAP) callOne(pair Pair) (*Price, error) {
+	contract, inverted, err := u.addrs.AddressByPair(pair)
+	if err != nil {
+		return nil, err
+	}
+
+	window := uint32(u.window.Seconds())
+	token0Data, err := u.abi.Pack("token0")
+	if err != nil {
+		return nil, err
+	}
+	token1Data, err := u.abi.Pack("token1")
+	if err != nil {
+		return nil, err
+	}
+	observeData, err := u.abi.Pack("observe", []uint32{window, 0})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := u.ethClient.MultiCall(context.Background(), []ethereum.Call{
+		{Address: contract, Data: token0Data},
+		{Address: contract, Data: token1Data},
+		{Address: contract, Data: observeData},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != 3 {
+		return nil, ErrEmptyOriginResponse
+	}
+	token0, err := u.unpackAddress("token0", resp[0])
+	if err != nil {
+		return nil, err
+	}
+	token1, err := u.unpackAddress("token1", resp[1])
+	if err != nil {
+		return nil, err
+	}
+	observe, err := u.abi.Unpack("observe", resp[2])
+	if err != nil {
+		return nil, fmt.Errorf("failed to unpack observations for pair %s: %w", pair.String(), err)
+	}
+	tickCumulatives := observe[0].([]*big.Int)
+	if len(tickCumulatives) != 2 {
+		return nil, ErrEmptyOriginResponse
+	}
+
+	decimalsData, err := u.abi.Pack("decimals")
+	if err != nil {
+		return nil, err
+	}
+	resp, err = u.ethClient.MultiCall(context.Background(), []ethereum.Call{
+		{Address: token0, Data: decimalsData},
+		{Address: token1, Data: decimalsData},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp) != 2 {
+		return nil, ErrEmptyOriginResponse
+	}
+	decimals0, err := u.unpackDecimals(resp[0])
+	if err != nil {
+		return nil, err
+	}
+	decimals1, err := u.unpackDecimals(resp[1])
+	if err != nil {
+		return nil, err
+	}
+
+	price := uniswapV3TickPrice(twapTick(tickCumulatives[0], tickCumulatives[1], window), decimals0, decimals1)
+	if inverted {
+		price = 1 / price
+	}
+	if math.IsInf(price, 0) || math.IsNaN(price) || price <= 0 {
+		return nil, ErrInvalidPrice
+	}
+	return &Price{
+		Pair:      pair,
+		Price:     price,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+func (u UniswapV3TWAP) unpackAddress(method string, data []byte) (ethereum.Address, error) {
+	r, err := u.abi.Unpack(method, data)
+	if err != nil {
+		return ethereum.Address{}, fmt.Errorf("failed to unpack %s: %w", method, err)
+	}
+	return r[0].(ethereum.Address), nil
+}
+
+func (u UniswapV3TWAP) unpackDecimals(data []byte) (uint8, error) {
+	r, err := u.abi.Unpack("decimals", data)
+	if err != nil {
+		return 0, fmt.Errorf("failed to unpack decimals: %w", err)
+	}
+	return r[0].(uint8), nil
+}
+
+// twapTick returns the arithmetic mean tick over the window. As in the
+// Uniswap OracleLibrary, the result is rounded toward negative infinity.
+func twapTick(from, to *big.Int, window uint32) int64 {
+	delta := new(big.Int).Sub(to, from)
+	w := big.NewInt(int64(window))
+	tick, mod := new(big.Int).QuoRem(delta, w, new(big.Int))
+	if delta.Sign() < 0 && mod.Sign() != 0 {
+		tick.Sub(tick, big.NewInt(1))
+	}
+	return tick.Int64()
+}
+
+// uniswapV3TickPrice returns the price of token0 in token1 for the given
+// tick, adjusted by token decimals.
+func uniswapV3TickPrice(tick int64, decimals0, decimals1 uint8) float64 {
+	return math.Pow(1.0001, float64(tick)) * math.Pow10(int(decimals0)-int(decimals1))
+}
@@ -0,0 +1,142 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package origins
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	ethereumMocks "github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UniswapV3TWAPSuite struct {
+	suite.Suite
+	addresses ContractAddresses
+	client    *ethereumMocks.Client
+	twap      *UniswapV3TWAP
+	origin    *BaseExchangeHandler
+}
+
+func (suite *UniswapV3TWAPSuite) SetupSuite() {
+	suite.addresses = ContractAddresses{
+		"USDC/WETH": "0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640",
+	}
+}
+func (suite *UniswapV3TWAPSuite) TearDownSuite() {
+	suite.addresses = nil
+}
+
+func (suite *UniswapV3TWAPSuite) SetupTest() {
+	var err error
+	suite.client = &ethereumMocks.Client{}
+	suite.twap, err = NewUniswapV3TWAP(suite.client, suite.addresses, 0)
+	suite.NoError(err)
+	suite.origin = NewBaseExchangeHandler(suite.twap, nil)
+}
+
+func (suite *UniswapV3TWAPSuite) TearDownTest() {
+	suite.client = nil
+	suite.origin = nil
+}
+
+func (suite *UniswapV3TWAPSuite) Origin() Handler {
+	return suite.origin
+}
+
+func TestUniswapV3TWAPSuite(t *testing.T) {
+	suite.Run(t, new(UniswapV3TWAPSuite))
+}
+
+func (suite *UniswapV3TWAPSuite) mockPool(tickCumulative0, tickCumulative1 int64) {
+	pool := ethereum.HexToAddress("0x88e6A0c2dDD26FEEb64F039a2c41296FcB3f5640")
+	usdc := ethereum.HexToAddress("0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
+	weth := ethereum.HexToAddress("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+
+	observe, err := suite.twap.abi.Methods["observe"].Outputs.Pack(
+		[]*big.Int{big.NewInt(tickCumulative0), big.NewInt(tickCumulative1)},
+		[]*big.Int{big.NewInt(0), big.NewInt(0)},
+	)
+	suite.Require().NoError(err)
+	observeData, err := suite.twap.abi.Pack("observe", []uint32{300, 0})
+	suite.Require().NoError(err)
+
+	suite.client.On(
+		"MultiCall",
+		mock.Anything,
+		[]ethereum.Call{
+			{Address: pool, Data: ethereum.HexToBytes("0x0dfe1681")},
+			{Address: pool, Data: ethereum.HexToBytes("0xd21220a7")},
+			{Address: pool, Data: observeData},
+		},
+	).Return([][]byte{common.BytesToHash(usdc.Bytes()).Bytes(), common.BytesToHash(weth.Bytes()).Bytes(), observe}, nil).Once()
+	suite.client.On(
+		"MultiCall",
+		mock.Anything,
+		[]ethereum.Call{
+			{Address: usdc, Data: ethereum.HexToBytes("0x313ce567")},
+			{Address: weth, Data: ethereum.HexToBytes("0x313ce567")},
+		},
+	).Return([][]byte{common.BigToHash(big.NewInt(6)).Bytes(), common.BigToHash(big.NewInt(18)).Bytes()}, nil).Once()
+}
+
+func (suite *UniswapV3TWAPSuite) TestSuccessResponse() {
+	suite.mockPool(1000000, 1000000+200311*300)
+
+	results := suite.origin.Fetch([]Pair{{Base: "USDC", Quote: "WETH"}})
+	suite.Require().NoError(results[0].Error)
+	suite.InDelta(0.0004999899277897779, results[0].Price.Price, 1e-12)
+}
+
+func (suite *UniswapV3TWAPSuite) TestSuccessResponse_Inverted() {
+	suite.mockPool(1000000, 1000000+200311*300)
+
+	results := suite.origin.Fetch([]Pair{{Base: "WETH", Quote: "USDC"}})
+	suite.Require().NoError(results[0].Error)
+	suite.InDelta(2000.0402896525002, results[0].Price.Price, 1e-6)
+}
+
+func (suite *UniswapV3TWAPSuite) TestCallError() {
+	suite.client.On("MultiCall", mock.Anything, mock.Anything).Return([][]byte(nil), errors.New("error")).Once()
+
+	results := suite.origin.Fetch([]Pair{{Base: "USDC", Quote: "WETH"}})
+	suite.EqualError(results[0].Error, "error")
+}
+
+func (suite *UniswapV3TWAPSuite) TestFailOnWrongPair() {
+	cr := suite.origin.Fetch([]Pair{{Base: "x", Quote: "y"}})
+	suite.Require().EqualError(cr[0].Error, "failed to get contract address for pair: x/y")
+}
+
+func TestNewUniswapV3TWAP_InvalidWindow(t *testing.T) {
+	_, err := NewUniswapV3TWAP(nil, nil, time.Millisecond)
+	assert.Error(t, err)
+}
+
+func Test_twapTick(t *testing.T) {
+	assert.Equal(t, int64(10), twapTick(big.NewInt(0), big.NewInt(3000), 300))
+	assert.Equal(t, int64(10), twapTick(big.NewInt(0), big.NewInt(3001), 300))
+	assert.Equal(t, int64(-10), twapTick(big.NewInt(3000), big.NewInt(0), 300))
+	assert.Equal(t, int64(-11), twapTick(big.NewInt(3001), big.NewInt(0), 300))
+}
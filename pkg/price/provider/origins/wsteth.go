@@ -77,10 +77,11 @@ func (s WrappedStakedETH) callOne(pair Pair) (*Price, error) {
 		return nil, err
 	}
 
-	price, _ := reduceEtherAverageFloat(resp).Float64()
+	price := reduceEtherAverageDecimal(resp)
 	return &Price{
-		Pair:      pair,
-		Price:     price,
-		Timestamp: time.Now(),
+		Pair:         pair,
+		Price:        price.Float64(),
+		DecimalPrice: price,
+		Timestamp:    time.Now(),
 	}, nil
 }
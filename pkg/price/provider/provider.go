@@ -19,6 +19,8 @@ import (
 	"fmt"
 	"strings"
 	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/decimal"
 )
 
 // Provider provides prices for asset pairs.
@@ -35,6 +37,13 @@ type Provider interface {
 	Pairs() ([]Pair, error)
 }
 
+// OriginsHealthProvider is implemented by providers which track the health
+// of price origins.
+type OriginsHealthProvider interface {
+	// OriginsHealth returns the health of all used origins.
+	OriginsHealth() (map[string]*OriginHealth, error)
+}
+
 // Pair represents an asset pair.
 type Pair struct {
 	Base  string
@@ -94,6 +103,29 @@ type Model struct {
 	Models []*Model
 }
 
+// OriginHealth describes the health of a price origin. The Score is a value
+// between 0 and 1 which is based on the error rate, latency and staleness of
+// prices. Origins which failed too many times in a row are disabled until
+// the DisabledUntil time.
+type OriginHealth struct {
+	Origin              string
+	Score               float64
+	ErrorRate           float64
+	Latency             time.Duration
+	Requests            int
+	Errors              int
+	ConsecutiveFailures int
+	LastSuccess         time.Time
+	LastPriceTime       time.Time
+	LastError           string
+	DisabledUntil       time.Time
+}
+
+// Disabled returns true if the origin is temporarily disabled.
+func (h *OriginHealth) Disabled() bool {
+	return time.Now().Before(h.DisabledUntil)
+}
+
 // Price represents price for a single pair. If the Price was calculated
 // indirectly it will also contain all prices used to calculate the price.
 type Price struct {
@@ -107,8 +139,23 @@ type Price struct {
 	Time       time.Time
 	Prices     []*Price
 	Error      string
+	// DecimalPrice is the exact price. The Price field is its approximation
+	// intended for display. If DecimalPrice is zero, Price should be used
+	// instead.
+	DecimalPrice decimal.Decimal
+	// TraceContext is the trace context of the span in which the origin
+	// price was fetched. It is set only for origin prices when tracing is
+	// enabled.
+	TraceContext map[string]string
 }
 
 type PriceHook interface {
 	Check(map[Pair]*Price) error
 }
+
+// PriceRecorder is an optional interface implemented by a PriceHook that
+// has to be notified about prices that were actually used after passing
+// the Check method.
+type PriceRecorder interface {
+	Record(map[Pair]*Price)
+}
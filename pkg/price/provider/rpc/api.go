@@ -16,6 +16,8 @@
 package rpc
 
 import (
+	"errors"
+
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/graph/feeder"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/provider/marshal"
@@ -25,6 +27,8 @@ import (
 
 type Nothing = struct{}
 
+var ErrOriginsHealthNotSupported = errors.New("price provider does not support origins health")
+
 type API struct {
 	provider provider.Provider
 	log      log.Logger
@@ -59,6 +63,10 @@ type PairsResp struct {
 	Pairs []provider.Pair
 }
 
+type OriginsHealthResp struct {
+	Origins map[string]*provider.OriginHealth
+}
+
 func (n *API) Models(arg *NodesArg, resp *NodesResp) error {
 	n.log.WithField("pairs", arg.Pairs).Info("Models")
 	pairs, err := n.provider.Models(arg.Pairs...)
@@ -88,3 +96,17 @@ func (n *API) Pairs(_ *Nothing, resp *PairsResp) error {
 	resp.Pairs = pairs
 	return nil
 }
+
+func (n *API) OriginsHealth(_ *Nothing, resp *OriginsHealthResp) error {
+	n.log.Info("OriginsHealth")
+	hp, ok := n.provider.(provider.OriginsHealthProvider)
+	if !ok {
+		return ErrOriginsHealthNotSupported
+	}
+	origins, err := hp.OriginsHealth()
+	if err != nil {
+		return err
+	}
+	resp.Origins = origins
+	return nil
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -107,3 +108,15 @@ func TestClient_Pairs(t *testing.T) {
 	assert.Equal(t, pairs, resp)
 	assert.NoError(t, err)
 }
+
+func TestClient_OriginsHealth(t *testing.T) {
+	health := map[string]*provider.OriginHealth{
+		"test": {Origin: "test", Score: 0.5, ErrorRate: 0.5, Latency: time.Second, Requests: 2, Errors: 1},
+	}
+
+	mockGofer.On("OriginsHealth").Return(health, nil)
+	resp, err := rpcGofer.OriginsHealth()
+
+	assert.Equal(t, health, resp)
+	assert.NoError(t, err)
+}
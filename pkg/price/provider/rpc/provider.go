@@ -119,6 +119,19 @@ func (g *Provider) Pairs() ([]provider.Pair, error) {
 	return resp.Pairs, nil
 }
 
+// OriginsHealth implements the provider.OriginsHealthProvider interface.
+func (g *Provider) OriginsHealth() (map[string]*provider.OriginHealth, error) {
+	if g.rpc == nil {
+		return nil, ErrNotStarted
+	}
+	resp := &OriginsHealthResp{}
+	err := g.rpc.Call("API.OriginsHealth", &Nothing{}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Origins, nil
+}
+
 func (g *Provider) contextCancelHandler() {
 	defer func() { close(g.waitCh) }()
 	<-g.ctx.Done()
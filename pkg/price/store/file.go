@@ -0,0 +1,291 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+const fileMaxRecordSize = 2 * 1024 * 1024 // Price messages are limited to 1MB.
+const fileCompactMinRecords = 1000        // Minimum number of records in the log file before compaction.
+
+// FileStorage provides a persistent storage mechanism for the PriceStore.
+//
+// Prices are kept in memory, and every accepted price is appended to a log
+// file. The log file is replayed when the storage is created, so prices
+// survive restarts. Expired prices are never returned, and from time to
+// time, the log file is rewritten to contain only the latest, non-expired
+// prices.
+type FileStorage struct {
+	mu sync.RWMutex
+
+	path    string
+	ttl     time.Duration // Price TTL, zero means that prices never expire.
+	file    *os.File
+	ps      map[FeederPrice]*messages.Price
+	records int // Number of records in the log file.
+}
+
+// fileRecord is a single entry in the log file.
+type fileRecord struct {
+	From  ethereum.Address `json:"from"`
+	Price *messages.Price  `json:"price"`
+}
+
+// NewFileStorage creates a new store instance that persists prices in
+// the file at the given path. If the file exists, prices stored in it
+// are loaded. Prices older than ttl are removed from the storage. If ttl
+// is zero, prices never expire.
+func NewFileStorage(path string, ttl time.Duration) (*FileStorage, error) {
+	f := &FileStorage{
+		path: path,
+		ttl:  ttl,
+		ps:   make(map[FeederPrice]*messages.Price),
+	}
+	if err := f.load(); err != nil {
+		return nil, err
+	}
+	if err := f.compact(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Add implements the store.Storage interface.
+func (f *FileStorage) Add(_ context.Context, from ethereum.Address, price *messages.Price) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.newer(from, price) {
+		return nil
+	}
+	// The price is written to the log file first, so the in-memory index
+	// does not contain prices that would be lost after restart:
+	if err := f.append(from, price); err != nil {
+		return err
+	}
+	f.set(from, price)
+	if f.records >= fileCompactMinRecords && f.records > 2*len(f.ps) {
+		return f.compact()
+	}
+	return nil
+}
+
+// GetAll implements the store.Storage interface.
+func (f *FileStorage) GetAll(_ context.Context) (map[FeederPrice]*messages.Price, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	r := map[FeederPrice]*messages.Price{}
+	for k, v := range f.ps {
+		if f.expired(v) {
+			continue
+		}
+		r[k] = v
+	}
+	return r, nil
+}
+
+// GetByAssetPair implements the store.Storage interface.
+func (f *FileStorage) GetByAssetPair(_ context.Context, pair string) ([]*messages.Price, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	var ps []*messages.Price
+	for k, v := range f.ps {
+		if k.AssetPair != pair || f.expired(v) {
+			continue
+		}
+		ps = append(ps, v)
+	}
+	return ps, nil
+}
+
+// GetByFeeder implements the store.Storage interface.
+func (f *FileStorage) GetByFeeder(_ context.Context, pair string, feeder ethereum.Address) (*messages.Price, error) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	fp := FeederPrice{
+		AssetPair: pair,
+		Feeder:    feeder,
+	}
+	if m, ok := f.ps[fp]; ok && !f.expired(m) {
+		return m, nil
+	}
+	return nil, nil
+}
+
+// Close closes the log file.
+func (f *FileStorage) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.file == nil {
+		return nil
+	}
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// newer returns false if the storage already contains a newer price from
+// the same feeder.
+func (f *FileStorage) newer(from ethereum.Address, price *messages.Price) bool {
+	prev, ok := f.ps[FeederPrice{AssetPair: price.Price.Wat, Feeder: from}]
+	return !ok || !prev.Price.Age.After(price.Price.Age)
+}
+
+// set adds a price to the in-memory index unless the storage already
+// contains a newer one.
+func (f *FileStorage) set(from ethereum.Address, price *messages.Price) {
+	if f.newer(from, price) {
+		f.ps[FeederPrice{AssetPair: price.Price.Wat, Feeder: from}] = price
+	}
+}
+
+// expired returns true if the price is older than the TTL.
+func (f *FileStorage) expired(price *messages.Price) bool {
+	return f.ttl > 0 && time.Since(price.Price.Age) > f.ttl
+}
+
+// append writes a price to the end of the log file.
+func (f *FileStorage) append(from ethereum.Address, price *messages.Price) error {
+	if f.file == nil {
+		return fmt.Errorf("file storage: log file %s is closed", f.path)
+	}
+	b, err := marshalFileRecord(from, price)
+	if err != nil {
+		return err
+	}
+	if _, err := f.file.Write(b); err != nil {
+		return fmt.Errorf("file storage: unable to write to %s: %w", f.path, err)
+	}
+	f.records++
+	return nil
+}
+
+// load reads all prices from the log file.
+func (f *FileStorage) load() (err error) {
+	file, err := os.Open(f.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("file storage: unable to open %s: %w", f.path, err)
+	}
+	defer func() {
+		if errClose := file.Close(); err == nil && errClose != nil {
+			err = errClose
+		}
+	}()
+	s := bufio.NewScanner(file)
+	s.Buffer(make([]byte, 0, 64*1024), fileMaxRecordSize)
+	for s.Scan() {
+		r, err := unmarshalFileRecord(s.Bytes())
+		if err != nil {
+			// The last record may be incomplete if the process was killed
+			// during writing. Such records are skipped.
+			continue
+		}
+		f.set(r.From, r.Price)
+	}
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("file storage: unable to read %s: %w", f.path, err)
+	}
+	return nil
+}
+
+// compact removes expired prices and rewrites the log file so that it
+// contains only the prices that are currently stored.
+func (f *FileStorage) compact() error {
+	for fp, p := range f.ps {
+		if f.expired(p) {
+			delete(f.ps, fp)
+		}
+	}
+	// The temporary file is opened in append mode, so after it replaces
+	// the log file, the same handle can be used to append new prices. The
+	// current handle is replaced only after the rename succeeds, so the
+	// storage remains usable if compaction fails.
+	tmp := f.path + ".tmp"
+	file, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("file storage: unable to create %s: %w", tmp, err)
+	}
+	if err := writeFileRecords(file, f.ps); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("file storage: unable to write to %s: %w", tmp, err)
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		_ = file.Close()
+		_ = os.Remove(tmp)
+		return fmt.Errorf("file storage: unable to replace %s: %w", f.path, err)
+	}
+	if f.file != nil {
+		_ = f.file.Close()
+	}
+	f.file = file
+	f.records = len(f.ps)
+	return nil
+}
+
+// writeFileRecords writes all prices to the file and syncs it.
+func writeFileRecords(file *os.File, ps map[FeederPrice]*messages.Price) error {
+	w := bufio.NewWriter(file)
+	for fp, p := range ps {
+		b, err := marshalFileRecord(fp.Feeder, p)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(b); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return file.Sync()
+}
+
+func marshalFileRecord(from ethereum.Address, price *messages.Price) ([]byte, error) {
+	b, err := json.Marshal(fileRecord{From: from, Price: price})
+	if err != nil {
+		return nil, fmt.Errorf("file storage: unable to marshal price: %w", err)
+	}
+	return append(b, '\n'), nil
+}
+
+func unmarshalFileRecord(b []byte) (*fileRecord, error) {
+	r := &fileRecord{}
+	if err := json.Unmarshal(b, r); err != nil {
+		return nil, fmt.Errorf("file storage: unable to unmarshal price: %w", err)
+	}
+	if r.Price == nil || r.Price.Price == nil {
+		return nil, fmt.Errorf("file storage: price is missing")
+	}
+	if string(r.Price.Trace) == "null" {
+		r.Price.Trace = nil
+	}
+	return r, nil
+}
+
+var _ Storage = (*FileStorage)(nil)
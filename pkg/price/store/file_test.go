@@ -0,0 +1,162 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store/testutil"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/errutil"
+)
+
+func TestFileStorage_Add(t *testing.T) {
+	ctx := context.Background()
+	fs, err := NewFileStorage(filepath.Join(t.TempDir(), "prices.log"), 0)
+	require.NoError(t, err)
+	defer fs.Close()
+
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceXXXYYY1))
+	require.NoError(t, fs.Add(ctx, testutil.Address2, testutil.PriceAAABBB1))
+	require.NoError(t, fs.Add(ctx, testutil.Address2, testutil.PriceXXXYYY1))
+
+	aaabbb := errutil.Must(fs.GetByAssetPair(ctx, "AAABBB"))
+	xxxyyy := errutil.Must(fs.GetByAssetPair(ctx, "XXXYYY"))
+
+	assert.Equal(t, 2, len(aaabbb))
+	assert.Equal(t, 2, len(xxxyyy))
+	assert.Contains(t, aaabbb, testutil.PriceAAABBB1)
+	assert.Contains(t, xxxyyy, testutil.PriceXXXYYY1)
+}
+
+func TestFileStorage_Add_UseNewerPrice(t *testing.T) {
+	ctx := context.Background()
+	fs, err := NewFileStorage(filepath.Join(t.TempDir(), "prices.log"), 0)
+	require.NoError(t, err)
+	defer fs.Close()
+
+	// Second price should replace first one because is younger:
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB2))
+
+	// Second price should be ignored because is older:
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceXXXYYY2))
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceXXXYYY1))
+
+	assert.Equal(t, testutil.PriceAAABBB2, errutil.Must(fs.GetByFeeder(ctx, "AAABBB", testutil.Address1)))
+	assert.Equal(t, testutil.PriceXXXYYY2, errutil.Must(fs.GetByFeeder(ctx, "XXXYYY", testutil.Address1)))
+}
+
+func TestFileStorage_Reopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "prices.log")
+	fs, err := NewFileStorage(path, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB2))
+	require.NoError(t, fs.Add(ctx, testutil.Address2, testutil.PriceXXXYYY1))
+	require.NoError(t, fs.Close())
+
+	// Append an incomplete record to simulate a crash during writing:
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"from":"0x2d800d93b065ce011af83f316cef9f0d005b0aa4","price":{"pri`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	fs, err = NewFileStorage(path, 0)
+	require.NoError(t, err)
+	defer fs.Close()
+
+	all := errutil.Must(fs.GetAll(ctx))
+	assert.Len(t, all, 2)
+	assert.Equal(t, testutil.PriceAAABBB2, errutil.Must(fs.GetByFeeder(ctx, "AAABBB", testutil.Address1)))
+	assert.Equal(t, testutil.PriceXXXYYY1, errutil.Must(fs.GetByFeeder(ctx, "XXXYYY", testutil.Address2)))
+
+	// Older price must not replace the one loaded from the file:
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	assert.Equal(t, testutil.PriceAAABBB2, errutil.Must(fs.GetByFeeder(ctx, "AAABBB", testutil.Address1)))
+}
+
+func TestFileStorage_Expired(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "prices.log")
+	fs, err := NewFileStorage(path, 0)
+	require.NoError(t, err)
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	require.NoError(t, fs.Close())
+
+	// Test prices are from 1970, so they are expired:
+	fs, err = NewFileStorage(path, time.Hour)
+	require.NoError(t, err)
+	defer fs.Close()
+
+	assert.Len(t, errutil.Must(fs.GetAll(ctx)), 0)
+}
+
+func TestFileStorage_ExpiredOnRead(t *testing.T) {
+	ctx := context.Background()
+	fs, err := NewFileStorage(filepath.Join(t.TempDir(), "prices.log"), time.Hour)
+	require.NoError(t, err)
+	defer fs.Close()
+
+	// Test prices are from 1970, so they must not be returned even if they
+	// were not removed by compaction yet:
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	assert.Len(t, errutil.Must(fs.GetAll(ctx)), 0)
+	assert.Len(t, errutil.Must(fs.GetByAssetPair(ctx, "AAABBB")), 0)
+	assert.Nil(t, errutil.Must(fs.GetByFeeder(ctx, "AAABBB", testutil.Address1)))
+}
+
+func TestFileStorage_Add_WriteError(t *testing.T) {
+	ctx := context.Background()
+	fs, err := NewFileStorage(filepath.Join(t.TempDir(), "prices.log"), 0)
+	require.NoError(t, err)
+	require.NoError(t, fs.Close())
+
+	// A price that was not written to the log file must not be stored:
+	assert.Error(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	assert.Len(t, errutil.Must(fs.GetAll(ctx)), 0)
+}
+
+func TestFileStorage_CompactRenameError(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "prices.log")
+	fs, err := NewFileStorage(path, 0)
+	require.NoError(t, err)
+	defer fs.Close()
+
+	// Replace the log file with a non-empty directory, so the compacted
+	// file cannot be renamed:
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, os.MkdirAll(filepath.Join(path, "dir"), 0700))
+	assert.Error(t, fs.compact())
+
+	// The temporary file must be removed, and the storage must still be
+	// able to append prices:
+	_, err = os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+	require.NoError(t, fs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	assert.Len(t, errutil.Must(fs.GetAll(ctx)), 1)
+}
@@ -0,0 +1,365 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+const historySegmentLayout = "2006-01-02" // Every segment file contains prices from a single day.
+const historySegmentExt = ".log"
+
+var ErrHistoryNotSupported = errors.New("storage does not support price history")
+
+// History provides an interface to the price history.
+type History interface {
+	// Range returns all prices that match the given query, sorted by age.
+	// The method is thread-safe.
+	Range(ctx context.Context, q HistoryQuery) ([]HistoryPrice, error)
+}
+
+// HistoryQuery is a filter used to query the price history.
+type HistoryQuery struct {
+	// AssetPair is the asset pair name. If empty, prices for all pairs are
+	// returned.
+	AssetPair string
+	// Feeder is the address of the feeder. If nil, prices from all feeders
+	// are returned.
+	Feeder *ethereum.Address
+	// From and To specify the time range, inclusive. A zero value means no
+	// limit.
+	From time.Time
+	To   time.Time
+}
+
+// HistoryPrice is a single price stored in the history.
+type HistoryPrice struct {
+	Feeder ethereum.Address `json:"feeder"`
+	Price  *messages.Price  `json:"price"`
+}
+
+// OHLC is a downsampled price aggregate for a single time interval.
+type OHLC struct {
+	Time  time.Time `json:"time"`
+	Open  *big.Int  `json:"open"`
+	High  *big.Int  `json:"high"`
+	Low   *big.Int  `json:"low"`
+	Close *big.Int  `json:"close"`
+	Count int       `json:"count"`
+}
+
+// HistoryStorage is a Storage decorator that records every price accepted
+// by the underlying storage in the history.
+//
+// The history is stored as append-only segment files in the given directory,
+// one file per day of the price age. Segments older than the retention period
+// are removed.
+type HistoryStorage struct {
+	mu sync.Mutex
+
+	storage   Storage
+	dir       string
+	retention time.Duration // Zero means that the history is kept forever.
+	segments  map[string]*os.File
+}
+
+// NewHistoryStorage creates a new HistoryStorage that uses the storage to
+// keep the latest prices and stores the price history in the dir directory.
+func NewHistoryStorage(storage Storage, dir string, retention time.Duration) (*HistoryStorage, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("history storage: unable to create %s: %w", dir, err)
+	}
+	h := &HistoryStorage{
+		storage:   storage,
+		dir:       dir,
+		retention: retention,
+		segments:  make(map[string]*os.File),
+	}
+	if err := h.prune(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Add implements the store.Storage interface.
+//
+// The price is recorded in the history only if it is newer than the one
+// already stored for the same feeder and asset pair.
+func (h *HistoryStorage) Add(ctx context.Context, from ethereum.Address, price *messages.Price) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	prev, err := h.storage.GetByFeeder(ctx, price.Price.Wat, from)
+	if err != nil {
+		return err
+	}
+	if err := h.storage.Add(ctx, from, price); err != nil {
+		return err
+	}
+	if prev != nil && !prev.Price.Age.Before(price.Price.Age) {
+		return nil
+	}
+	return h.record(from, price)
+}
+
+// GetAll implements the store.Storage interface.
+func (h *HistoryStorage) GetAll(ctx context.Context) (map[FeederPrice]*messages.Price, error) {
+	return h.storage.GetAll(ctx)
+}
+
+// GetByAssetPair implements the store.Storage interface.
+func (h *HistoryStorage) GetByAssetPair(ctx context.Context, pair string) ([]*messages.Price, error) {
+	return h.storage.GetByAssetPair(ctx, pair)
+}
+
+// GetByFeeder implements the store.Storage interface.
+func (h *HistoryStorage) GetByFeeder(ctx context.Context, pair string, feeder ethereum.Address) (*messages.Price, error) {
+	return h.storage.GetByFeeder(ctx, pair, feeder)
+}
+
+// Range implements the store.History interface.
+//
+// The lock is held only while listing segments, so long queries do not
+// block adding new prices. Segments are append-only, so at worst the last,
+// incomplete record of a segment is skipped.
+func (h *HistoryStorage) Range(_ context.Context, q HistoryQuery) ([]HistoryPrice, error) {
+	h.mu.Lock()
+	names, err := h.segmentNames()
+	h.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	var ps []HistoryPrice
+	for _, name := range names {
+		day, err := time.Parse(historySegmentLayout, name)
+		if err != nil {
+			continue
+		}
+		if !q.From.IsZero() && day.Add(24*time.Hour).Before(q.From) {
+			continue
+		}
+		if !q.To.IsZero() && day.After(q.To) {
+			continue
+		}
+		if err := h.readSegment(name, func(r *fileRecord) {
+			if q.AssetPair != "" && r.Price.Price.Wat != q.AssetPair {
+				return
+			}
+			if q.Feeder != nil && r.From != *q.Feeder {
+				return
+			}
+			if !q.From.IsZero() && r.Price.Price.Age.Before(q.From) {
+				return
+			}
+			if !q.To.IsZero() && r.Price.Price.Age.After(q.To) {
+				return
+			}
+			ps = append(ps, HistoryPrice{Feeder: r.From, Price: r.Price})
+		}); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(ps, func(i, j int) bool {
+		return ps[i].Price.Price.Age.Before(ps[j].Price.Price.Age)
+	})
+	return ps, nil
+}
+
+// Close closes the underlying storage and all open segment files.
+func (h *HistoryStorage) Close() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var err error
+	for name, f := range h.segments {
+		if errClose := f.Close(); err == nil {
+			err = errClose
+		}
+		delete(h.segments, name)
+	}
+	if c, ok := h.storage.(io.Closer); ok {
+		if errClose := c.Close(); err == nil {
+			err = errClose
+		}
+	}
+	return err
+}
+
+// record appends the price to the segment file for the price age.
+func (h *HistoryStorage) record(from ethereum.Address, price *messages.Price) error {
+	if h.retention > 0 && time.Since(price.Price.Age) > h.retention {
+		return nil
+	}
+	name := price.Price.Age.UTC().Format(historySegmentLayout)
+	f, ok := h.segments[name]
+	if !ok {
+		// A new segment is usually created once a day, which is a good
+		// moment to remove old ones.
+		if err := h.prune(); err != nil {
+			return err
+		}
+		var err error
+		f, err = os.OpenFile(h.segmentPath(name), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return fmt.Errorf("history storage: unable to open %s: %w", h.segmentPath(name), err)
+		}
+		h.segments[name] = f
+	}
+	b, err := marshalFileRecord(from, price)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		return fmt.Errorf("history storage: unable to write to %s: %w", h.segmentPath(name), err)
+	}
+	return nil
+}
+
+// prune removes segments older than the retention period and closes
+// segment files that are no longer written to.
+func (h *HistoryStorage) prune() error {
+	today := time.Now().UTC().Format(historySegmentLayout)
+	for name, f := range h.segments {
+		if name < today {
+			_ = f.Close()
+			delete(h.segments, name)
+		}
+	}
+	if h.retention == 0 {
+		return nil
+	}
+	names, err := h.segmentNames()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		day, err := time.Parse(historySegmentLayout, name)
+		if err != nil {
+			continue
+		}
+		if time.Since(day.Add(24*time.Hour)) <= h.retention {
+			continue
+		}
+		if f, ok := h.segments[name]; ok {
+			_ = f.Close()
+			delete(h.segments, name)
+		}
+		if err := os.Remove(h.segmentPath(name)); err != nil {
+			return fmt.Errorf("history storage: unable to remove %s: %w", h.segmentPath(name), err)
+		}
+	}
+	return nil
+}
+
+// segmentNames returns sorted names of all segments, without the extension.
+func (h *HistoryStorage) segmentNames() ([]string, error) {
+	entries, err := os.ReadDir(h.dir)
+	if err != nil {
+		return nil, fmt.Errorf("history storage: unable to read %s: %w", h.dir, err)
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), historySegmentExt) {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), historySegmentExt))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (h *HistoryStorage) readSegment(name string, fn func(r *fileRecord)) (err error) {
+	file, err := os.Open(h.segmentPath(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("history storage: unable to open %s: %w", h.segmentPath(name), err)
+	}
+	defer func() {
+		if errClose := file.Close(); err == nil && errClose != nil {
+			err = errClose
+		}
+	}()
+	s := bufio.NewScanner(file)
+	s.Buffer(make([]byte, 0, 64*1024), fileMaxRecordSize)
+	for s.Scan() {
+		r, err := unmarshalFileRecord(s.Bytes())
+		if err != nil {
+			continue
+		}
+		fn(r)
+	}
+	if err := s.Err(); err != nil {
+		return fmt.Errorf("history storage: unable to read %s: %w", h.segmentPath(name), err)
+	}
+	return nil
+}
+
+func (h *HistoryStorage) segmentPath(name string) string {
+	return filepath.Join(h.dir, name+historySegmentExt)
+}
+
+// AggregateOHLC downsamples prices into OHLC aggregates. Prices are grouped
+// into buckets of the given interval, as returned by time.Time.Truncate. The prices
+// must be sorted by age, as returned by History.Range.
+func AggregateOHLC(ps []HistoryPrice, interval time.Duration) []OHLC {
+	if interval <= 0 {
+		return nil
+	}
+	var res []OHLC
+	for _, p := range ps {
+		val := p.Price.Price.Val
+		if val == nil {
+			continue
+		}
+		t := p.Price.Price.Age.Truncate(interval)
+		if len(res) == 0 || !res[len(res)-1].Time.Equal(t) {
+			res = append(res, OHLC{
+				Time:  t,
+				Open:  val,
+				High:  val,
+				Low:   val,
+				Close: val,
+			})
+		}
+		c := &res[len(res)-1]
+		if val.Cmp(c.High) > 0 {
+			c.High = val
+		}
+		if val.Cmp(c.Low) < 0 {
+			c.Low = val
+		}
+		c.Close = val
+		c.Count++
+	}
+	return res
+}
+
+var _ Storage = (*HistoryStorage)(nil)
+var _ History = (*HistoryStorage)(nil)
@@ -0,0 +1,184 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package store
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store/testutil"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+	"github.com/chronicleprotocol/oracle-suite/pkg/util/errutil"
+)
+
+func TestHistoryStorage_Add(t *testing.T) {
+	ctx := context.Background()
+	hs, err := NewHistoryStorage(NewMemoryStorage(), t.TempDir(), 0)
+	require.NoError(t, err)
+	defer hs.Close()
+
+	require.NoError(t, hs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	require.NoError(t, hs.Add(ctx, testutil.Address1, testutil.PriceAAABBB2))
+	require.NoError(t, hs.Add(ctx, testutil.Address2, testutil.PriceAAABBB1))
+	require.NoError(t, hs.Add(ctx, testutil.Address1, testutil.PriceXXXYYY1))
+
+	// Duplicated and older prices must not be recorded:
+	require.NoError(t, hs.Add(ctx, testutil.Address1, testutil.PriceAAABBB2))
+	require.NoError(t, hs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+
+	// The underlying storage keeps only the latest prices:
+	assert.Equal(t, testutil.PriceAAABBB2, errutil.Must(hs.GetByFeeder(ctx, "AAABBB", testutil.Address1)))
+
+	ps := errutil.Must(hs.Range(ctx, HistoryQuery{AssetPair: "AAABBB"}))
+	require.Len(t, ps, 3)
+	assert.Equal(t, testutil.PriceAAABBB1, ps[0].Price)
+	assert.Equal(t, testutil.PriceAAABBB1, ps[1].Price)
+	assert.Equal(t, testutil.PriceAAABBB2, ps[2].Price)
+	assert.Equal(t, testutil.Address1, ps[2].Feeder)
+}
+
+func TestHistoryStorage_Range(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	hs, err := NewHistoryStorage(NewMemoryStorage(), dir, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, hs.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	require.NoError(t, hs.Add(ctx, testutil.Address1, testutil.PriceAAABBB2))
+	require.NoError(t, hs.Add(ctx, testutil.Address1, testutil.PriceAAABBB3))
+	require.NoError(t, hs.Add(ctx, testutil.Address2, testutil.PriceAAABBB3))
+	require.NoError(t, hs.Add(ctx, testutil.Address2, testutil.PriceXXXYYY1))
+	require.NoError(t, hs.Close())
+
+	// History must be available after reopening:
+	hs, err = NewHistoryStorage(NewMemoryStorage(), dir, 0)
+	require.NoError(t, err)
+	defer hs.Close()
+
+	tests := []struct {
+		name  string
+		query HistoryQuery
+		want  []*messages.Price
+	}{
+		{
+			name:  "all",
+			query: HistoryQuery{},
+			want: []*messages.Price{
+				testutil.PriceAAABBB1,
+				testutil.PriceXXXYYY1,
+				testutil.PriceAAABBB2,
+				testutil.PriceAAABBB3,
+				testutil.PriceAAABBB3,
+			},
+		},
+		{
+			name:  "feeder",
+			query: HistoryQuery{AssetPair: "AAABBB", Feeder: &testutil.Address2},
+			want:  []*messages.Price{testutil.PriceAAABBB3},
+		},
+		{
+			name:  "from",
+			query: HistoryQuery{AssetPair: "AAABBB", Feeder: &testutil.Address1, From: time.Unix(200, 0)},
+			want:  []*messages.Price{testutil.PriceAAABBB2, testutil.PriceAAABBB3},
+		},
+		{
+			name:  "to",
+			query: HistoryQuery{AssetPair: "AAABBB", Feeder: &testutil.Address1, To: time.Unix(200, 0)},
+			want:  []*messages.Price{testutil.PriceAAABBB1, testutil.PriceAAABBB2},
+		},
+		{
+			name:  "out of range",
+			query: HistoryQuery{From: time.Unix(86400*10, 0)},
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := errutil.Must(hs.Range(ctx, tt.query))
+			var got []*messages.Price
+			for _, p := range ps {
+				got = append(got, p.Price)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestHistoryStorage_Retention(t *testing.T) {
+	dir := t.TempDir()
+	old := time.Now().Add(-72 * time.Hour).UTC().Format(historySegmentLayout)
+	today := time.Now().UTC().Format(historySegmentLayout)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, old+historySegmentExt), nil, 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, today+historySegmentExt), nil, 0600))
+
+	hs, err := NewHistoryStorage(NewMemoryStorage(), dir, 24*time.Hour)
+	require.NoError(t, err)
+	defer hs.Close()
+
+	assert.NoFileExists(t, filepath.Join(dir, old+historySegmentExt))
+	assert.FileExists(t, filepath.Join(dir, today+historySegmentExt))
+}
+
+func TestAggregateOHLC(t *testing.T) {
+	price := func(val int64, age int64) HistoryPrice {
+		return HistoryPrice{
+			Feeder: testutil.Address1,
+			Price: &messages.Price{Price: &oracle.Price{
+				Wat: "AAABBB",
+				Val: big.NewInt(val),
+				Age: time.Unix(age, 0),
+			}},
+		}
+	}
+	ps := []HistoryPrice{
+		price(10, 0),
+		price(30, 10),
+		price(5, 20),
+		price(20, 59),
+		price(40, 60),
+		price(50, 180),
+	}
+
+	ohlc := AggregateOHLC(ps, time.Minute)
+
+	require.Len(t, ohlc, 3)
+	assert.Equal(t, OHLC{
+		Time:  time.Unix(0, 0),
+		Open:  big.NewInt(10),
+		High:  big.NewInt(30),
+		Low:   big.NewInt(5),
+		Close: big.NewInt(20),
+		Count: 4,
+	}, ohlc[0])
+	assert.Equal(t, OHLC{
+		Time:  time.Unix(60, 0),
+		Open:  big.NewInt(40),
+		High:  big.NewInt(40),
+		Low:   big.NewInt(40),
+		Close: big.NewInt(40),
+		Count: 1,
+	}, ohlc[1])
+	assert.Equal(t, time.Unix(180, 0), ohlc[2].Time)
+	assert.Nil(t, AggregateOHLC(ps, 0))
+}
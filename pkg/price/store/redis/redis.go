@@ -0,0 +1,337 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package redis
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+const retryAttempts = 3               // The maximum number of attempts to call Redis in case of an error.
+const retryInterval = 1 * time.Second // The delay between retry attempts.
+const keyPrefix = "price"             // The prefix of all keys used by the storage.
+
+// Storage provides storage mechanism for store.PriceStore.
+// It uses a Redis database to store prices, so the same prices may be
+// shared between multiple instances of the application.
+type Storage struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// Config is the configuration for the Storage.
+type Config struct {
+	// TTL specifies how long prices should be kept in storage. Expiration
+	// time is calculated from the price age. If zero, prices never expire.
+	TTL time.Duration
+	// Address specifies Redis server address as "host:port".
+	Address string
+	// Username specifies Redis username for the ACL.
+	Username string
+	// Password specifies Redis server password.
+	Password string
+	// DB is the Redis database number.
+	DB int
+	// TLS specifies whether to use TLS for Redis connection.
+	TLS bool
+	// TLSServerName specifies the server name used to verify
+	// the hostname on the returned certificates from the server.
+	TLSServerName string
+	// TLSCertFile specifies the path to the client certificate file.
+	TLSCertFile string
+	// TLSKeyFile specifies the path to the client key file.
+	TLSKeyFile string
+	// TLSRootCAFile specifies the path to the CA certificate file.
+	TLSRootCAFile string
+	// TLSInsecureSkipVerify specifies whether to skip server certificate verification.
+	TLSInsecureSkipVerify bool
+}
+
+// NewRedisStorage returns a new instance of Redis.
+func NewRedisStorage(cfg Config) (*Storage, error) {
+	opts := &redis.Options{
+		Addr:     cfg.Address,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		DB:       cfg.DB,
+	}
+	if cfg.TLS {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.TLSInsecureSkipVerify {
+			opts.TLSConfig.InsecureSkipVerify = true
+		}
+		if cfg.TLSServerName != "" {
+			opts.TLSConfig.ServerName = cfg.TLSServerName
+		}
+		if cfg.TLSCertFile != "" && cfg.TLSKeyFile != "" {
+			cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+			if err != nil {
+				return nil, err
+			}
+			opts.TLSConfig.Certificates = []tls.Certificate{cert}
+		}
+		if cfg.TLSRootCAFile != "" {
+			caCert, err := os.ReadFile(cfg.TLSRootCAFile)
+			if err != nil {
+				return nil, err
+			}
+			caCertPool := x509.NewCertPool()
+			caCertPool.AppendCertsFromPEM(caCert)
+			opts.TLSConfig.RootCAs = caCertPool
+		}
+	}
+	cli := redis.NewClient(opts)
+	// go-redis default timeout is 5 seconds, so using background context should be ok
+	res := cli.Ping(context.Background())
+	if res.Err() != nil {
+		return nil, res.Err()
+	}
+	return &Storage{
+		client: cli,
+		ttl:    cfg.TTL,
+	}, nil
+}
+
+// Add implements the store.Storage interface.
+func (r *Storage) Add(ctx context.Context, from ethereum.Address, price *messages.Price) error {
+	return retry(func() error {
+		return r.add(ctx, from, price)
+	})
+}
+
+// GetAll implements the store.Storage interface.
+func (r *Storage) GetAll(ctx context.Context) (map[store.FeederPrice]*messages.Price, error) {
+	var ps map[store.FeederPrice]*messages.Price
+	err := retry(func() (err error) {
+		ps, err = r.get(ctx, wildcardPriceKey())
+		return err
+	})
+	return ps, err
+}
+
+// GetByAssetPair implements the store.Storage interface.
+func (r *Storage) GetByAssetPair(ctx context.Context, pair string) ([]*messages.Price, error) {
+	var ps map[store.FeederPrice]*messages.Price
+	err := retry(func() (err error) {
+		ps, err = r.get(ctx, wildcardPairPriceKey(pair))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res []*messages.Price
+	for fp, p := range ps {
+		// The wildcard may match pairs with a colon in the name, so the pair
+		// has to be checked again.
+		if fp.AssetPair != pair {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res, nil
+}
+
+// GetByFeeder implements the store.Storage interface.
+func (r *Storage) GetByFeeder(ctx context.Context, pair string, feeder ethereum.Address) (*messages.Price, error) {
+	var price *messages.Price
+	err := retry(func() error {
+		val, err := r.client.Get(ctx, priceKey(pair, feeder)).Result()
+		switch {
+		case errors.Is(err, redis.Nil):
+			return nil
+		case err != nil:
+			return err
+		}
+		price, err = unmarshalPrice(val)
+		return err
+	})
+	return price, err
+}
+
+// Close closes the connection to the Redis server.
+func (r *Storage) Close() error {
+	return r.client.Close()
+}
+
+func (r *Storage) add(ctx context.Context, from ethereum.Address, price *messages.Price) error {
+	var expireAt time.Time
+	if r.ttl > 0 {
+		expireAt = price.Price.Age.Add(r.ttl)
+		if time.Now().After(expireAt) {
+			return nil
+		}
+	}
+	val, err := price.Marshall()
+	if err != nil {
+		return err
+	}
+	key := priceKey(price.Price.Wat, from)
+	return r.client.Watch(ctx, func(tx *redis.Tx) error {
+		prevVal, err := tx.Get(ctx, key).Result()
+		switch {
+		case err == nil:
+			// If a price from the same feeder exists, replace it only if
+			// it is older.
+			prevPrice, err := unmarshalPrice(prevVal)
+			if err == nil && prevPrice.Price.Age.After(price.Price.Age) {
+				return nil
+			}
+		case !errors.Is(err, redis.Nil):
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, val, 0)
+			if !expireAt.IsZero() {
+				pipe.ExpireAt(ctx, key, expireAt)
+			}
+			return nil
+		})
+		return err
+	}, key)
+}
+
+func (r *Storage) get(ctx context.Context, pattern string) (map[store.FeederPrice]*messages.Price, error) {
+	ps := map[store.FeederPrice]*messages.Price{}
+	err := r.scan(ctx, pattern, func(keys []string) error {
+		vals, err := r.client.MGet(ctx, keys...).Result()
+		if err != nil {
+			return err
+		}
+		for i, val := range vals {
+			s, ok := val.(string)
+			if !ok {
+				continue
+			}
+			fp, ok := parsePriceKey(keys[i])
+			if !ok {
+				continue
+			}
+			price, err := unmarshalPrice(s)
+			if err != nil {
+				continue
+			}
+			ps[fp] = price
+		}
+		return nil
+	})
+	return ps, err
+}
+
+func (r *Storage) scan(ctx context.Context, pattern string, fn func(keys []string) error) error {
+	var err error
+	var keys []string
+	var cursor uint64
+	for {
+		keys, cursor, err = r.client.Scan(ctx, cursor, pattern, 0).Result()
+		if err != nil {
+			return err
+		}
+		if len(keys) > 0 {
+			err = fn(keys)
+			if err != nil {
+				return err
+			}
+		}
+		if cursor == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+func unmarshalPrice(val string) (*messages.Price, error) {
+	price := &messages.Price{}
+	if err := price.Unmarshall([]byte(val)); err != nil {
+		return nil, err
+	}
+	if price.Price == nil {
+		return nil, errors.New("price is missing")
+	}
+	if string(price.Trace) == "null" {
+		price.Trace = nil
+	}
+	return price, nil
+}
+
+func priceKey(pair string, feeder ethereum.Address) string {
+	return fmt.Sprintf("%s:%s:%s", keyPrefix, pair, strings.ToLower(feeder.Hex()))
+}
+
+func wildcardPriceKey() string {
+	return fmt.Sprintf("%s:*", keyPrefix)
+}
+
+func wildcardPairPriceKey(pair string) string {
+	return fmt.Sprintf("%s:%s:*", keyPrefix, escapePattern(pair))
+}
+
+// parsePriceKey extracts the asset pair and feeder address from the key
+// created by the priceKey function.
+func parsePriceKey(key string) (store.FeederPrice, bool) {
+	key = strings.TrimPrefix(key, keyPrefix+":")
+	idx := strings.LastIndex(key, ":")
+	if idx < 0 || !ethereum.IsHexAddress(key[idx+1:]) {
+		return store.FeederPrice{}, false
+	}
+	return store.FeederPrice{
+		AssetPair: key[:idx],
+		Feeder:    ethereum.HexToAddress(key[idx+1:]),
+	}, true
+}
+
+// escapePattern escapes characters that have special meaning in Redis
+// glob-style patterns.
+func escapePattern(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		switch c {
+		case '*', '?', '[', ']', '\\', '^':
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// retry runs the f function until it returns nil. Maximum number of retries
+// and delay between them are defined in the retryAttempts and retryInterval
+// constants.
+func retry(f func() error) (err error) {
+	for i := 0; i < retryAttempts; i++ {
+		if i > 0 {
+			time.Sleep(retryInterval)
+		}
+		err = f()
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+var _ store.Storage = (*Storage)(nil)
@@ -0,0 +1,154 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package redis
+
+import (
+	"context"
+	"math/big"
+	"math/rand"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store/testutil"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+func TestMain(m *testing.M) {
+	rand.Seed(time.Now().Unix())
+	os.Exit(m.Run())
+}
+
+func TestRedis_Add(t *testing.T) {
+	ok, cfg := getConfig()
+	if !ok {
+		t.Skip()
+		return
+	}
+	ctx := context.Background()
+	pair := "AAA" + strconv.Itoa(rand.Int())
+	r, err := NewRedisStorage(cfg)
+	require.NoError(t, err)
+	defer r.Close()
+
+	p1 := testPrice(pair, 10, time.Now().Add(-time.Second))
+	p2 := testPrice(pair, 20, time.Now())
+
+	require.NoError(t, r.Add(ctx, testutil.Address1, p1))
+	require.NoError(t, r.Add(ctx, testutil.Address2, p1))
+
+	// Newer price should replace the older one:
+	require.NoError(t, r.Add(ctx, testutil.Address1, p2))
+
+	// Older price should be ignored:
+	require.NoError(t, r.Add(ctx, testutil.Address2, p2))
+	require.NoError(t, r.Add(ctx, testutil.Address2, p1))
+
+	ps, err := r.GetByAssetPair(ctx, pair)
+	require.NoError(t, err)
+	assert.Len(t, ps, 2)
+
+	p, err := r.GetByFeeder(ctx, pair, testutil.Address1)
+	require.NoError(t, err)
+	assert.Equal(t, p2.Price.Val, p.Price.Val)
+	assert.Equal(t, p2.Price.Age.Unix(), p.Price.Age.Unix())
+
+	p, err = r.GetByFeeder(ctx, pair, testutil.Address2)
+	require.NoError(t, err)
+	assert.Equal(t, p2.Price.Val, p.Price.Val)
+
+	all, err := r.GetAll(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, all, store.FeederPrice{AssetPair: pair, Feeder: testutil.Address1})
+	assert.Contains(t, all, store.FeederPrice{AssetPair: pair, Feeder: testutil.Address2})
+}
+
+func TestRedis_Add_Expired(t *testing.T) {
+	ok, cfg := getConfig()
+	if !ok {
+		t.Skip()
+		return
+	}
+	ctx := context.Background()
+	pair := "AAA" + strconv.Itoa(rand.Int())
+	r, err := NewRedisStorage(cfg)
+	require.NoError(t, err)
+	defer r.Close()
+
+	require.NoError(t, r.Add(ctx, testutil.Address1, testPrice(pair, 10, time.Now().Add(-2*cfg.TTL))))
+
+	p, err := r.GetByFeeder(ctx, pair, testutil.Address1)
+	require.NoError(t, err)
+	assert.Nil(t, p)
+}
+
+func Test_parsePriceKey(t *testing.T) {
+	addr := ethereum.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	tests := []struct {
+		key  string
+		want store.FeederPrice
+		ok   bool
+	}{
+		{key: priceKey("AAABBB", addr), want: store.FeederPrice{AssetPair: "AAABBB", Feeder: addr}, ok: true},
+		{key: priceKey("AAA:BBB", addr), want: store.FeederPrice{AssetPair: "AAA:BBB", Feeder: addr}, ok: true},
+		{key: "price:AAABBB:invalid", ok: false},
+		{key: "price", ok: false},
+	}
+	for n, tt := range tests {
+		t.Run(strconv.Itoa(n), func(t *testing.T) {
+			fp, ok := parsePriceKey(tt.key)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, fp)
+		})
+	}
+}
+
+func Test_escapePattern(t *testing.T) {
+	assert.Equal(t, "AAABBB", escapePattern("AAABBB"))
+	assert.Equal(t, `A\*A\?\[B\]`, escapePattern("A*A?[B]"))
+}
+
+func testPrice(pair string, val int64, age time.Time) *messages.Price {
+	return &messages.Price{
+		Price: &oracle.Price{
+			Wat: pair,
+			Val: big.NewInt(val),
+			Age: age,
+		},
+	}
+}
+
+func getConfig() (bool, Config) {
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	pass := os.Getenv("TEST_REDIS_PASS")
+	db, _ := strconv.Atoi(os.Getenv("TEST_REDIS_DB"))
+	if len(addr) == 0 {
+		return false, Config{}
+	}
+	return true, Config{
+		TTL:      time.Minute,
+		Address:  addr,
+		Password: pass,
+		DB:       db,
+	}
+}
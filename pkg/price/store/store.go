@@ -18,17 +18,32 @@ package store
 import (
 	"context"
 	"errors"
+	"io"
 	"math/big"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
+	"github.com/chronicleprotocol/oracle-suite/pkg/tracing"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
 
 const LoggerTag = "PRICE_STORE"
 
+// sizeMetricInterval is the interval at which the number of stored prices
+// is reported in metrics. It is not updated on every price, because it
+// requires fetching all prices for the pair from the storage.
+const sizeMetricInterval = 30 * time.Second
+
+var tracer = otel.Tracer("github.com/chronicleprotocol/oracle-suite/pkg/price/store")
+
 var ErrInvalidSignature = errors.New("received price has an invalid signature")
 var ErrInvalidPrice = errors.New("received price is invalid")
 var ErrUnknownPair = errors.New("received pair is not configured")
@@ -115,6 +130,7 @@ func (p *PriceStore) Start(ctx context.Context) error {
 	p.log.Info("Starting")
 	p.ctx = ctx
 	go p.priceCollectorRoutine()
+	go p.sizeMetricRoutine()
 	go p.contextCancelHandler()
 	return nil
 }
@@ -126,7 +142,18 @@ func (p *PriceStore) Wait() chan error {
 
 // Add adds a new price to the list. If a price from same feeder already
 // exists, the newer one will be used.
-func (p *PriceStore) Add(ctx context.Context, from ethereum.Address, msg *messages.Price) error {
+//
+// If the message contains a trace context, the operation is traced as
+// a child of the span in which the price was broadcast.
+func (p *PriceStore) Add(ctx context.Context, from ethereum.Address, msg *messages.Price) (err error) {
+	ctx, span := tracer.Start(tracing.Extract(ctx, msg.TraceContext), "PriceStore.Add", trace.WithAttributes(
+		attribute.String("pair", msg.Price.Wat),
+		attribute.String("feeder", from.String()),
+	))
+	defer func() {
+		tracing.SetStatus(span, err)
+		span.End()
+	}()
 	return p.storage.Add(ctx, from, msg)
 }
 
@@ -145,6 +172,28 @@ func (p *PriceStore) GetByFeeder(ctx context.Context, pair string, feeder ethere
 	return p.storage.GetByFeeder(ctx, pair, feeder)
 }
 
+// GetHistory returns prices from the history that match the given query.
+// If the storage does not support history, ErrHistoryNotSupported is
+// returned.
+func (p *PriceStore) GetHistory(ctx context.Context, q HistoryQuery) ([]HistoryPrice, error) {
+	h, ok := p.storage.(History)
+	if !ok {
+		return nil, ErrHistoryNotSupported
+	}
+	return h.Range(ctx, q)
+}
+
+// GetOHLC returns OHLC aggregates of prices from the history that match
+// the given query. If the storage does not support history,
+// ErrHistoryNotSupported is returned.
+func (p *PriceStore) GetOHLC(ctx context.Context, q HistoryQuery, interval time.Duration) ([]OHLC, error) {
+	ps, err := p.GetHistory(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	return AggregateOHLC(ps, interval), nil
+}
+
 func (p *PriceStore) collectPrice(price *messages.Price) error {
 	from, err := price.Price.From(p.signer)
 	if err != nil {
@@ -181,6 +230,33 @@ func (p *PriceStore) priceCollectorRoutine() {
 	}
 }
 
+func (p *PriceStore) sizeMetricRoutine() {
+	ticker := time.NewTicker(sizeMetricInterval)
+	defer ticker.Stop()
+	p.updateSizeMetric()
+	for {
+		select {
+		case <-p.ctx.Done():
+			return
+		case <-ticker.C:
+			p.updateSizeMetric()
+		}
+	}
+}
+
+// updateSizeMetric updates the number of stored prices for every supported
+// asset pair.
+func (p *PriceStore) updateSizeMetric() {
+	for _, pair := range p.pairs {
+		ps, err := p.storage.GetByAssetPair(p.ctx, pair)
+		if err != nil {
+			p.log.WithError(err).WithField("assetPair", pair).Warn("Unable to count stored prices")
+			continue
+		}
+		metrics.PriceStoreSize.WithLabelValues(pair).Set(float64(len(ps)))
+	}
+}
+
 func (p *PriceStore) handlePriceMessage(msg transport.ReceivedMessage) {
 	if msg.Error != nil {
 		p.log.WithError(msg.Error).Error("Unable to read prices from the transport layer")
@@ -192,6 +268,7 @@ func (p *PriceStore) handlePriceMessage(msg transport.ReceivedMessage) {
 		return
 	}
 	err := p.collectPrice(price)
+	p.recordMessageMetrics(price, err)
 	if err != nil {
 		p.log.
 			WithError(err).
@@ -205,9 +282,38 @@ func (p *PriceStore) handlePriceMessage(msg transport.ReceivedMessage) {
 	}
 }
 
+// recordMessageMetrics updates Prometheus metrics for a received price.
+// Prices for unsupported pairs are counted under the "unknown" pair to
+// avoid creating metrics for arbitrary pairs sent by other peers.
+func (p *PriceStore) recordMessageMetrics(price *messages.Price, err error) {
+	pair := "unknown"
+	if p.isPairSupported(price.Price.Wat) {
+		pair = price.Price.Wat
+	}
+	var result string
+	switch {
+	case err == nil:
+		result = metrics.ResultSuccess
+	case errors.Is(err, ErrInvalidSignature):
+		result = "invalid_signature"
+	case errors.Is(err, ErrUnknownPair):
+		result = "unknown_pair"
+	case errors.Is(err, ErrInvalidPrice):
+		result = "invalid_price"
+	default:
+		result = metrics.ResultError
+	}
+	metrics.PriceStoreMessages.WithLabelValues(pair, result).Inc()
+}
+
 // contextCancelHandler handles context cancellation.
 func (p *PriceStore) contextCancelHandler() {
 	defer func() { close(p.waitCh) }()
 	defer p.log.Info("Stopped")
 	<-p.ctx.Done()
+	if c, ok := p.storage.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			p.log.WithError(err).Error("Unable to close the storage")
+		}
+	}
 }
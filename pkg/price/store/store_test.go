@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	promtestutil "github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
@@ -80,6 +82,34 @@ func TestStore(t *testing.T) {
 	assert.Contains(t, toOraclePrices(xxxyyy), testutil.PriceXXXYYY2.Price)
 }
 
+func TestStore_Metrics(t *testing.T) {
+	ctx := context.Background()
+	ps, err := New(Config{
+		Signer:    &mocks.Signer{},
+		Storage:   NewMemoryStorage(),
+		Transport: local.New([]byte("test"), 0, nil),
+		Pairs:     []string{"AAABBB"},
+		Logger:    null.New(),
+	})
+	require.NoError(t, err)
+
+	accepted := metrics.PriceStoreMessages.WithLabelValues("AAABBB", metrics.ResultSuccess)
+	unknown := metrics.PriceStoreMessages.WithLabelValues("unknown", "unknown_pair")
+	prevAccepted := promtestutil.ToFloat64(accepted)
+	prevUnknown := promtestutil.ToFloat64(unknown)
+
+	ps.recordMessageMetrics(testutil.PriceAAABBB1, nil)
+	ps.recordMessageMetrics(testutil.PriceXXXYYY1, ErrUnknownPair)
+	assert.Equal(t, prevAccepted+1, promtestutil.ToFloat64(accepted))
+	assert.Equal(t, prevUnknown+1, promtestutil.ToFloat64(unknown))
+
+	require.NoError(t, ps.Add(ctx, testutil.Address1, testutil.PriceAAABBB1))
+	require.NoError(t, ps.Add(ctx, testutil.Address2, testutil.PriceAAABBB2))
+	ps.ctx = ctx
+	ps.updateSizeMetric()
+	assert.Equal(t, float64(2), promtestutil.ToFloat64(metrics.PriceStoreSize.WithLabelValues("AAABBB")))
+}
+
 func toOraclePrices(ps []*messages.Price) []*oracle.Price {
 	var r []*oracle.Price
 	for _, p := range ps {
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
 	"time"
 
@@ -27,6 +28,7 @@ import (
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
 )
 
 const LoggerTag = "RPCSPLITTER"
@@ -516,6 +518,7 @@ func (s *server) call(
 				if r := recover(); r != nil {
 					err = fmt.Errorf("panic: %s", r)
 				}
+				recordCallMetrics(n, method, time.Since(t), err)
 				switch {
 				case err != nil:
 					s.log.
@@ -571,6 +574,19 @@ func (s *server) call(
 	}
 }
 
+// recordCallMetrics updates Prometheus metrics for a single endpoint call.
+// Only the host of the endpoint is used as a label value, because endpoint
+// URLs often contain API keys.
+func recordCallMetrics(endpoint, method string, d time.Duration, err error) {
+	if u, uErr := url.Parse(endpoint); uErr == nil && u.Host != "" {
+		endpoint = u.Host
+	}
+	metrics.RPCSplitterCallDuration.WithLabelValues(endpoint, method).Observe(d.Seconds())
+	if err != nil {
+		metrics.RPCSplitterCallErrors.WithLabelValues(endpoint, method).Inc()
+	}
+}
+
 // removeTrailingNilArgs removes trailing nil parameters from the params
 // slice. Some RPC servers do not like null parameters and will return a
 // "bad request" error if they occur.
@@ -0,0 +1,91 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spectre
+
+import (
+	"encoding/hex"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+)
+
+// ShadowRecord describes a decision made by Spectre in the shadow mode.
+type ShadowRecord struct {
+	// Time is the time when the decision was made.
+	Time time.Time `json:"time"`
+	// AssetPair is the name of the asset pair.
+	AssetPair string `json:"assetPair"`
+	// Oracle is the address of the Oracle contract.
+	Oracle string `json:"oracle"`
+	// OracleVal is the current Oracle price.
+	OracleVal *big.Int `json:"oracleVal"`
+	// OracleAge is the time of the last Oracle update.
+	OracleAge time.Time `json:"oracleAge"`
+	// Bar is the Oracle quorum.
+	Bar int64 `json:"bar"`
+	// Median is the median of prices that would be used to update the Oracle.
+	Median *big.Int `json:"median,omitempty"`
+	// Spread is the spread between the Oracle price and the median, in
+	// percentage points. It is nil if the spread cannot be calculated.
+	Spread *float64 `json:"spread,omitempty"`
+	// Expired is true if the Oracle price is expired.
+	Expired bool `json:"expired"`
+	// Stale is true if the spread exceeds the configured one.
+	Stale bool `json:"stale"`
+	// Poke is true if Spectre would send an update transaction.
+	Poke bool `json:"poke"`
+	// Feeders is the list of feeders whose prices would be used.
+	Feeders []ethereum.Address `json:"feeders"`
+	// Calldata is the data of the transaction that would be sent.
+	Calldata hexutil.Bytes `json:"calldata,omitempty"`
+	// Error describes why the Oracle would not be updated even though it
+	// should be.
+	Error string `json:"error,omitempty"`
+}
+
+// Fields returns the record as log fields.
+func (r ShadowRecord) Fields() log.Fields {
+	f := log.Fields{
+		"assetPair": r.AssetPair,
+		"oracle":    r.Oracle,
+		"bar":       r.Bar,
+		"expired":   r.Expired,
+		"stale":     r.Stale,
+		"poke":      r.Poke,
+		"feeders":   r.Feeders,
+		"calldata":  hex.EncodeToString(r.Calldata),
+	}
+	if r.OracleVal != nil {
+		f["oracleVal"] = r.OracleVal.String()
+	}
+	if !r.OracleAge.IsZero() {
+		f["oracleAge"] = r.OracleAge.String()
+	}
+	if r.Median != nil {
+		f["median"] = r.Median.String()
+	}
+	if r.Spread != nil {
+		f["spread"] = *r.Spread
+	}
+	if r.Error != "" {
+		f["error"] = r.Error
+	}
+	return f
+}
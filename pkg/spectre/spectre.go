@@ -17,20 +17,33 @@ package spectre
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum/txmanager"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log"
 	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
 	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/tracing"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
 
 const LoggerTag = "SPECTRE"
 
+var tracer = otel.Tracer("github.com/chronicleprotocol/oracle-suite/pkg/spectre")
+
 type errNotEnoughPricesForQuorum struct {
 	AssetPair string
 }
@@ -68,6 +81,17 @@ type Spectre struct {
 	interval   time.Duration
 	log        log.Logger
 	pairs      map[string]*Pair
+	shadow     bool
+	shadowW    io.Writer
+	batcher    Batcher
+}
+
+// Batcher sends multiple calls in a single transaction.
+type Batcher interface {
+	// SendBatch sends the calls in a single transaction. Calls that would
+	// fail are skipped, in which case the corresponding error in the
+	// returned slice is not nil.
+	SendBatch(ctx context.Context, calls []ethereum.Call) (*ethereum.Hash, []error, error)
 }
 
 // Config is the configuration for Spectre.
@@ -79,6 +103,18 @@ type Config struct {
 	Interval time.Duration
 	// Pairs is the list supported pairs by Spectre with their configuration.
 	Pairs []*Pair
+	// ShadowMode enables the shadow mode. In the shadow mode, Spectre
+	// evaluates Oracles as usual, but instead of sending transactions, it
+	// records decisions as ShadowRecord's.
+	ShadowMode bool
+	// ShadowWriter is an optional writer to which shadow records are written
+	// as JSON lines. If nil, records are only logged. If the writer implements
+	// io.Closer, it will be closed when Spectre stops.
+	ShadowWriter io.Writer
+	// Batcher is an optional batcher used to send updates for all pairs
+	// that need to be updated in a single transaction. If nil, every
+	// Oracle is updated using a separate transaction.
+	Batcher Batcher
 	// Logger is a current logger interface used by the Spectre. The Logger is
 	// required to monitor asynchronous processes.
 	Logger log.Logger
@@ -96,9 +132,9 @@ type Pair struct {
 	// PriceExpiration is the maximum amount of time before price received
 	// from the feeder will be considered as expired.
 	PriceExpiration time.Duration
-	// Median is the instance of the oracle.Median which is the interface for
-	// the Oracle contract.
-	Median oracle.Median
+	// Median is the instance of the oracle.Oracle which is the interface for
+	// the Oracle contract, e.g. oracle.Median or oracle.Scribe.
+	Median oracle.Oracle
 }
 
 func NewSpectre(cfg Config) (*Spectre, error) {
@@ -118,6 +154,9 @@ func NewSpectre(cfg Config) (*Spectre, error) {
 		interval:   cfg.Interval,
 		pairs:      make(map[string]*Pair),
 		log:        cfg.Logger.WithField("tag", LoggerTag),
+		shadow:     cfg.ShadowMode,
+		shadowW:    cfg.ShadowWriter,
+		batcher:    cfg.Batcher,
 	}
 	for _, p := range cfg.Pairs {
 		r.pairs[p.AssetPair] = p
@@ -144,12 +183,99 @@ func (s *Spectre) Wait() chan error {
 	return s.waitCh
 }
 
+// update describes an Oracle update that should be sent.
+type update struct {
+	ctx    context.Context
+	pair   *Pair
+	prices []*oracle.Price
+	// links are links to the spans in which prices were broadcast.
+	links []trace.Link
+}
+
 // relay tries to update an Oracle contract for given pair. It'll return
 // transaction hash or nil if there is no need to update Oracle.
-func (s *Spectre) relay(assetPair string) (*ethereum.Hash, error) {
+//
+// The update is traced in a span. The transaction is sent in a child span
+// linked to the spans in which used prices were broadcast.
+func (s *Spectre) relay(assetPair string) (tx *ethereum.Hash, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ctx, span := tracer.Start(s.ctx, "spectre.relay", trace.WithAttributes(
+		attribute.String("pair", assetPair),
+	))
+	defer func() { endRelaySpan(span, tx, err) }()
+
+	u, err := s.prepare(ctx, assetPair)
+	if err != nil || u == nil {
+		return nil, err
+	}
+
+	// Send *actual* transaction to the Ethereum network:
+	ctx, pokeSpan := tracer.Start(u.ctx, "spectre.poke", trace.WithLinks(u.links...), trace.WithAttributes(
+		attribute.String("oracle", oracle.AddressString(u.pair.Median)),
+	))
+	tx, err = u.pair.Median.Poke(ctx, u.prices, true)
+	endRelaySpan(pokeSpan, tx, err)
+	return tx, err
+}
+
+// relayBatch works like relay, but sends updates for all pairs in a single
+// transaction using the batcher.
+func (s *Spectre) relayBatch() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	ctx, span := tracer.Start(s.ctx, "spectre.relayBatch")
+	defer span.End()
+
+	var (
+		urgency float64
+		pairs   []string
+		calls   []ethereum.Call
+		links   []trace.Link
+	)
+	for assetPair := range s.pairs {
+		u, err := s.prepare(ctx, assetPair)
+		if err == nil && u != nil {
+			var cd []byte
+			cd, err = u.pair.Median.PokeCalldata(u.ctx, u.prices)
+			if err == nil {
+				pairs = append(pairs, assetPair)
+				calls = append(calls, ethereum.Call{Address: u.pair.Median.Address(), Data: cd})
+				links = append(links, u.links...)
+				if uu := ethereum.UrgencyFromContext(u.ctx); uu > urgency {
+					urgency = uu
+				}
+				continue
+			}
+		}
+		s.logRelay(assetPair, nil, err)
+	}
+	if len(calls) == 0 {
+		return
+	}
+
+	// Send *actual* transaction to the Ethereum network:
+	span.SetAttributes(attribute.StringSlice("pairs", pairs))
+	ctx, pokeSpan := tracer.Start(ctx, "spectre.poke", trace.WithLinks(links...))
+	tx, errs, err := s.batcher.SendBatch(ethereum.WithUrgency(ctx, urgency), calls)
+	endRelaySpan(pokeSpan, tx, err)
+	for i, assetPair := range pairs {
+		switch {
+		case err != nil:
+			s.logRelay(assetPair, nil, err)
+		case errs[i] != nil:
+			s.logRelay(assetPair, nil, errs[i])
+		default:
+			s.logRelay(assetPair, tx, nil)
+		}
+	}
+}
+
+// prepare checks if the Oracle for the given pair needs to be updated. It
+// returns nil if there is no need to update Oracle.
+func (s *Spectre) prepare(ctx context.Context, assetPair string) (*update, error) {
 	pair, ok := s.pairs[assetPair]
 	if !ok {
 		return nil, errUnknownAsset{AssetPair: assetPair}
@@ -165,15 +291,15 @@ func (s *Spectre) relay(assetPair string) (*ethereum.Hash, error) {
 		return nil, errNoPrices{AssetPair: assetPair}
 	}
 
-	oracleQuorum, err := pair.Median.Bar(s.ctx)
+	oracleQuorum, err := pair.Median.Bar(ctx)
 	if err != nil {
 		return nil, err
 	}
-	oracleTime, err := pair.Median.Age(s.ctx)
+	oracleTime, err := pair.Median.Age(ctx)
 	if err != nil {
 		return nil, err
 	}
-	oraclePrice, err := pair.Median.Val(s.ctx)
+	oraclePrice, err := pair.Median.Val(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -210,21 +336,95 @@ func (s *Spectre) relay(assetPair string) (*ethereum.Hash, error) {
 			Debug("Feed")
 	}
 
+	if s.shadow {
+		return nil, s.recordShadow(ShadowRecord{
+			Time:      time.Now(),
+			AssetPair: assetPair,
+			Oracle:    oracle.AddressString(pair.Median),
+			OracleVal: oraclePrice,
+			OracleAge: oracleTime,
+			Bar:       oracleQuorum,
+			Expired:   isExpired,
+			Stale:     isStale,
+		}, pair, pricesList, spread)
+	}
+
 	if isExpired || isStale {
 		// Check if there are enough prices to achieve a quorum:
 		if int64(pricesList.len()) != oracleQuorum {
 			return nil, errNotEnoughPricesForQuorum{AssetPair: assetPair}
 		}
 
-		// Send *actual* transaction to the Ethereum network:
-		tx, err := pair.Median.Poke(s.ctx, pricesList.oraclePrices(), true)
-		return tx, err
+		// The older the Oracle price is, the more urgent is the update:
+		if pair.OracleExpiration > 0 {
+			ctx = ethereum.WithUrgency(ctx, urgency(time.Since(oracleTime), pair.OracleExpiration))
+		}
+
+		return &update{
+			ctx:    ctx,
+			pair:   pair,
+			prices: pricesList.oraclePrices(),
+			links:  broadcastLinks(pricesList.messages()),
+		}, nil
 	}
 
 	// There is no need to update Oracle:
 	return nil, nil
 }
 
+// urgency returns the urgency of the Oracle update for the given age of
+// the Oracle price. The urgency grows linearly from 0, for a new price, to 1,
+// when the price has been expired for another expiration period, so updates
+// of just expired Oracles do not pay the maximum fees right away.
+func urgency(age, expiration time.Duration) float64 {
+	u := float64(age) / float64(2*expiration)
+	if u > 1 {
+		return 1
+	}
+	return u
+}
+
+// recordShadow completes the shadow record with the data that would be used
+// to update the Oracle and writes it to the shadow writer.
+func (s *Spectre) recordShadow(r ShadowRecord, pair *Pair, pricesList *prices, spread float64) error {
+	if !math.IsInf(spread, 0) && !math.IsNaN(spread) {
+		r.Spread = &spread
+	}
+	if pricesList.len() > 0 {
+		r.Median = pricesList.median()
+	}
+	for _, price := range pricesList.oraclePrices() {
+		if from, err := price.From(s.signer); err == nil {
+			r.Feeders = append(r.Feeders, *from)
+		}
+	}
+	if r.Expired || r.Stale {
+		if int64(pricesList.len()) != r.Bar {
+			r.Error = errNotEnoughPricesForQuorum{AssetPair: r.AssetPair}.Error()
+		} else {
+			cd, err := pair.Median.PokeCalldata(s.ctx, pricesList.oraclePrices())
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.Poke = true
+				r.Calldata = cd
+			}
+		}
+	}
+	s.log.
+		WithFields(r.Fields()).
+		Info("Shadow mode decision")
+	if s.shadowW == nil {
+		return nil
+	}
+	b, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	_, err = s.shadowW.Write(append(b, '\n'))
+	return err
+}
+
 // relayerLoop creates a asynchronous loop which tries to send an update
 // to an Oracle contract at a specified interval.
 func (s *Spectre) relayerLoop() {
@@ -240,36 +440,83 @@ func (s *Spectre) relayerLoop() {
 				ticker.Stop()
 				return
 			case <-ticker.C:
+				if s.batcher != nil {
+					s.relayBatch()
+					continue
+				}
 				for assetPair := range s.pairs {
 					tx, err := s.relay(assetPair)
-
-					// Print log in case of an error:
-					if err != nil {
-						s.log.
-							WithFields(log.Fields{"assetPair": assetPair}).
-							WithError(err).
-							Warn("Unable to update Oracle")
-					}
-					// Print log if there was no need to update prices:
-					if err == nil && tx == nil {
-						s.log.
-							WithFields(log.Fields{"assetPair": assetPair}).
-							Info("Oracle price is still valid")
-					}
-					// Print log if Oracle update transaction was sent:
-					if tx != nil {
-						s.log.
-							WithFields(log.Fields{"assetPair": assetPair, "tx": tx.String()}).
-							Info("Oracle updated")
-					}
+					s.logRelay(assetPair, tx, err)
 				}
 			}
 		}
 	}()
 }
 
+// logRelay prints the result of an Oracle update.
+func (s *Spectre) logRelay(assetPair string, tx *ethereum.Hash, err error) {
+	switch {
+	case errors.Is(err, txmanager.ErrTransactionInFlight):
+		metrics.SpectrePokes.WithLabelValues(assetPair, "in_flight").Inc()
+		// Print log if the previous transaction is not mined yet:
+		s.log.
+			WithFields(log.Fields{"assetPair": assetPair}).
+			Info("Waiting for the previous Oracle update to be mined")
+	case err != nil:
+		metrics.SpectrePokes.WithLabelValues(assetPair, metrics.ResultError).Inc()
+		// Print log in case of an error:
+		s.log.
+			WithFields(log.Fields{"assetPair": assetPair}).
+			WithError(err).
+			Warn("Unable to update Oracle")
+	case tx != nil:
+		metrics.SpectrePokes.WithLabelValues(assetPair, metrics.ResultSuccess).Inc()
+		// Print log if Oracle update transaction was sent:
+		s.log.
+			WithFields(log.Fields{"assetPair": assetPair, "tx": tx.String()}).
+			Info("Oracle updated")
+	case !s.shadow:
+		metrics.SpectrePokes.WithLabelValues(assetPair, "skipped").Inc()
+		// Print log if there was no need to update prices:
+		s.log.
+			WithFields(log.Fields{"assetPair": assetPair}).
+			Info("Oracle price is still valid")
+	}
+}
+
+// endRelaySpan records the result of an Oracle update in the span and ends
+// the span.
+func endRelaySpan(span trace.Span, tx *ethereum.Hash, err error) {
+	switch {
+	case errors.Is(err, txmanager.ErrTransactionInFlight):
+		span.SetAttributes(attribute.Bool("inFlight", true))
+	case err != nil:
+		tracing.SetStatus(span, err)
+	case tx != nil:
+		span.SetAttributes(attribute.String("tx", tx.String()))
+	}
+	span.End()
+}
+
+// broadcastLinks returns links to the spans in which given prices were
+// broadcast.
+func broadcastLinks(ms []*messages.Price) []trace.Link {
+	var links []trace.Link
+	for _, m := range ms {
+		if l, ok := tracing.Link(m.TraceContext); ok {
+			links = append(links, l)
+		}
+	}
+	return links
+}
+
 func (s *Spectre) contextCancelHandler() {
 	defer func() { close(s.waitCh) }()
 	defer s.log.Info("Stopped")
 	<-s.ctx.Done()
+	if c, ok := s.shadowW.(io.Closer); ok {
+		if err := c.Close(); err != nil {
+			s.log.WithError(err).Error("Unable to close the shadow writer")
+		}
+	}
 }
@@ -0,0 +1,251 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package spectre
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	ethereumMocks "github.com/chronicleprotocol/oracle-suite/pkg/ethereum/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/oracle"
+	oracleMocks "github.com/chronicleprotocol/oracle-suite/pkg/price/oracle/mocks"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/local"
+	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
+)
+
+var (
+	testFeeder1 = ethereum.HexToAddress("0x2d800d93b065ce011af83f316cef9f0d005b0aa4")
+	testFeeder2 = ethereum.HexToAddress("0x8eb3daaf5cb4138f5f96711c09c0cfd0288a36e9")
+	testOracle  = ethereum.HexToAddress("0xe0F30cb149fAADC7247E953746Be9BbBB6B5751f")
+)
+
+func newTestSpectre(t *testing.T, cfg Config, prices map[ethereum.Address]*messages.Price) *Spectre {
+	sig := &ethereumMocks.Signer{}
+	for addr, price := range prices {
+		addr := addr
+		sig.On("Recover", price.Price.Signature(), mock.Anything).Return(&addr, nil)
+	}
+	var pairs []string
+	for _, p := range cfg.Pairs {
+		pairs = append(pairs, p.AssetPair)
+	}
+	ps, err := store.New(store.Config{
+		Storage:   store.NewMemoryStorage(),
+		Signer:    sig,
+		Transport: local.New([]byte("test"), 0, nil),
+		Pairs:     pairs,
+	})
+	require.NoError(t, err)
+	for addr, price := range prices {
+		require.NoError(t, ps.Add(context.Background(), addr, price))
+	}
+	cfg.Signer = sig
+	cfg.PriceStore = ps
+	s, err := NewSpectre(cfg)
+	require.NoError(t, err)
+	s.ctx = context.Background()
+	return s
+}
+
+type testBatcher struct {
+	calls []ethereum.Call
+	hash  *ethereum.Hash
+}
+
+func (b *testBatcher) SendBatch(_ context.Context, calls []ethereum.Call) (*ethereum.Hash, []error, error) {
+	b.calls = calls
+	return b.hash, make([]error, len(calls)), nil
+}
+
+func newTestPrice(val int64, age time.Time, v byte) *messages.Price {
+	return newTestPairPrice("AAABBB", val, age, v)
+}
+
+func newTestPairPrice(pair string, val int64, age time.Time, v byte) *messages.Price {
+	return &messages.Price{Price: &oracle.Price{
+		Wat: pair,
+		Val: big.NewInt(val),
+		Age: age,
+		V:   v,
+	}}
+}
+
+func newTestMedian(val int64, age time.Time, bar int64) *oracleMocks.Median {
+	return newTestMedianAt(testOracle, val, age, bar)
+}
+
+func newTestMedianAt(address ethereum.Address, val int64, age time.Time, bar int64) *oracleMocks.Median {
+	m := &oracleMocks.Median{}
+	m.On("Address").Return(address)
+	m.On("Bar", mock.Anything).Return(bar, nil)
+	m.On("Age", mock.Anything).Return(age, nil)
+	m.On("Val", mock.Anything).Return(big.NewInt(val), nil)
+	return m
+}
+
+func TestSpectre_relay(t *testing.T) {
+	now := time.Now()
+	median := newTestMedian(10, now.Add(-time.Hour), 2)
+	s := newTestSpectre(t, Config{
+		Pairs: []*Pair{{
+			AssetPair:        "AAABBB",
+			OracleSpread:     1,
+			OracleExpiration: time.Minute,
+			PriceExpiration:  time.Minute,
+			Median:           median,
+		}},
+	}, map[ethereum.Address]*messages.Price{
+		testFeeder1: newTestPrice(10, now, 1),
+		testFeeder2: newTestPrice(10, now, 2),
+	})
+
+	hash := &ethereum.Hash{1}
+	median.On("Poke", mock.Anything, mock.Anything, true).Return(hash, nil)
+
+	tx, err := s.relay("AAABBB")
+	require.NoError(t, err)
+	assert.Equal(t, hash, tx)
+	median.AssertCalled(t, "Poke", mock.Anything, mock.Anything, true)
+}
+
+func TestSpectre_relay_ShadowMode(t *testing.T) {
+	now := time.Now()
+	buf := &bytes.Buffer{}
+	median := newTestMedian(10, now.Add(-time.Hour), 2)
+	s := newTestSpectre(t, Config{
+		ShadowMode:   true,
+		ShadowWriter: buf,
+		Pairs: []*Pair{{
+			AssetPair:        "AAABBB",
+			OracleSpread:     1,
+			OracleExpiration: time.Minute,
+			PriceExpiration:  time.Minute,
+			Median:           median,
+		}},
+	}, map[ethereum.Address]*messages.Price{
+		testFeeder1: newTestPrice(12, now, 1),
+		testFeeder2: newTestPrice(14, now, 2),
+	})
+
+	median.On("PokeCalldata", mock.Anything, mock.Anything).Return([]byte{0xAA, 0xBB}, nil)
+
+	tx, err := s.relay("AAABBB")
+	require.NoError(t, err)
+	assert.Nil(t, tx)
+	median.AssertNotCalled(t, "Poke", mock.Anything, mock.Anything, mock.Anything)
+
+	var r ShadowRecord
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &r))
+	assert.Equal(t, "AAABBB", r.AssetPair)
+	assert.Equal(t, testOracle.String(), r.Oracle)
+	assert.Equal(t, big.NewInt(10), r.OracleVal)
+	assert.Equal(t, int64(2), r.Bar)
+	assert.Equal(t, big.NewInt(13), r.Median)
+	assert.InDelta(t, 30, *r.Spread, 0.0001)
+	assert.True(t, r.Expired)
+	assert.True(t, r.Stale)
+	assert.True(t, r.Poke)
+	assert.ElementsMatch(t, []ethereum.Address{testFeeder1, testFeeder2}, r.Feeders)
+	assert.Equal(t, []byte{0xAA, 0xBB}, []byte(r.Calldata))
+	assert.Empty(t, r.Error)
+}
+
+func TestSpectre_relay_ShadowMode_NotEnoughPrices(t *testing.T) {
+	now := time.Now()
+	buf := &bytes.Buffer{}
+	median := newTestMedian(10, now.Add(-time.Hour), 3)
+	s := newTestSpectre(t, Config{
+		ShadowMode:   true,
+		ShadowWriter: buf,
+		Pairs: []*Pair{{
+			AssetPair:        "AAABBB",
+			OracleSpread:     1,
+			OracleExpiration: time.Minute,
+			PriceExpiration:  time.Minute,
+			Median:           median,
+		}},
+	}, map[ethereum.Address]*messages.Price{
+		testFeeder1: newTestPrice(10, now, 1),
+	})
+
+	tx, err := s.relay("AAABBB")
+	require.NoError(t, err)
+	assert.Nil(t, tx)
+	median.AssertNotCalled(t, "PokeCalldata", mock.Anything, mock.Anything)
+
+	var r ShadowRecord
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &r))
+	assert.False(t, r.Poke)
+	assert.Nil(t, r.Calldata)
+	assert.NotEmpty(t, r.Error)
+}
+
+func TestSpectre_relayBatch(t *testing.T) {
+	now := time.Now()
+	oracle1 := ethereum.HexToAddress("0x01")
+	oracle2 := ethereum.HexToAddress("0x02")
+	oracle3 := ethereum.HexToAddress("0x03")
+	median1 := newTestMedianAt(oracle1, 10, now.Add(-time.Hour), 1)
+	median2 := newTestMedianAt(oracle2, 10, now.Add(-time.Hour), 1)
+	median3 := newTestMedianAt(oracle3, 10, now, 1)
+	batcher := &testBatcher{hash: &ethereum.Hash{1}}
+	s := newTestSpectre(t, Config{
+		Batcher: batcher,
+		Pairs: []*Pair{
+			{AssetPair: "AAABBB", OracleSpread: 1, OracleExpiration: time.Minute, PriceExpiration: time.Minute, Median: median1},
+			{AssetPair: "XXXYYY", OracleSpread: 1, OracleExpiration: time.Minute, PriceExpiration: time.Minute, Median: median2},
+			{AssetPair: "FOOBAR", OracleSpread: 1, OracleExpiration: time.Minute, PriceExpiration: time.Minute, Median: median3},
+		},
+	}, map[ethereum.Address]*messages.Price{
+		testFeeder1: newTestPairPrice("AAABBB", 10, now, 1),
+		testFeeder2: newTestPairPrice("XXXYYY", 10, now, 2),
+	})
+	fooBar := newTestPairPrice("FOOBAR", 10, now, 3)
+	s.signer.(*ethereumMocks.Signer).On("Recover", fooBar.Price.Signature(), mock.Anything).Return(&testFeeder1, nil)
+	require.NoError(t, s.priceStore.Add(context.Background(), testFeeder1, fooBar))
+
+	median1.On("PokeCalldata", mock.Anything, mock.Anything).Return([]byte{1}, nil)
+	median2.On("PokeCalldata", mock.Anything, mock.Anything).Return([]byte{2}, nil)
+
+	s.relayBatch()
+
+	// Only expired Oracles must be updated, in a single transaction:
+	assert.ElementsMatch(t, []ethereum.Call{
+		{Address: oracle1, Data: []byte{1}},
+		{Address: oracle2, Data: []byte{2}},
+	}, batcher.calls)
+	median1.AssertNotCalled(t, "Poke", mock.Anything, mock.Anything, mock.Anything)
+	median2.AssertNotCalled(t, "Poke", mock.Anything, mock.Anything, mock.Anything)
+	median3.AssertNotCalled(t, "PokeCalldata", mock.Anything, mock.Anything)
+}
+
+func Test_urgency(t *testing.T) {
+	assert.Equal(t, 0.0, urgency(0, time.Hour))
+	assert.Equal(t, 0.25, urgency(30*time.Minute, time.Hour))
+	assert.Equal(t, 0.5, urgency(time.Hour, time.Hour))
+	assert.Equal(t, 0.75, urgency(90*time.Minute, time.Hour))
+	assert.Equal(t, 1.0, urgency(3*time.Hour, time.Hour))
+}
@@ -60,6 +60,29 @@ type PullPriceResp struct {
 	Price *messages.Price
 }
 
+type PullHistoryArg struct {
+	FilterAssetPair string
+	FilterFeeder    string
+	From            time.Time
+	To              time.Time
+}
+
+type PullHistoryResp struct {
+	Prices []store.HistoryPrice
+}
+
+type PullOHLCArg struct {
+	FilterAssetPair string
+	FilterFeeder    string
+	From            time.Time
+	To              time.Time
+	Interval        time.Duration
+}
+
+type PullOHLCResp struct {
+	OHLC []store.OHLC
+}
+
 func (n *API) PublishPrice(arg *PublishPriceArg, _ *Nothing) error {
 	n.log.
 		WithFields(arg.Price.Price.Fields(n.signer)).
@@ -134,3 +157,59 @@ func (n *API) PullPrice(arg *PullPriceArg, resp *PullPriceResp) error {
 
 	return nil
 }
+
+func (n *API) PullHistory(arg *PullHistoryArg, resp *PullHistoryResp) error {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), defaultRPCTimeout)
+	defer ctxCancel()
+
+	n.log.
+		WithField("assetPair", arg.FilterAssetPair).
+		WithField("feeder", arg.FilterFeeder).
+		WithField("from", arg.From).
+		WithField("to", arg.To).
+		Info("Pull history")
+
+	prices, err := n.priceStore.GetHistory(ctx, historyQuery(arg.FilterAssetPair, arg.FilterFeeder, arg.From, arg.To))
+	if err != nil {
+		return err
+	}
+
+	*resp = PullHistoryResp{Prices: prices}
+
+	return nil
+}
+
+func (n *API) PullOHLC(arg *PullOHLCArg, resp *PullOHLCResp) error {
+	ctx, ctxCancel := context.WithTimeout(context.Background(), defaultRPCTimeout)
+	defer ctxCancel()
+
+	n.log.
+		WithField("assetPair", arg.FilterAssetPair).
+		WithField("feeder", arg.FilterFeeder).
+		WithField("from", arg.From).
+		WithField("to", arg.To).
+		WithField("interval", arg.Interval).
+		Info("Pull OHLC")
+
+	ohlc, err := n.priceStore.GetOHLC(ctx, historyQuery(arg.FilterAssetPair, arg.FilterFeeder, arg.From, arg.To), arg.Interval)
+	if err != nil {
+		return err
+	}
+
+	*resp = PullOHLCResp{OHLC: ohlc}
+
+	return nil
+}
+
+func historyQuery(assetPair, feeder string, from, to time.Time) store.HistoryQuery {
+	q := store.HistoryQuery{
+		AssetPair: assetPair,
+		From:      from,
+		To:        to,
+	}
+	if feeder != "" {
+		addr := ethereum.HexToAddress(feeder)
+		q.Feeder = &addr
+	}
+	return q
+}
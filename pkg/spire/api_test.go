@@ -52,6 +52,7 @@ var (
 	agent      *Agent
 	spire      *Client
 	priceStore *store.PriceStore
+	historyDir string
 	ctxCancel  context.CancelFunc
 )
 
@@ -67,8 +68,16 @@ func newTestInstances() (*Agent, *Client) {
 		messages.PriceV1MessageName: (*messages.Price)(nil),
 	})
 	_ = tra.Start(ctx)
+	historyDir, err = os.MkdirTemp("", "spire-history")
+	if err != nil {
+		panic(err)
+	}
+	storage, err := store.NewHistoryStorage(store.NewMemoryStorage(), historyDir, 0)
+	if err != nil {
+		panic(err)
+	}
 	priceStore, err = store.New(store.Config{
-		Storage:   store.NewMemoryStorage(),
+		Storage:   storage,
 		Signer:    sig,
 		Transport: tra,
 		Pairs:     []string{"AAABBB", "XXXYYY"},
@@ -122,6 +131,7 @@ func TestMain(m *testing.M) {
 	<-agent.Wait()
 	<-spire.Wait()
 	<-priceStore.Wait()
+	_ = os.RemoveAll(historyDir)
 
 	os.Exit(retCode)
 }
@@ -181,6 +191,42 @@ func TestClient_PullPrices_ByFeeder(t *testing.T) {
 	assertEqualPrices(t, testPriceAAABBB, prices[0])
 }
 
+func TestClient_PullHistory(t *testing.T) {
+	var err error
+	var prices []store.HistoryPrice
+
+	err = spire.PublishPrice(testPriceAAABBB)
+	assert.NoError(t, err)
+
+	wait(func() bool {
+		prices, err = spire.PullHistory("AAABBB", testAddress.String(), time.Time{}, time.Time{})
+		return len(prices) != 0
+	}, time.Second)
+
+	assert.NoError(t, err)
+	assert.Len(t, prices, 1)
+	assert.Equal(t, testAddress, prices[0].Feeder)
+	assertEqualPrices(t, testPriceAAABBB, prices[0].Price)
+}
+
+func TestClient_PullOHLC(t *testing.T) {
+	var err error
+	var ohlc []store.OHLC
+
+	err = spire.PublishPrice(testPriceAAABBB)
+	assert.NoError(t, err)
+
+	wait(func() bool {
+		ohlc, err = spire.PullOHLC("AAABBB", "", time.Time{}, time.Time{}, time.Hour)
+		return len(ohlc) != 0
+	}, time.Second)
+
+	assert.NoError(t, err)
+	assert.Len(t, ohlc, 1)
+	assert.Equal(t, testPriceAAABBB.Price.Val, ohlc[0].Open)
+	assert.Equal(t, 1, ohlc[0].Count)
+}
+
 func assertEqualPrices(t *testing.T, expected, given *messages.Price) {
 	je, _ := json.Marshal(expected)
 	jg, _ := json.Marshal(given)
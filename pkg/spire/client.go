@@ -19,8 +19,10 @@ import (
 	"context"
 	"errors"
 	"net/rpc"
+	"time"
 
 	"github.com/chronicleprotocol/oracle-suite/pkg/ethereum"
+	"github.com/chronicleprotocol/oracle-suite/pkg/price/store"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/messages"
 )
 
@@ -91,6 +93,35 @@ func (c *Client) PullPrice(assetPair string, feeder string) (*messages.Price, er
 	return resp.Price, nil
 }
 
+func (c *Client) PullHistory(assetPair string, feeder string, from, to time.Time) ([]store.HistoryPrice, error) {
+	resp := &PullHistoryResp{}
+	err := c.rpc.Call("API.PullHistory", PullHistoryArg{
+		FilterAssetPair: assetPair,
+		FilterFeeder:    feeder,
+		From:            from,
+		To:              to,
+	}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Prices, nil
+}
+
+func (c *Client) PullOHLC(assetPair string, feeder string, from, to time.Time, interval time.Duration) ([]store.OHLC, error) {
+	resp := &PullOHLCResp{}
+	err := c.rpc.Call("API.PullOHLC", PullOHLCArg{
+		FilterAssetPair: assetPair,
+		FilterFeeder:    feeder,
+		From:            from,
+		To:              to,
+		Interval:        interval,
+	}, resp)
+	if err != nil {
+		return nil, err
+	}
+	return resp.OHLC, nil
+}
+
 func (c *Client) contextCancelHandler() {
 	defer func() { close(c.waitCh) }()
 	<-c.ctx.Done()
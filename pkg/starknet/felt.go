@@ -19,8 +19,13 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// selectorMask is used to truncate Keccak hashes to 250 bits.
+var selectorMask = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 250), big.NewInt(1))
+
 type Felt struct {
 	*big.Int
 }
@@ -31,6 +36,36 @@ func HexToFelt(s string) *Felt {
 	return f
 }
 
+// BigToFelt returns the felt with the given value.
+func BigToFelt(i *big.Int) *Felt {
+	return &Felt{Int: new(big.Int).Set(i)}
+}
+
+// BytesToFelt returns the felt with the value of the given big-endian
+// bytes.
+func BytesToFelt(b []byte) *Felt {
+	return &Felt{Int: new(big.Int).SetBytes(b)}
+}
+
+// StringToFelt encodes a short string (up to 31 characters) as a felt.
+func StringToFelt(s string) *Felt {
+	return BytesToFelt([]byte(s))
+}
+
+// Selector returns the entry point selector for the given function name.
+func Selector(name string) *Felt {
+	h := new(big.Int).SetBytes(crypto.Keccak256([]byte(name)))
+	return &Felt{Int: h.And(h, selectorMask)}
+}
+
+// ShortString decodes the felt as a short string.
+func (f Felt) ShortString() string {
+	if f.Int == nil {
+		return ""
+	}
+	return string(f.Bytes())
+}
+
 func (f Felt) MarshalJSON() ([]byte, error) {
 	return []byte(fmt.Sprintf(`"0x%s"`, f.Text(16))), nil
 }
@@ -85,3 +85,17 @@ func Test_Felt_Marshal(t *testing.T) {
 		})
 	}
 }
+
+func Test_Selector(t *testing.T) {
+	assert.Equal(
+		t,
+		"83afd3f4caedc6eebf44246fe54e38c95e3179a5ec9ea81740eca5b482d12e",
+		Selector("transfer").Text(16),
+	)
+}
+
+func Test_StringToFelt(t *testing.T) {
+	f := StringToFelt("ETHUSD")
+	assert.Equal(t, "455448555344", f.Text(16))
+	assert.Equal(t, "ETHUSD", f.ShortString())
+}
@@ -50,6 +50,20 @@ func (c *Sequencer) GetBlockByNumber(ctx context.Context, blockNumber uint64) (*
 	return args.Get(0).(*starknet.Block), args.Error(1)
 }
 
+func (c *Sequencer) CallContract(ctx context.Context, call *starknet.Call) ([]*starknet.Felt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	args := c.Called(ctx, call)
+	return args.Get(0).([]*starknet.Felt), args.Error(1)
+}
+
+func (c *Sequencer) AddTransaction(ctx context.Context, tx *starknet.Transaction) (*starknet.AddTransactionResult, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	args := c.Called(ctx, tx)
+	return args.Get(0).(*starknet.AddTransactionResult), args.Error(1)
+}
+
 func (c *Sequencer) Calls() []mock.Call {
 	c.mu.Lock()
 	defer c.mu.Unlock()
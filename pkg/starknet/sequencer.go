@@ -16,6 +16,7 @@
 package starknet
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -23,6 +24,8 @@ import (
 	"net/http"
 )
 
+const TransactionTypeInvoke = "INVOKE_FUNCTION"
+
 type Sequencer struct {
 	endpoint   string
 	httpClient http.Client
@@ -44,29 +47,64 @@ func (s *Sequencer) GetBlockByNumber(ctx context.Context, blockNumber uint64) (*
 	return s.getBlock(ctx, fmt.Sprintf("%d", blockNumber))
 }
 
+// CallContract calls the contract's function on the pending block without
+// creating a transaction.
+func (s *Sequencer) CallContract(ctx context.Context, call *Call) ([]*Felt, error) {
+	url := fmt.Sprintf("%s/feeder_gateway/call_contract?blockNumber=pending", s.endpoint)
+	var res *CallResult
+	if err := s.do(ctx, "POST", url, call, &res); err != nil {
+		return nil, err
+	}
+	return res.Result, nil
+}
+
+// AddTransaction submits the transaction to the sequencer.
+func (s *Sequencer) AddTransaction(ctx context.Context, tx *Transaction) (*AddTransactionResult, error) {
+	url := fmt.Sprintf("%s/gateway/add_transaction", s.endpoint)
+	var res *AddTransactionResult
+	if err := s.do(ctx, "POST", url, tx, &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
 func (s *Sequencer) getBlock(ctx context.Context, blockNumber string) (*Block, error) {
 	url := fmt.Sprintf("%s/feeder_gateway/get_block?blockNumber=%s", s.endpoint, blockNumber)
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
+	var block *Block
+	if err := s.do(ctx, "GET", url, nil, &block); err != nil {
 		return nil, err
 	}
+	return block, nil
+}
+
+func (s *Sequencer) do(ctx context.Context, method, url string, data, result interface{}) error {
+	var reqBody io.Reader
+	if data != nil {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(b)
+	}
+	req, err := http.NewRequest(method, url, reqBody)
+	if err != nil {
+		return err
+	}
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 	req = req.WithContext(ctx)
 	res, err := s.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer res.Body.Close()
-	if res.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", res.StatusCode)
-	}
 	body, err := io.ReadAll(res.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	var block *Block
-	err = json.Unmarshal(body, &block)
-	if err != nil {
-		return nil, err
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code: %d: %s", res.StatusCode, string(body))
 	}
-	return block, nil
+	return json.Unmarshal(body, result)
 }
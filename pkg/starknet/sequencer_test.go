@@ -0,0 +1,91 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package starknet
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSequencer_CallContract(t *testing.T) {
+	var call *Call
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/feeder_gateway/call_contract", r.URL.Path)
+		assert.Equal(t, "pending", r.URL.Query().Get("blockNumber"))
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &call))
+		_, _ = w.Write([]byte(`{"result":["0x2a"]}`))
+	}))
+	defer srv.Close()
+
+	seq := NewSequencer(srv.URL, http.Client{})
+	res, err := seq.CallContract(context.Background(), &Call{
+		ContractAddress:    HexToFelt("0x1"),
+		EntryPointSelector: Selector("read"),
+		Calldata:           []*Felt{},
+		Signature:          []*Felt{},
+	})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, int64(42), res[0].Int64())
+	assert.Equal(t, Selector("read").Int, call.EntryPointSelector.Int)
+}
+
+func TestSequencer_AddTransaction(t *testing.T) {
+	var tx *Transaction
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/gateway/add_transaction", r.URL.Path)
+		b, _ := io.ReadAll(r.Body)
+		require.NoError(t, json.Unmarshal(b, &tx))
+		_, _ = w.Write([]byte(`{"code":"TRANSACTION_RECEIVED","transaction_hash":"0xdeadbeef"}`))
+	}))
+	defer srv.Close()
+
+	seq := NewSequencer(srv.URL, http.Client{})
+	res, err := seq.AddTransaction(context.Background(), &Transaction{
+		Type:               TransactionTypeInvoke,
+		ContractAddress:    HexToFelt("0x1"),
+		EntryPointSelector: Selector("poke"),
+		Calldata:           []*Felt{HexToFelt("0x2")},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "TRANSACTION_RECEIVED", res.Code)
+	assert.Equal(t, "deadbeef", res.TransactionHash.Text(16))
+	assert.Equal(t, TransactionTypeInvoke, tx.Type)
+	assert.Equal(t, int64(2), tx.Calldata[0].Int64())
+}
+
+func TestSequencer_UnexpectedStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"code":"StarknetErrorCode.ENTRY_POINT_NOT_FOUND_IN_CONTRACT"}`))
+	}))
+	defer srv.Close()
+
+	seq := NewSequencer(srv.URL, http.Client{})
+	_, err := seq.CallContract(context.Background(), &Call{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ENTRY_POINT_NOT_FOUND_IN_CONTRACT")
+}
@@ -39,6 +39,26 @@ type Transaction struct {
 	EntryPointType      string  `json:"entry_point_type,omitempty"`
 	Calldata            []*Felt `json:"calldata,omitempty"`
 	MaxFee              *Felt   `json:"max_fee,omitempty"`
+	Signature           []*Felt `json:"signature,omitempty"`
+	Version             *Felt   `json:"version,omitempty"`
+}
+
+// Call is a call to the contract's function that does not create
+// a transaction.
+type Call struct {
+	ContractAddress    *Felt   `json:"contract_address"`
+	EntryPointSelector *Felt   `json:"entry_point_selector"`
+	Calldata           []*Felt `json:"calldata"`
+	Signature          []*Felt `json:"signature"`
+}
+
+type CallResult struct {
+	Result []*Felt `json:"result"`
+}
+
+type AddTransactionResult struct {
+	Code            string `json:"code"`
+	TransactionHash *Felt  `json:"transaction_hash"`
 }
 
 type BuiltinInstanceCounter struct {
@@ -0,0 +1,193 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	"go.opentelemetry.io/otel/trace"
+
+	suite "github.com/chronicleprotocol/oracle-suite"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log"
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+const LoggerTag = "TRACING"
+
+// shutdownTimeout is the maximum amount of time spent on exporting
+// remaining spans after the context is canceled.
+const shutdownTimeout = 5 * time.Second
+
+// propagator is used to propagate trace context between services.
+var propagator = propagation.TraceContext{}
+
+// Tracing exports spans created by the global OpenTelemetry tracer provider
+// to the OTLP collector using the HTTP protocol.
+//
+// Until the service is started, the global tracer provider is a no-op one,
+// so spans created by other packages are not recorded.
+type Tracing struct {
+	ctx    context.Context
+	waitCh chan error
+
+	endpoint    string
+	insecure    bool
+	serviceName string
+	sampleRatio float64
+	provider    *sdktrace.TracerProvider
+	log         log.Logger
+}
+
+// Config is the configuration for the Tracing.
+type Config struct {
+	// Endpoint is the address of the OTLP collector in the form "host:port".
+	Endpoint string
+	// Insecure disables TLS for the connection to the collector.
+	Insecure bool
+	// ServiceName is the name of the service reported to the collector.
+	ServiceName string
+	// SampleRatio is the fraction of traces to sample, between 0 and 1.
+	// Traces started from a remote span are sampled if the remote span
+	// was sampled.
+	SampleRatio float64
+	// Logger is a current logger used by the Tracing.
+	Logger log.Logger
+}
+
+// New returns a new instance of the Tracing struct.
+func New(cfg Config) (*Tracing, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("endpoint must not be empty")
+	}
+	if cfg.SampleRatio < 0 || cfg.SampleRatio > 1 {
+		return nil, errors.New("sample ratio must be between 0 and 1")
+	}
+	if cfg.Logger == nil {
+		cfg.Logger = null.New()
+	}
+	return &Tracing{
+		waitCh:      make(chan error),
+		endpoint:    cfg.Endpoint,
+		insecure:    cfg.Insecure,
+		serviceName: cfg.ServiceName,
+		sampleRatio: cfg.SampleRatio,
+		log:         cfg.Logger.WithField("tag", LoggerTag),
+	}, nil
+}
+
+// Start implements the supervisor.Service interface. It registers the
+// global tracer provider and the trace context propagator.
+func (t *Tracing) Start(ctx context.Context) error {
+	if t.ctx != nil {
+		return errors.New("service can be started only once")
+	}
+	if ctx == nil {
+		return errors.New("context must not be nil")
+	}
+	t.log.Infof("Starting")
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(t.endpoint)}
+	if t.insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	exp, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return fmt.Errorf("unable to create the OTLP exporter: %w", err)
+	}
+	t.ctx = ctx
+	t.provider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(t.sampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(t.serviceName),
+			semconv.ServiceVersionKey.String(suite.Version),
+		)),
+	)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		t.log.WithError(err).Warn("Tracing error")
+	}))
+	otel.SetTracerProvider(t.provider)
+	otel.SetTextMapPropagator(propagator)
+	go t.contextCancelHandler()
+	return nil
+}
+
+// Wait implements the supervisor.Service interface.
+func (t *Tracing) Wait() chan error {
+	return t.waitCh
+}
+
+func (t *Tracing) contextCancelHandler() {
+	defer func() { close(t.waitCh) }()
+	defer t.log.Info("Stopped")
+	<-t.ctx.Done()
+
+	// Parent context is already canceled, so a new one is required to
+	// export remaining spans:
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := t.provider.Shutdown(ctx); err != nil {
+		t.log.WithError(err).Error("Unable to export remaining spans")
+	}
+}
+
+// Inject returns the trace context of the span from the given context as
+// a map that can be sent to other services. If the context does not
+// contain a valid span, nil is returned.
+func Inject(ctx context.Context) map[string]string {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return nil
+	}
+	c := propagation.MapCarrier{}
+	propagator.Inject(ctx, c)
+	return c
+}
+
+// Extract returns a copy of the given context with the remote span context
+// from the trace context created by the Inject function. Spans started from
+// the returned context are children of the remote span.
+func Extract(ctx context.Context, tc map[string]string) context.Context {
+	if len(tc) == 0 {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier(tc))
+}
+
+// Link returns a link to the remote span from the trace context created by
+// the Inject function. The second return value is false if the trace
+// context does not contain a valid span.
+func Link(tc map[string]string) (trace.Link, bool) {
+	sc := trace.SpanContextFromContext(Extract(context.Background(), tc))
+	return trace.Link{SpanContext: sc}, sc.IsValid()
+}
+
+// SetStatus records the error in the span and sets the span status.
+func SetStatus(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
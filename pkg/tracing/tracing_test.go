@@ -0,0 +1,144 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package tracing
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/chronicleprotocol/oracle-suite/pkg/log/null"
+)
+
+// collectorStub is a minimal OTLP/HTTP collector which stores received spans.
+type collectorStub struct {
+	mu    sync.Mutex
+	spans []*tracepb.Span
+}
+
+func (c *collectorStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/v1/traces" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	b, err := io.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	req := &collectortrace.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(b, req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.mu.Lock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			c.spans = append(c.spans, ss.Spans...)
+		}
+	}
+	c.mu.Unlock()
+	res, _ := proto.Marshal(&collectortrace.ExportTraceServiceResponse{})
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(res)
+}
+
+func (c *collectorStub) span(name string) *tracepb.Span {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.spans {
+		if s.Name == name {
+			return s
+		}
+	}
+	return nil
+}
+
+func TestTracing(t *testing.T) {
+	col := &collectorStub{}
+	srv := httptest.NewServer(col)
+	defer srv.Close()
+
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	tr, err := New(Config{
+		Endpoint:    strings.TrimPrefix(srv.URL, "http://"),
+		Insecure:    true,
+		ServiceName: "test",
+		SampleRatio: 1,
+		Logger:      null.New(),
+	})
+	require.NoError(t, err)
+	require.NoError(t, tr.Start(ctx))
+
+	// Trace context is sent to a remote service and a child span is
+	// created there:
+	sctx, span := otel.Tracer("test").Start(ctx, "sender")
+	tc := Inject(sctx)
+	span.End()
+	_, child := otel.Tracer("test").Start(Extract(context.Background(), tc), "receiver")
+	child.End()
+
+	// Remaining spans are exported on shutdown:
+	cancelFunc()
+	<-tr.Wait()
+
+	sender := col.span("sender")
+	receiver := col.span("receiver")
+	require.NotNil(t, sender)
+	require.NotNil(t, receiver)
+	assert.Equal(t, sender.TraceId, receiver.TraceId)
+	assert.Equal(t, sender.SpanId, receiver.ParentSpanId)
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	_, err := New(Config{})
+	assert.Error(t, err)
+	_, err = New(Config{Endpoint: "localhost:4318", SampleRatio: 2})
+	assert.Error(t, err)
+}
+
+func TestInject(t *testing.T) {
+	// Without a span, there is nothing to propagate:
+	assert.Nil(t, Inject(context.Background()))
+
+	tp := sdktrace.NewTracerProvider()
+	ctx, span := tp.Tracer("test").Start(context.Background(), "test")
+	defer span.End()
+
+	tc := Inject(ctx)
+	require.Contains(t, tc, "traceparent")
+
+	l, ok := Link(tc)
+	assert.True(t, ok)
+	assert.Equal(t, span.SpanContext().TraceID(), l.SpanContext.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), l.SpanContext.SpanID())
+	assert.True(t, l.SpanContext.IsRemote())
+
+	_, ok = Link(nil)
+	assert.False(t, ok)
+}
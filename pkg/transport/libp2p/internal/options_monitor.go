@@ -22,6 +22,7 @@ import (
 	"github.com/libp2p/go-libp2p-core/network"
 	"github.com/multiformats/go-multiaddr"
 
+	"github.com/chronicleprotocol/oracle-suite/pkg/metrics"
 	"github.com/chronicleprotocol/oracle-suite/pkg/transport/libp2p/internal/sets"
 )
 
@@ -76,8 +77,10 @@ func (n *monitorNotifee) Stop() {
 func Monitor() Options {
 	return func(n *Node) error {
 		log := func() {
+			peerCount := len(n.host.Network().Peers())
+			metrics.TransportPeers.Set(float64(peerCount))
 			n.tsLog.get().
-				WithField("peerCount", len(n.host.Network().Peers())).
+				WithField("peerCount", peerCount).
 				Info("Connected peers")
 		}
 		notifeeCh := make(chan struct{})
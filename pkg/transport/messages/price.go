@@ -41,6 +41,11 @@ type Price struct {
 	Trace   json.RawMessage `json:"trace"`             // TODO: allow data in any format, not just JSON
 	Version string          `json:"version,omitempty"` // TODO: this should move to some meta field e.g. `feedVersion`
 
+	// TraceContext is the OpenTelemetry trace context of the span in which
+	// the price was broadcast. It is used to continue the trace on the
+	// receiving side.
+	TraceContext map[string]string `json:"traceContext,omitempty"`
+
 	// messageVersion is the version of the message. The value 0 corresponds to
 	// the price/v0 and 1 to the price/v1 message. Both messages contain the
 	// same data but the price/v1 uses protobuf to encode the data. After full
@@ -78,6 +83,13 @@ func (p *Price) MarshallBinary() ([]byte, error) {
 		if p.Price.Val != nil {
 			pbPrice.Val = p.Price.Val.Bytes()
 		}
+		if len(p.TraceContext) > 0 {
+			tc, err := json.Marshal(p.TraceContext)
+			if err != nil {
+				return nil, err
+			}
+			pbPrice.TraceContext = tc
+		}
 		data, err := proto.Marshal(pbPrice)
 		if err != nil {
 			return nil, err
@@ -130,6 +142,13 @@ func (p *Price) UnmarshallBinary(data []byte) error {
 		}
 		p.Trace = msg.Trace
 		p.Version = msg.Version
+		if len(msg.TraceContext) > 0 {
+			// The trace context is not required to process the price, so
+			// an invalid one is ignored:
+			if err := json.Unmarshal(msg.TraceContext, &p.TraceContext); err != nil {
+				p.TraceContext = nil
+			}
+		}
 	case 0:
 		if err := p.Unmarshall(data); err != nil {
 			return err
@@ -187,6 +206,12 @@ func (p *Price) copy() *Price {
 		c.Trace = make([]byte, len(p.Trace))
 		copy(c.Trace, p.Trace)
 	}
+	if p.TraceContext != nil {
+		c.TraceContext = make(map[string]string, len(p.TraceContext))
+		for k, v := range p.TraceContext {
+			c.TraceContext[k] = v
+		}
+	}
 	if p.Price.StarkS != nil {
 		c.Price.StarkS = make([]byte, len(p.Price.StarkS))
 		copy(c.Price.StarkS, p.Price.StarkS)
@@ -124,6 +124,32 @@ func TestPrice_Marshalling(t *testing.T) {
 			}).AsV1(),
 			wantErr: false,
 		},
+		// With trace context as V0:
+		{
+			price: (&Price{
+				messageVersion: 0,
+				Price:          &oracle.Price{},
+				Trace:          []byte("{}"),
+				Version:        "0.0.1",
+				TraceContext: map[string]string{
+					"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+				},
+			}).AsV0(),
+			wantErr: false,
+		},
+		// With trace context as V1:
+		{
+			price: (&Price{
+				messageVersion: 0,
+				Price:          &oracle.Price{},
+				Trace:          []byte("{}"),
+				Version:        "0.0.1",
+				TraceContext: map[string]string{
+					"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+				},
+			}).AsV1(),
+			wantErr: false,
+		},
 		// Too large message:
 		{
 			price: &Price{
@@ -179,6 +205,7 @@ func TestPrice_Marshalling(t *testing.T) {
 				assert.Equal(t, tt.price.Price.StarkS, price.Price.StarkS)
 				assert.Equal(t, tt.price.Price.StarkPK, price.Price.StarkPK)
 				assert.Equal(t, tt.price.Version, price.Version)
+				assert.Equal(t, tt.price.TraceContext, price.TraceContext)
 
 				if tt.price.messageVersion == 0 && tt.price.Trace == nil {
 					assert.Equal(t, json.RawMessage("null"), price.Trace)
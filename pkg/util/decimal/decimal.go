@@ -0,0 +1,262 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package decimal provides an arbitrary-precision number type used to carry
+// prices without the rounding errors of float64.
+package decimal
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// displayPrecision is the number of decimal places used by the String
+// method for numbers that cannot be represented exactly in the decimal
+// notation, e.g. 1/3.
+const displayPrecision = 18
+
+// Decimal is an immutable arbitrary-precision rational number. Because
+// all operations are exact, numbers such as 0.1 or results of divisions
+// do not accumulate rounding errors.
+//
+// The number is stored in its canonical form, so two Decimals with the same
+// value are equal when compared using the == operator or reflect.DeepEqual.
+// The zero value represents zero and is ready to use.
+type Decimal struct {
+	v string // canonical form returned by big.Rat.RatString, empty for zero
+}
+
+func fromRat(r *big.Rat) Decimal {
+	if r.Sign() == 0 {
+		return Decimal{}
+	}
+	return Decimal{v: r.RatString()}
+}
+
+// New returns a Decimal with the value of r.
+func New(r *big.Rat) Decimal {
+	if r == nil {
+		return Decimal{}
+	}
+	return fromRat(r)
+}
+
+// FromInt64 returns a Decimal with the value of i.
+func FromInt64(i int64) Decimal {
+	return fromRat(new(big.Rat).SetInt64(i))
+}
+
+// FromFloat64 returns a Decimal with the value of the shortest decimal
+// representation of f, so FromFloat64(0.1) is exactly 1/10 rather than
+// the nearest binary fraction. NaN and infinities are converted to zero.
+func FromFloat64(f float64) Decimal {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}
+	}
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(f, 'g', -1, 64))
+	return fromRat(r)
+}
+
+// FromBigInt returns a Decimal with the value of i divided by 10^decimals.
+// It is intended to be used with fixed-point numbers returned by smart
+// contracts.
+func FromBigInt(i *big.Int, decimals int) Decimal {
+	if i == nil {
+		return Decimal{}
+	}
+	r := new(big.Rat).SetInt(i)
+	if decimals > 0 {
+		r.Quo(r, new(big.Rat).SetInt(pow10(decimals)))
+	} else if decimals < 0 {
+		r.Mul(r, new(big.Rat).SetInt(pow10(-decimals)))
+	}
+	return fromRat(r)
+}
+
+// FromString parses a number in the decimal notation, in the scientific
+// notation or as a fraction ("a/b").
+func FromString(s string) (Decimal, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(s))
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal number: %q", s)
+	}
+	return fromRat(r), nil
+}
+
+func (d Decimal) rat() *big.Rat {
+	r := new(big.Rat)
+	if d.v != "" {
+		r.SetString(d.v)
+	}
+	return r
+}
+
+// Rat returns the value of d as a rational number.
+func (d Decimal) Rat() *big.Rat {
+	return d.rat()
+}
+
+// IsZero returns true if d is zero.
+func (d Decimal) IsZero() bool {
+	return d.v == ""
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.rat().Sign()
+}
+
+// Cmp compares d and x and returns -1, 0 or 1.
+func (d Decimal) Cmp(x Decimal) int {
+	return d.rat().Cmp(x.rat())
+}
+
+// Add returns d + x.
+func (d Decimal) Add(x Decimal) Decimal {
+	return fromRat(new(big.Rat).Add(d.rat(), x.rat()))
+}
+
+// Sub returns d - x.
+func (d Decimal) Sub(x Decimal) Decimal {
+	return fromRat(new(big.Rat).Sub(d.rat(), x.rat()))
+}
+
+// Mul returns d * x.
+func (d Decimal) Mul(x Decimal) Decimal {
+	return fromRat(new(big.Rat).Mul(d.rat(), x.rat()))
+}
+
+// Quo returns d / x. It panics if x is zero.
+func (d Decimal) Quo(x Decimal) Decimal {
+	return fromRat(new(big.Rat).Quo(d.rat(), x.rat()))
+}
+
+// Inv returns 1 / d. It panics if d is zero.
+func (d Decimal) Inv() Decimal {
+	return fromRat(new(big.Rat).Inv(d.rat()))
+}
+
+// Abs returns the absolute value of d.
+func (d Decimal) Abs() Decimal {
+	return fromRat(new(big.Rat).Abs(d.rat()))
+}
+
+// Float64 returns the nearest float64 value of d.
+func (d Decimal) Float64() float64 {
+	f, _ := d.rat().Float64()
+	return f
+}
+
+// BigInt returns d multiplied by 10^decimals, truncated toward zero.
+func (d Decimal) BigInt(decimals int) *big.Int {
+	r := d.rat()
+	n := new(big.Int).Set(r.Num())
+	if decimals > 0 {
+		n.Mul(n, pow10(decimals))
+	}
+	den := new(big.Int).Set(r.Denom())
+	if decimals < 0 {
+		den.Mul(den, pow10(-decimals))
+	}
+	return n.Quo(n, den)
+}
+
+// String returns d in the decimal notation. Numbers that cannot be
+// represented exactly are rounded to 18 decimal places.
+func (d Decimal) String() string {
+	r := d.rat()
+	if r.IsInt() {
+		return r.Num().String()
+	}
+	prec, exact := decimalPlaces(r.Denom())
+	if !exact || prec > displayPrecision {
+		prec = displayPrecision
+	}
+	s := r.FloatString(prec)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// MarshalText implements the encoding.TextMarshaler interface. Unlike
+// String, it never loses precision: numbers that cannot be represented in
+// the decimal notation are encoded as fractions.
+func (d Decimal) MarshalText() ([]byte, error) {
+	r := d.rat()
+	if r.IsInt() {
+		return []byte(r.Num().String()), nil
+	}
+	if prec, exact := decimalPlaces(r.Denom()); exact {
+		return []byte(r.FloatString(prec)), nil
+	}
+	return []byte(r.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*d = Decimal{}
+		return nil
+	}
+	v, err := FromString(string(text))
+	if err != nil {
+		return err
+	}
+	*d = v
+	return nil
+}
+
+// GobEncode implements the gob.GobEncoder interface.
+func (d Decimal) GobEncode() ([]byte, error) {
+	return d.MarshalText()
+}
+
+// GobDecode implements the gob.GobDecoder interface.
+func (d *Decimal) GobDecode(data []byte) error {
+	return d.UnmarshalText(data)
+}
+
+// decimalPlaces returns the number of decimal places needed to represent
+// a fraction with the given denominator. If the fraction cannot be
+// represented exactly, false is returned.
+func decimalPlaces(den *big.Int) (int, bool) {
+	d := new(big.Int).Set(den)
+	two, five, zero := big.NewInt(2), big.NewInt(5), new(big.Int)
+	m := new(big.Int)
+	twos, fives := 0, 0
+	for m.Mod(d, two).Cmp(zero) == 0 {
+		d.Quo(d, two)
+		twos++
+	}
+	for m.Mod(d, five).Cmp(zero) == 0 {
+		d.Quo(d, five)
+		fives++
+	}
+	if d.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	if twos > fives {
+		return twos, true
+	}
+	return fives, true
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
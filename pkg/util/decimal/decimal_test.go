@@ -0,0 +1,116 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package decimal
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustDecimal(t *testing.T, s string) Decimal {
+	d, err := FromString(s)
+	require.NoError(t, err)
+	return d
+}
+
+func TestDecimal_ZeroValue(t *testing.T) {
+	var d Decimal
+	assert.True(t, d.IsZero())
+	assert.Equal(t, 0, d.Sign())
+	assert.Equal(t, "0", d.String())
+	assert.Equal(t, "1", d.Add(FromInt64(1)).String())
+}
+
+func TestFromFloat64(t *testing.T) {
+	assert.Equal(t, "0.1", FromFloat64(0.1).String())
+	assert.Equal(t, 0, FromFloat64(0.1).Cmp(mustDecimal(t, "0.1")))
+	assert.Equal(t, "-123.456", FromFloat64(-123.456).String())
+	assert.Equal(t, "100000000000000000000", FromFloat64(1e20).String())
+	assert.True(t, FromFloat64(math.NaN()).IsZero())
+	assert.True(t, FromFloat64(math.Inf(1)).IsZero())
+}
+
+func TestFromBigInt(t *testing.T) {
+	i, _ := new(big.Int).SetString("1234567890123456789012", 10)
+	assert.Equal(t, "1234.567890123456789012", FromBigInt(i, 18).String())
+	assert.Equal(t, "1234567890123456789012", FromBigInt(i, 0).String())
+	assert.Equal(t, "123456789012345678901200", FromBigInt(i, -2).String())
+	assert.True(t, FromBigInt(nil, 18).IsZero())
+}
+
+func TestFromString(t *testing.T) {
+	assert.Equal(t, "1.5", mustDecimal(t, "1.5").String())
+	assert.Equal(t, "1500", mustDecimal(t, "1.5e3").String())
+	assert.Equal(t, "0.5", mustDecimal(t, "1/2").String())
+	_, err := FromString("abc")
+	assert.Error(t, err)
+}
+
+func TestDecimal_Arithmetic(t *testing.T) {
+	a := mustDecimal(t, "0.1")
+	b := mustDecimal(t, "0.2")
+	assert.Equal(t, "0.3", a.Add(b).String())
+	assert.Equal(t, "-0.1", a.Sub(b).String())
+	assert.Equal(t, "0.02", a.Mul(b).String())
+	assert.Equal(t, "0.5", a.Quo(b).String())
+	assert.Equal(t, "10", a.Inv().String())
+	assert.Equal(t, -1, a.Cmp(b))
+
+	// Division results are exact, so the inverse of the inverse is the
+	// same number.
+	c := mustDecimal(t, "3")
+	assert.Equal(t, "0.333333333333333333", c.Inv().String())
+	assert.Equal(t, 0, c.Inv().Inv().Cmp(c))
+
+	// Operations do not modify operands.
+	assert.Equal(t, "0.1", a.String())
+}
+
+func TestDecimal_BigInt(t *testing.T) {
+	assert.Equal(t, "1234500000000000000000", mustDecimal(t, "1234.5").BigInt(18).String())
+	assert.Equal(t, "333333333333333333", mustDecimal(t, "1/3").BigInt(18).String())
+	assert.Equal(t, "-1", mustDecimal(t, "-1.9").BigInt(0).String())
+	assert.Equal(t, "12", mustDecimal(t, "1234.5").BigInt(-2).String())
+}
+
+func TestDecimal_Marshal(t *testing.T) {
+	for _, s := range []string{"0", "12", "-1.25", "1/3", "0.000000000000000000000001"} {
+		d := mustDecimal(t, s)
+
+		j, err := json.Marshal(d)
+		require.NoError(t, err)
+		var dj Decimal
+		require.NoError(t, json.Unmarshal(j, &dj))
+		assert.Equal(t, 0, d.Cmp(dj), s)
+
+		var buf bytes.Buffer
+		require.NoError(t, gob.NewEncoder(&buf).Encode(d))
+		var dg Decimal
+		require.NoError(t, gob.NewDecoder(&buf).Decode(&dg))
+		assert.Equal(t, 0, d.Cmp(dg), s)
+	}
+
+	j, err := json.Marshal(mustDecimal(t, "1/3"))
+	require.NoError(t, err)
+	assert.Equal(t, `"1/3"`, string(j))
+}
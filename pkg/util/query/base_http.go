@@ -16,10 +16,12 @@
 package query
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
+	"strconv"
 	"time"
 )
 
@@ -42,6 +44,19 @@ type HTTPRequest struct {
 	Body    io.Reader
 }
 
+// HTTPStatusError is returned when a server responds with an unexpected
+// status code. RetryAfter is set if the response contained a valid
+// Retry-After header.
+type HTTPStatusError struct {
+	URL        string
+	StatusCode int
+	RetryAfter time.Duration
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("failed to make HTTP request to %s, got %d status code", e.URL, e.StatusCode)
+}
+
 // HTTPResponse default query engine response
 type HTTPResponse struct {
 	Body  []byte
@@ -54,6 +69,13 @@ type HTTPResponse struct {
 // Note for `timeout` waiting this function uses `time.Sleep()` so it will block execution flow.
 // Better to be used in go-routine.
 func MakeHTTPRequest(r *HTTPRequest) *HTTPResponse {
+	return makeHTTPRequest(r, nil)
+}
+
+// makeHTTPRequest works like MakeHTTPRequest, but if beforeRetry is not nil,
+// it is called before every retry. If it returns an error, the request is
+// not retried and that error is returned.
+func makeHTTPRequest(r *HTTPRequest, beforeRetry func() error) *HTTPResponse {
 	if r == nil {
 		return &HTTPResponse{
 			Error: fmt.Errorf("failed to make HTTP request to `nil`"),
@@ -71,9 +93,22 @@ func MakeHTTPRequest(r *HTTPRequest) *HTTPResponse {
 
 	for step <= r.Retry {
 		res, err = doMakeHTTPRequest(r)
+		if isRateLimitError(err) {
+			// Retrying would only make it worse.
+			break
+		}
 		if err != nil {
-			time.Sleep(defaultDelayBetweenRetries)
 			step++
+			if step > r.Retry {
+				break
+			}
+			time.Sleep(defaultDelayBetweenRetries)
+			if beforeRetry != nil {
+				if wErr := beforeRetry(); wErr != nil {
+					err = wErr
+					break
+				}
+			}
 			continue
 		}
 		// All ok no `err` received
@@ -117,11 +152,45 @@ func doMakeHTTPRequest(r *HTTPRequest) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
+
 	if resp.StatusCode != 200 && resp.StatusCode != 201 {
-		return nil, fmt.Errorf("failed to make HTTP request to %s, got %d status code", r.URL, resp.StatusCode)
+		return nil, &HTTPStatusError{
+			URL:        r.URL,
+			StatusCode: resp.StatusCode,
+			RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()),
+		}
 	}
 
-	defer resp.Body.Close()
-
 	return ioutil.ReadAll(resp.Body)
 }
+
+// isRateLimitError returns true if the error was caused by the server
+// rate limiting our requests.
+func isRateLimitError(err error) bool {
+	var sErr *HTTPStatusError
+	if !errors.As(err, &sErr) {
+		return false
+	}
+	return sErr.StatusCode == http.StatusTooManyRequests ||
+		(sErr.StatusCode == http.StatusServiceUnavailable && sErr.RetryAfter > 0)
+}
+
+// parseRetryAfter parses the value of the Retry-After header, which may be
+// either a number of seconds or an HTTP date. Zero is returned if the value
+// is empty or invalid.
+func parseRetryAfter(v string, now time.Time) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if s, err := strconv.Atoi(v); err == nil {
+		if s < 0 {
+			return 0
+		}
+		return time.Duration(s) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil && t.After(now) {
+		return t.Sub(now)
+	}
+	return 0
+}
@@ -15,28 +15,83 @@
 
 package query
 
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultHTTPCacheTTL is the default time for which successful GET responses
+// are cached by HTTPWorkerPool.
+const DefaultHTTPCacheTTL = time.Second
+
 // WorkerPool interface for any Query Engine worker pools
 type WorkerPool interface {
 	Query(req *HTTPRequest) *HTTPResponse
 }
 
+// HTTPWorkerPoolConfig is the configuration for HTTPWorkerPool.
+type HTTPWorkerPoolConfig struct {
+	// WorkerCount is the number of concurrent HTTP requests.
+	WorkerCount int
+	// CacheTTL is the time for which successful GET responses are cached.
+	// Identical requests made in that time, or while the first one is still
+	// in progress, share a single HTTP call. Zero disables caching.
+	CacheTTL time.Duration
+}
+
 // HTTPWorkerPool structure that contain WokerPool HTTP implementation
 // It implements worker pool that will do real HTTP calls to resources using `query.MakeHTTPRequest`
+//
+// Requests are throttled per host according to the limits registered with
+// WithRateLimit. If a host responds with 429 status code, further requests
+// to it fail immediately with ErrRateLimited until the time given in the
+// Retry-After header passes. Every retry of a failed request counts against
+// the limit too.
 type HTTPWorkerPool struct {
 	workerCount int
 	input       chan *asyncHTTPRequest
+	limiter     *rateLimiter
+	cacheTTL    time.Duration
+
+	mu       sync.Mutex
+	cache    map[string]cachedHTTPResponse
+	inFlight map[string]*inFlightHTTPRequest
 }
 
 type asyncHTTPRequest struct {
-	request  *HTTPRequest
-	response chan *HTTPResponse
+	request     *HTTPRequest
+	beforeRetry func() error
+	response    chan *HTTPResponse
+}
+
+type cachedHTTPResponse struct {
+	response *HTTPResponse
+	expires  time.Time
+}
+
+type inFlightHTTPRequest struct {
+	done     chan struct{}
+	response *HTTPResponse
 }
 
 // NewHTTPWorkerPool create new worker pool for queries
 func NewHTTPWorkerPool(workerCount int) *HTTPWorkerPool {
+	return NewHTTPWorkerPoolWithConfig(HTTPWorkerPoolConfig{WorkerCount: workerCount})
+}
+
+// NewHTTPWorkerPoolWithConfig create new worker pool for queries using
+// given configuration.
+func NewHTTPWorkerPoolWithConfig(cfg HTTPWorkerPoolConfig) *HTTPWorkerPool {
 	wp := &HTTPWorkerPool{
-		workerCount: workerCount,
-		input:       make(chan *asyncHTTPRequest, workerCount),
+		workerCount: cfg.WorkerCount,
+		input:       make(chan *asyncHTTPRequest, cfg.WorkerCount),
+		limiter:     newRateLimiter(),
+		cacheTTL:    cfg.CacheTTL,
+		cache:       make(map[string]cachedHTTPResponse),
+		inFlight:    make(map[string]*inFlightHTTPRequest),
 	}
 
 	for w := 0; w < wp.workerCount; w++ {
@@ -46,12 +101,69 @@ func NewHTTPWorkerPool(workerCount int) *HTTPWorkerPool {
 	return wp
 }
 
+// WithRateLimit returns a WorkerPool that shares workers, cache and host
+// state with wp, but applies the given limit to every host it sends
+// requests to. If several limits are registered for the same host, the
+// strictest one is used.
+func (wp *HTTPWorkerPool) WithRateLimit(limit RateLimit) WorkerPool {
+	return &rateLimitedWorkerPool{wp: wp, limit: limit}
+}
+
 // Query makes request to given Request
 // Under the hood it will wrap everything to async query and execute it using
 // worker pool.
 func (wp *HTTPWorkerPool) Query(req *HTTPRequest) *HTTPResponse {
+	if req == nil {
+		return MakeHTTPRequest(req)
+	}
+	key := wp.cacheKey(req)
+	if key == "" {
+		return wp.query(req)
+	}
+
+	wp.mu.Lock()
+	if c, ok := wp.cache[key]; ok && time.Now().Before(c.expires) {
+		wp.mu.Unlock()
+		return c.response
+	}
+	if f, ok := wp.inFlight[key]; ok {
+		wp.mu.Unlock()
+		<-f.done
+		return f.response
+	}
+	f := &inFlightHTTPRequest{done: make(chan struct{})}
+	wp.inFlight[key] = f
+	wp.mu.Unlock()
+
+	f.response = wp.query(req)
+
+	wp.mu.Lock()
+	delete(wp.inFlight, key)
+	if f.response.Error == nil {
+		now := time.Now()
+		for k, c := range wp.cache {
+			if !now.Before(c.expires) {
+				delete(wp.cache, k)
+			}
+		}
+		wp.cache[key] = cachedHTTPResponse{response: f.response, expires: now.Add(wp.cacheTTL)}
+	}
+	wp.mu.Unlock()
+	close(f.done)
+
+	return f.response
+}
+
+func (wp *HTTPWorkerPool) query(req *HTTPRequest) *HTTPResponse {
+	host := requestHost(req.URL)
+	if err := wp.limiter.wait(host); err != nil {
+		return &HTTPResponse{Error: err}
+	}
 	asyncReq := &asyncHTTPRequest{
-		request:  req,
+		request: req,
+		beforeRetry: func() error {
+			return wp.limiter.wait(host)
+		},
 		response: make(chan *HTTPResponse),
 	}
 	// Sending request
@@ -60,11 +172,42 @@ func (wp *HTTPWorkerPool) Query(req *HTTPRequest) *HTTPResponse {
 	res := <-asyncReq.response
 	// Have to close channel
 	close(asyncReq.response)
+	wp.limiter.update(host, res.Error)
 	return res
 }
 
+// cacheKey returns the key under which the response for the request is
+// cached. Empty string is returned for requests that must not be cached.
+func (wp *HTTPWorkerPool) cacheKey(req *HTTPRequest) string {
+	if wp.cacheTTL <= 0 || req.Body != nil {
+		return ""
+	}
+	if req.Method != "" && req.Method != http.MethodGet {
+		return ""
+	}
+	headers := make([]string, 0, len(req.Headers))
+	for k, v := range req.Headers {
+		headers = append(headers, k+":"+v)
+	}
+	sort.Strings(headers)
+	return req.URL + "\n" + strings.Join(headers, "\n")
+}
+
 func (wp *HTTPWorkerPool) worker() {
 	for req := range wp.input {
-		req.response <- MakeHTTPRequest(req.request)
+		req.response <- makeHTTPRequest(req.request, req.beforeRetry)
+	}
+}
+
+type rateLimitedWorkerPool struct {
+	wp    *HTTPWorkerPool
+	limit RateLimit
+}
+
+// Query implements the WorkerPool interface.
+func (r *rateLimitedWorkerPool) Query(req *HTTPRequest) *HTTPResponse {
+	if req != nil {
+		r.wp.limiter.setLimit(requestHost(req.URL), r.limit)
 	}
+	return r.wp.Query(req)
 }
@@ -0,0 +1,160 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package query
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPWorkerPool_Cache(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(50 * time.Millisecond)
+		rw.Write([]byte(serverResponse))
+	}))
+	defer srv.Close()
+
+	wp := NewHTTPWorkerPoolWithConfig(HTTPWorkerPoolConfig{WorkerCount: 5, CacheTTL: time.Minute})
+
+	// Concurrent identical requests must share a single HTTP call.
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res := wp.Query(&HTTPRequest{URL: srv.URL})
+			assert.NoError(t, res.Error)
+			assert.Equal(t, []byte(serverResponse), res.Body)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// Subsequent request must be served from the cache.
+	res := wp.Query(&HTTPRequest{URL: srv.URL})
+	require.NoError(t, res.Error)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// Requests with different headers, or with a body, are not shared.
+	wp.Query(&HTTPRequest{URL: srv.URL, Headers: map[string]string{"a": "b"}})
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	wp.Query(&HTTPRequest{URL: srv.URL, Method: http.MethodPost, Body: strings.NewReader("{}")})
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestHTTPWorkerPool_NoCache(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.Write([]byte(serverResponse))
+	}))
+	defer srv.Close()
+
+	wp := NewHTTPWorkerPool(1)
+	wp.Query(&HTTPRequest{URL: srv.URL})
+	wp.Query(&HTTPRequest{URL: srv.URL})
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestHTTPWorkerPool_RateLimit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Write([]byte(serverResponse))
+	}))
+	defer srv.Close()
+
+	wp := NewHTTPWorkerPool(5).WithRateLimit(RateLimit{RequestsPerSecond: 20, Burst: 2})
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		require.NoError(t, wp.Query(&HTTPRequest{URL: srv.URL}).Error)
+	}
+	// The first two requests use the burst, the next two have to wait 50ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
+func TestHTTPWorkerPool_TooManyRequests(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.Header().Set("Retry-After", "60")
+		rw.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	wp := NewHTTPWorkerPool(1)
+
+	res := wp.Query(&HTTPRequest{URL: srv.URL})
+	var sErr *HTTPStatusError
+	require.True(t, errors.As(res.Error, &sErr))
+	assert.Equal(t, http.StatusTooManyRequests, sErr.StatusCode)
+	assert.Equal(t, time.Minute, sErr.RetryAfter)
+	// A rate limited request must not be retried.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	// The host is blocked until the Retry-After time passes.
+	res = wp.Query(&HTTPRequest{URL: srv.URL})
+	assert.True(t, errors.Is(res.Error, ErrRateLimited))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
+
+func TestRateLimit_stricter(t *testing.T) {
+	assert.True(t, RateLimit{RequestsPerSecond: 1}.stricter(RateLimit{}))
+	assert.True(t, RateLimit{RequestsPerSecond: 1}.stricter(RateLimit{RequestsPerSecond: 2}))
+	assert.False(t, RateLimit{RequestsPerSecond: 2}.stricter(RateLimit{RequestsPerSecond: 1}))
+	assert.False(t, RateLimit{}.stricter(RateLimit{RequestsPerSecond: 1}))
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, time.Duration(0), parseRetryAfter("", now))
+	assert.Equal(t, 120*time.Second, parseRetryAfter("120", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("-1", now))
+	assert.Equal(t, 30*time.Second, parseRetryAfter("Fri, 01 Jan 2021 00:00:30 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("Thu, 31 Dec 2020 23:59:00 GMT", now))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("invalid", now))
+}
+
+func TestHTTPWorkerPool_RateLimitRetries(t *testing.T) {
+	var calls int32
+	var wp *HTTPWorkerPool
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		// Simulate a request that exhausted the limit while the failed
+		// request was waiting for a retry:
+		wp.limiter.host(req.Host).block(time.Now().Add(time.Minute))
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	wp = NewHTTPWorkerPool(1)
+
+	// Retries must go through the rate limiter, so the request is not
+	// retried while the host is blocked.
+	res := wp.Query(&HTTPRequest{URL: srv.URL, Retry: 3})
+	assert.True(t, errors.Is(res.Error, ErrRateLimited))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,174 @@
+//  Copyright (C) 2020 Maker Ecosystem Growth Holdings, INC.
+//
+//  This program is free software: you can redistribute it and/or modify
+//  it under the terms of the GNU Affero General Public License as
+//  published by the Free Software Foundation, either version 3 of the
+//  License, or (at your option) any later version.
+//
+//  This program is distributed in the hope that it will be useful,
+//  but WITHOUT ANY WARRANTY; without even the implied warranty of
+//  MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+//  GNU Affero General Public License for more details.
+//
+//  You should have received a copy of the GNU Affero General Public License
+//  along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package query
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// ErrRateLimited is returned when a request is not sent because the host
+// asked us to back off.
+var ErrRateLimited = errors.New("rate limited")
+
+// defaultRateLimitBackoff is used when a host responds with 429 status code
+// without a Retry-After header.
+const defaultRateLimitBackoff = 30 * time.Second
+
+// RateLimit describes the request quota for a single host.
+type RateLimit struct {
+	// RequestsPerSecond is the sustained number of requests allowed per
+	// second. Zero or less means no limit.
+	RequestsPerSecond float64
+	// Burst is the maximum number of requests that may be sent at once.
+	// If less than one, one is used.
+	Burst int
+}
+
+// stricter returns true if r allows fewer requests than l.
+func (r RateLimit) stricter(l RateLimit) bool {
+	if l.RequestsPerSecond <= 0 {
+		return r.RequestsPerSecond > 0
+	}
+	if r.RequestsPerSecond <= 0 {
+		return false
+	}
+	return r.RequestsPerSecond < l.RequestsPerSecond
+}
+
+// hostLimiter keeps the token bucket and back-off state for a single host.
+type hostLimiter struct {
+	mu           sync.Mutex
+	limit        RateLimit
+	tokens       float64
+	last         time.Time
+	blockedUntil time.Time
+}
+
+// reserve reserves a single token and returns the duration the caller has
+// to wait before sending the request. Tokens may go negative, in which case
+// subsequent callers are queued behind the current one.
+func (h *hostLimiter) reserve(now time.Time) time.Duration {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.limit.RequestsPerSecond <= 0 {
+		return 0
+	}
+	burst := float64(h.limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	if h.last.IsZero() {
+		h.tokens = burst
+	} else {
+		h.tokens = math.Min(burst, h.tokens+now.Sub(h.last).Seconds()*h.limit.RequestsPerSecond)
+	}
+	h.last = now
+	h.tokens--
+	if h.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-h.tokens / h.limit.RequestsPerSecond * float64(time.Second))
+}
+
+// block prevents requests to the host until the given time.
+func (h *hostLimiter) block(until time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if until.After(h.blockedUntil) {
+		h.blockedUntil = until
+	}
+}
+
+// blocked returns the time until which the host is blocked, if it is.
+func (h *hostLimiter) blocked(now time.Time) (time.Time, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.blockedUntil, now.Before(h.blockedUntil)
+}
+
+// rateLimiter manages hostLimiters for all hosts.
+type rateLimiter struct {
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{hosts: make(map[string]*hostLimiter)}
+}
+
+// setLimit sets the quota for the host. If the host already has a quota, the
+// stricter one is kept, so origins sharing a host cannot exceed it together.
+func (r *rateLimiter) setLimit(host string, limit RateLimit) {
+	h := r.host(host)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if limit.stricter(h.limit) {
+		h.limit = limit
+	}
+}
+
+func (r *rateLimiter) host(host string) *hostLimiter {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	h, ok := r.hosts[host]
+	if !ok {
+		h = &hostLimiter{}
+		r.hosts[host] = h
+	}
+	return h
+}
+
+// wait blocks until a request to the host may be sent. It returns
+// ErrRateLimited if the host asked us to back off.
+func (r *rateLimiter) wait(host string) error {
+	h := r.host(host)
+	now := time.Now()
+	if until, ok := h.blocked(now); ok {
+		return fmt.Errorf("%w: %s until %s", ErrRateLimited, host, until.Format(time.RFC3339))
+	}
+	if d := h.reserve(now); d > 0 {
+		time.Sleep(d)
+	}
+	return nil
+}
+
+// update blocks the host if the response indicates that we are rate limited.
+func (r *rateLimiter) update(host string, err error) {
+	if !isRateLimitError(err) {
+		return
+	}
+	var sErr *HTTPStatusError
+	errors.As(err, &sErr)
+	d := sErr.RetryAfter
+	if d <= 0 {
+		d = defaultRateLimitBackoff
+	}
+	r.host(host).block(time.Now().Add(d))
+}
+
+// requestHost returns the host part of the request URL.
+func requestHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}